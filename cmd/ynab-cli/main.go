@@ -2,11 +2,17 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"os"
+	"os/signal"
+	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joeyhipolito/ynab-cli/internal/api"
 	"github.com/joeyhipolito/ynab-cli/internal/cmd"
@@ -16,9 +22,35 @@ import (
 
 const version = "3.0.0"
 
+// Build metadata, injected at link time by release builds:
+//
+//	go build -ldflags "-X main.commit=$(git rev-parse --short HEAD) -X main.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	commit    = "unknown"
+	buildDate = "unknown"
+)
+
+// VersionOutput represents the JSON output for --version --json.
+type VersionOutput struct {
+	Version   string `json:"version"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+}
+
 func main() {
 	if err := run(); err != nil {
+		if errors.Is(err, context.Canceled) {
+			fmt.Fprintln(os.Stderr, "Interrupted")
+			os.Exit(130)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if api.IsNetworkError(err) {
+			// YNAB never answered, so nothing was rejected
+			fmt.Fprintln(os.Stderr, "\nCould not reach YNAB. Check your internet connection and try again.")
+		}
 		os.Exit(1)
 	}
 }
@@ -27,6 +59,17 @@ func run() error {
 	// Parse command line arguments
 	args := os.Args[1:]
 
+	// Global flags that take a value may come before the subcommand
+	// (ynab --env-file .env.ynab status); move them after it
+	var leading []string
+	for len(args) >= 2 && (args[0] == "--env-file" || args[0] == "--profile" || args[0] == "--budget") {
+		leading = append(leading, args[:2]...)
+		args = args[2:]
+	}
+	if len(args) > 0 && len(leading) > 0 {
+		args = append(append([]string{args[0]}, leading...), args[1:]...)
+	}
+
 	// Handle help and version flags
 	if len(args) == 0 || args[0] == "--help" || args[0] == "-h" {
 		printUsage()
@@ -34,38 +77,101 @@ func run() error {
 	}
 
 	if args[0] == "--version" || args[0] == "-v" {
-		fmt.Printf("ynab version %s\n", version)
-		return nil
+		return printVersion(len(args) > 1 && args[1] == "--json")
 	}
 
 	// Parse subcommand
 	subcommand := args[0]
 	remainingArgs := args[1:]
 
-	// Check for global --json flag
-	jsonOutput := false
-	var filteredArgs []string
-	for _, arg := range remainingArgs {
-		if arg == "--json" {
-			jsonOutput = true
-		} else {
-			filteredArgs = append(filteredArgs, arg)
+	// Check for global flags
+	flags, err := parseGlobalFlags(subcommand, remainingArgs)
+	if err != nil {
+		return err
+	}
+	jsonOutput, csvOutput := flags.json, flags.csv
+	strictJSON, noCache, dryRun := flags.strictJSON, flags.noCache, flags.dryRun
+	profileFlag, budgetFlag, envFile := flags.profile, flags.budget, flags.envFile
+	tableStyle, color := flags.tableStyle, flags.color
+	maxBackoff, retryBudget := flags.maxBackoff, flags.retryBudget
+	filteredArgs := flags.args
+	if csvOutput {
+		if jsonOutput {
+			return fmt.Errorf("choose one of --json and --csv")
+		}
+		switch subcommand {
+		case "transactions", "balance", "budget", "categories", "payees":
+			cmd.SetCSVOutput(true)
+		default:
+			return fmt.Errorf("--csv is supported by transactions, balance, budget, categories and payees, not %s", subcommand)
+		}
+	}
+
+	if dryRun {
+		switch subcommand {
+		case "add", "edit", "move", "delete":
+		default:
+			return fmt.Errorf("--dry-run is supported by add, edit, move, delete, sweep, import and reconcile, not %s", subcommand)
+		}
+	}
+
+	// The env file goes into the environment before anything reads it
+	if envFile != "" {
+		if err := config.LoadEnvFile(envFile); err != nil {
+			return err
+		}
+	}
+
+	// --table-style and --plain win over table_style in the config
+	if tableStyle != "" {
+		if err := cmd.SetTableStyle(tableStyle); err != nil {
+			return err
+		}
+	} else if style := config.ResolveTableStyle(); style != "" {
+		if err := cmd.SetTableStyle(style); err != nil {
+			return fmt.Errorf("table_style in %s: %w", config.Path(), err)
 		}
 	}
 
+	// Only tables and summaries are colored; JSON and CSV stay plain
+	cmd.SetColorOutput(color && !jsonOutput && !csvOutput)
+
+	// --profile wins over YNAB_PROFILE; empty means the top-level settings
+	profile := config.ResolveProfile(profileFlag)
+
 	// Commands that don't require authentication
 	switch subcommand {
 	case "configure":
 		if len(filteredArgs) > 0 && filteredArgs[0] == "show" {
-			return cmd.ConfigureShowCmd(jsonOutput)
+			return cmd.ConfigureShowCmd(profile, jsonOutput)
 		}
 		return cmd.ConfigureCmd()
 	case "doctor":
-		return cmd.DoctorCmd(jsonOutput)
+		fix := false
+		for _, arg := range filteredArgs {
+			switch arg {
+			case "--fix":
+				fix = true
+			default:
+				return fmt.Errorf("unknown flag: %s", arg)
+			}
+		}
+		return cmd.DoctorCmd(profile, fix, jsonOutput)
+	case "alias":
+		return handleAliasCommand(filteredArgs, jsonOutput)
+	case "completion":
+		if len(filteredArgs) != 1 {
+			return fmt.Errorf("completion requires a shell\n\nUsage: ynab completion bash|zsh|fish")
+		}
+		return cmd.CompletionCmd(filteredArgs[0])
 	}
 
-	// Resolve access token: config file > environment variable
-	token := config.ResolveToken()
+	if err := config.CheckProfile(profile); err != nil {
+		return err
+	}
+
+	// Resolve access token: profile > env file > config file > environment variable
+	token := config.ResolveToken(profile)
 	if token == "" {
 		return fmt.Errorf("no access token found\n\nRun 'ynab configure' to set up, or set YNAB_ACCESS_TOKEN")
 	}
@@ -75,155 +181,453 @@ func run() error {
 	if err != nil {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
+	client.SetRetryLimits(maxBackoff, retryBudget)
+	client.SetStrictJSON(strictJSON)
+
+	// The circuit breaker carries over between commands via ~/.ynab
+	defer cmd.PersistBreaker(client)()
+
+	// Name resolution consults aliases before matching
+	cmd.SetAliases(config.ResolveAliases())
+	cmd.SetLookupCache(config.ResolveLookupCacheTTL(), noCache)
 
 	// Set default budget ID from config if available
-	budgetID := config.ResolveBudgetID()
+	budgetID := config.ResolveBudgetID(profile)
 	if budgetID != "" {
 		client.SetDefaultBudgetID(budgetID)
 	}
 
+	// Enable OAuth token refresh if configured (opt-in; PAT users are unaffected)
+	if cfg, err := config.Load(); err == nil {
+		if oauth := oauthRefreshConfig(cfg, token); oauth != nil {
+			client.SetOAuthConfig(oauth)
+		}
+	}
+
+	// Ctrl-C cancels the request in flight and any retry wait; a second
+	// Ctrl-C kills the process as usual
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	client = client.WithContext(ctx)
+
+	// --budget overrides the configured default for this run. Setting it on
+	// the client means the budgets are listed once, not by every command.
+	if budgetFlag != "" {
+		budgets, err := client.GetBudgets()
+		if err != nil {
+			return fmt.Errorf("failed to get budgets: %w", err)
+		}
+		names := make(map[string]string, len(budgets))
+		for _, b := range budgets {
+			names[b.ID] = b.Name
+		}
+		budgetID, err := config.ResolveBudgetByName(budgetFlag, names)
+		if err != nil {
+			return err
+		}
+		client.SetDefaultBudgetID(budgetID)
+	}
+
 	// Dispatch to appropriate command handler
 	switch subcommand {
 	case "status":
 		return cmd.StatusCmd(client, jsonOutput)
 
 	case "balance":
-		filter := ""
-		if len(filteredArgs) > 0 {
-			filter = filteredArgs[0]
-		}
-		return cmd.BalanceCmd(client, filter, jsonOutput)
+		return handleBalanceCommand(client, filteredArgs, jsonOutput)
 
 	case "budget":
-		return cmd.BudgetCmd(client, jsonOutput)
+		return handleBudgetCommand(client, filteredArgs, jsonOutput)
+
+	case "net-worth":
+		return handleNetWorthCommand(client, filteredArgs, jsonOutput)
+
+	case "stats":
+		return handleStatsCommand(client, filteredArgs, jsonOutput)
+
+	case "whoami":
+		return cmd.WhoamiCmd(client, jsonOutput)
 
 	case "categories":
-		return cmd.CategoriesCmd(client, jsonOutput)
+		return handleCategoriesCommand(client, filteredArgs, jsonOutput)
 
 	case "add":
-		return handleAddCommand(client, filteredArgs, jsonOutput)
+		return handleAddCommand(client, filteredArgs, dryRun, jsonOutput)
 
 	case "transactions":
 		return handleTransactionsCommand(client, filteredArgs, jsonOutput)
 
 	case "payees":
-		filter := ""
-		if len(filteredArgs) > 0 {
-			filter = filteredArgs[0]
-		}
-		return cmd.PayeesCmd(client, filter, jsonOutput)
+		return handlePayeesCommand(client, filteredArgs, jsonOutput)
+
+	case "search":
+		return handleSearchCommand(client, filteredArgs, jsonOutput)
 
 	case "months":
-		monthArg := ""
-		if len(filteredArgs) > 0 {
-			monthArg = filteredArgs[0]
-		}
-		return cmd.MonthsCmd(client, monthArg, jsonOutput)
+		return handleMonthsCommand(client, filteredArgs, jsonOutput)
 
 	case "edit":
-		return handleEditCommand(client, filteredArgs, jsonOutput)
+		return handleEditCommand(client, filteredArgs, dryRun, jsonOutput)
 
 	case "delete":
-		if len(filteredArgs) < 1 {
-			return fmt.Errorf("delete requires a transaction ID\n\nUsage: ynab delete <transaction_id>")
-		}
-		return cmd.DeleteCmd(client, filteredArgs[0], jsonOutput)
+		return handleDeleteCommand(client, filteredArgs, dryRun, jsonOutput)
+
+	case "restore":
+		return handleRestoreCommand(client, filteredArgs, jsonOutput)
+
+	case "undo":
+		return handleUndoCommand(client, filteredArgs, jsonOutput)
 
 	case "move":
-		return handleMoveCommand(client, filteredArgs, jsonOutput)
+		return handleMoveCommand(client, filteredArgs, dryRun, jsonOutput)
+
+	case "assign":
+		return handleAssignCommand(client, filteredArgs, jsonOutput)
+
+	case "transfer":
+		return handleTransferCommand(client, filteredArgs, jsonOutput)
+
+	case "reconcile":
+		return handleReconcileCommand(client, filteredArgs, jsonOutput)
+
+	case "approve":
+		return handleApproveCommand(client, filteredArgs, jsonOutput)
+
+	case "import":
+		return handleImportCommand(client, filteredArgs, jsonOutput)
+
+	case "sync":
+		full := false
+		for _, arg := range filteredArgs {
+			switch arg {
+			case "--full":
+				full = true
+			default:
+				return fmt.Errorf("unknown flag: %s", arg)
+			}
+		}
+		return cmd.SyncCmd(client, full, jsonOutput)
+
+	case "sweep":
+		return handleSweepCommand(client, filteredArgs, jsonOutput)
 
 	case "scheduled":
-		return cmd.ScheduledCmd(client, jsonOutput)
+		return handleScheduledCommand(client, filteredArgs, jsonOutput)
+
+	case "schedule":
+		return handleScheduleCommand(client, filteredArgs, jsonOutput)
+
+	case "report":
+		return handleReportCommand(client, filteredArgs, jsonOutput)
 
 	case "add-account":
 		return handleAddAccountCommand(client, filteredArgs, jsonOutput)
 
+	case "account":
+		return handleAccountCommand(client, filteredArgs, jsonOutput)
+
+	case "export":
+		return handleExportCommand(client, filteredArgs, jsonOutput)
+
+	case "__complete":
+		// Hidden: names for the completion scripts
+		if len(filteredArgs) != 1 {
+			return fmt.Errorf("usage: ynab __complete payees|categories|accounts")
+		}
+		return cmd.CompleteCmd(client, filteredArgs[0])
+
 	default:
 		return fmt.Errorf("unknown command: %s\n\nRun 'ynab --help' for usage", subcommand)
 	}
 }
 
+// oauthRefreshConfig returns the refresh settings for token, or nil when
+// it must not be refreshed. The refresh token belongs to the config's own
+// access_token, so any other token in use (a profile's, YNAB_ACCESS_TOKEN
+// or one from --env-file) is never refreshed or overwritten.
+func oauthRefreshConfig(cfg *config.Config, token string) *api.OAuthConfig {
+	if !cfg.HasOAuthRefresh() || token == "" || token != cfg.AccessToken {
+		return nil
+	}
+	return &api.OAuthConfig{
+		ClientID:     cfg.OAuthClientID,
+		ClientSecret: cfg.OAuthClientSecret,
+		RefreshToken: cfg.RefreshToken,
+		OnRefresh: func(accessToken, refreshToken string) error {
+			cfg.AccessToken = accessToken
+			if refreshToken != "" {
+				cfg.RefreshToken = refreshToken
+			}
+			return config.Save(cfg)
+		},
+	}
+}
+
+// globalFlags holds the flags every subcommand accepts. args keeps the
+// subcommand's own arguments, in order.
+type globalFlags struct {
+	json, csv, strictJSON, noCache, dryRun bool
+	profile, budget, envFile               string
+	tableStyle                             string
+	plain, color                           bool
+	maxBackoff, retryBudget                time.Duration
+	args                                   []string
+}
+
+// parseGlobalFlags takes the global flags out of a subcommand's arguments.
+// Flags a subcommand parses itself, such as export's --format, are left in
+// args.
+func parseGlobalFlags(subcommand string, args []string) (globalFlags, error) {
+	f := globalFlags{color: cmd.ColorAuto()}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--json":
+			f.json = true
+		case "--csv":
+			f.csv = true
+		case "--format":
+			// export parses its own --format (json or ledger)
+			if subcommand == "export" {
+				f.args = append(f.args, arg)
+				continue
+			}
+			if i+1 >= len(args) {
+				return f, fmt.Errorf("--format requires a format (table, json or csv)")
+			}
+			switch args[i+1] {
+			case "table":
+			case "json":
+				f.json = true
+			case "csv":
+				f.csv = true
+			default:
+				return f, fmt.Errorf("invalid format: %s (expected table, json or csv)", args[i+1])
+			}
+			i++
+		case "--strict-json":
+			f.strictJSON = true
+		case "--no-cache":
+			f.noCache = true
+		case "--dry-run":
+			// sweep, import and reconcile parse their own --dry-run
+			switch subcommand {
+			case "sweep", "import", "reconcile":
+				f.args = append(f.args, arg)
+			default:
+				f.dryRun = true
+			}
+		case "--max-backoff", "--retry-budget":
+			if i+1 >= len(args) {
+				return f, fmt.Errorf("%s requires a duration (e.g. 10s, 2m)", arg)
+			}
+			d, err := time.ParseDuration(args[i+1])
+			if err != nil || d <= 0 {
+				return f, fmt.Errorf("invalid %s duration: %s", arg, args[i+1])
+			}
+			if arg == "--max-backoff" {
+				f.maxBackoff = d
+			} else {
+				f.retryBudget = d
+			}
+			i++
+		case "--table-style":
+			if i+1 >= len(args) {
+				return f, fmt.Errorf("--table-style requires a style (plain, box or markdown)")
+			}
+			if f.plain && args[i+1] != cmd.TableStylePlain {
+				return f, fmt.Errorf("choose one of --plain and --table-style")
+			}
+			f.tableStyle = args[i+1]
+			i++
+		case "--plain":
+			if f.tableStyle != "" && f.tableStyle != cmd.TableStylePlain {
+				return f, fmt.Errorf("choose one of --plain and --table-style")
+			}
+			f.plain, f.tableStyle = true, cmd.TableStylePlain
+		case "--color":
+			f.color = true
+		case "--no-color":
+			f.color = false
+		case "--profile":
+			if i+1 >= len(args) {
+				return f, fmt.Errorf("--profile requires a profile name")
+			}
+			f.profile = args[i+1]
+			i++
+		case "--budget":
+			if i+1 >= len(args) {
+				return f, fmt.Errorf("--budget requires a budget name or ID")
+			}
+			f.budget = args[i+1]
+			i++
+		case "--env-file":
+			if i+1 >= len(args) {
+				return f, fmt.Errorf("--env-file requires a file path")
+			}
+			f.envFile = args[i+1]
+			i++
+		default:
+			f.args = append(f.args, arg)
+		}
+	}
+
+	return f, nil
+}
+
 // handleAddCommand parses and executes the add command.
-func handleAddCommand(client *api.Client, args []string, jsonOutput bool) error {
+func handleAddCommand(client *api.Client, args []string, dryRun, jsonOutput bool) error {
 	if len(args) < 2 {
-		return fmt.Errorf("add command requires at least amount and payee\n\nUsage: ynab add <amount> <payee> [category] [--account <name>] [--date <YYYY-MM-DD>] [--memo <text>]")
+		return fmt.Errorf("add command requires at least amount and payee\n\nUsage: ynab add <amount> <payee> [category] [--account <name> | --account-id <id>] [--category-id <id>] [--date <YYYY-MM-DD>] [--memo <text>] [--import-id <id>] [--flag <color>] [--no-approve] [--verify] [--force] [--split <category:amount>]...")
 	}
 
-	amount := args[0]
-	payee := args[1]
-	category := ""
+	opts := cmd.AddOptions{
+		Amount:   args[0],
+		Payee:    args[1],
+		Approved: config.ResolveApproveOnAdd(),
+		DryRun:   dryRun,
+
+		DefaultAccount:      config.ResolveDefaultAccount(),
+		DuplicateWindowDays: config.ResolveDuplicateWindowDays(),
+	}
 	if len(args) > 2 && !strings.HasPrefix(args[2], "--") {
-		category = args[2]
+		opts.Category = args[2]
 		args = args[3:]
 	} else {
 		args = args[2:]
 	}
 
-	account := ""
-	date := ""
-	memo := ""
-
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
 		case "--account":
 			if i+1 >= len(args) {
 				return fmt.Errorf("--account requires an argument")
 			}
-			account = args[i+1]
+			opts.Account = args[i+1]
 			i++
 		case "--date":
 			if i+1 >= len(args) {
 				return fmt.Errorf("--date requires an argument")
 			}
-			date = args[i+1]
+			opts.Date = args[i+1]
 			i++
 		case "--memo":
 			if i+1 >= len(args) {
 				return fmt.Errorf("--memo requires an argument")
 			}
-			memo = args[i+1]
+			opts.Memo = args[i+1]
+			i++
+		case "--account-id":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--account-id requires an argument")
+			}
+			opts.AccountID = args[i+1]
+			i++
+		case "--category-id":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--category-id requires an argument")
+			}
+			opts.CategoryID = args[i+1]
+			i++
+		case "--import-id":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--import-id requires an argument")
+			}
+			opts.ImportID = args[i+1]
+			i++
+		case "--flag":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--flag requires a color (red, orange, yellow, green, blue, purple or none)")
+			}
+			opts.Flag = args[i+1]
+			i++
+		case "--no-approve":
+			opts.Approved = false
+		case "--verify":
+			opts.Verify = true
+		case "--force":
+			opts.Force = true
+		case "--split":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--split requires category:amount")
+			}
+			opts.Splits = append(opts.Splits, args[i+1])
 			i++
 		default:
 			return fmt.Errorf("unknown flag: %s", args[i])
 		}
 	}
 
-	return cmd.AddCmd(client, amount, payee, category, account, date, memo, jsonOutput)
+	return cmd.AddCmd(client, opts, jsonOutput)
+}
+
+// handleBudgetCommand parses and executes the budget command.
+func handleBudgetCommand(client *api.Client, args []string, jsonOutput bool) error {
+	goals, goalsOnly, failOnOverspend := false, false, false
+	for _, arg := range args {
+		switch arg {
+		case "--goals":
+			goals = true
+		case "--goals-only":
+			goals, goalsOnly = true, true
+		case "--fail-on-overspend":
+			failOnOverspend = true
+		default:
+			return fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+
+	if goals {
+		if failOnOverspend {
+			return fmt.Errorf("--fail-on-overspend applies to the budget table, not --goals")
+		}
+		return cmd.BudgetGoalsCmd(client, goalsOnly, jsonOutput)
+	}
+	return cmd.BudgetCmd(client, failOnOverspend, jsonOutput)
 }
 
 // handleTransactionsCommand parses and executes the transactions command.
 func handleTransactionsCommand(client *api.Client, args []string, jsonOutput bool) error {
-	sinceDate := ""
-	accountFilter := ""
-	categoryFilter := ""
-	payeeFilter := ""
-	limit := 50
+	opts := cmd.TransactionsOptions{
+		Limit:        50,
+		DefaultSince: config.ResolveDefaultSince(),
+	}
 
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
 		case "--since":
 			if i+1 >= len(args) {
-				return fmt.Errorf("--since requires a date (YYYY-MM-DD)")
+				return fmt.Errorf("--since requires a date (YYYY-MM-DD, today, or a time ago like 7d, 2w or 1m)")
+			}
+			opts.SinceDate = args[i+1]
+			i++
+		case "--until":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--until requires a date (YYYY-MM-DD, today, or a time ago like 7d)")
 			}
-			sinceDate = args[i+1]
+			opts.UntilDate = args[i+1]
 			i++
 		case "--account":
 			if i+1 >= len(args) {
 				return fmt.Errorf("--account requires an argument")
 			}
-			accountFilter = args[i+1]
+			opts.Account = args[i+1]
 			i++
 		case "--category":
 			if i+1 >= len(args) {
 				return fmt.Errorf("--category requires an argument")
 			}
-			categoryFilter = args[i+1]
+			opts.Category = args[i+1]
 			i++
 		case "--payee":
 			if i+1 >= len(args) {
 				return fmt.Errorf("--payee requires an argument")
 			}
-			payeeFilter = args[i+1]
+			opts.Payee = args[i+1]
 			i++
 		case "--limit":
 			if i+1 >= len(args) {
@@ -233,137 +637,1120 @@ func handleTransactionsCommand(client *api.Client, args []string, jsonOutput boo
 			if err != nil {
 				return fmt.Errorf("--limit must be a number: %s", args[i+1])
 			}
-			limit = n
+			opts.Limit = n
+			i++
+		case "--include-scheduled":
+			opts.IncludeScheduled = true
+		case "--group-by":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--group-by requires an argument (account, extracted, day, week, month or Nd)")
+			}
+			// Periods are validated by TransactionsCmd
+			opts.GroupBy = args[i+1]
+			i++
+		case "--week-start":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--week-start requires monday or sunday")
+			}
+			opts.WeekStart = args[i+1]
+			i++
+		case "--memo-grep":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--memo-grep requires a pattern")
+			}
+			opts.MemoGrep = args[i+1]
+			i++
+		case "--extract":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--extract requires a pattern with a capture group")
+			}
+			opts.Extract = args[i+1]
+			i++
+		case "--include-transfers":
+			opts.IncludeTransfers = true
+		case "--no-transfers":
+			opts.NoTransfers = true
+		case "--approved", "--unapproved":
+			approval := strings.TrimPrefix(args[i], "--")
+			if opts.Approval != "" && opts.Approval != approval {
+				return fmt.Errorf("--approved and --unapproved are mutually exclusive")
+			}
+			opts.Approval = approval
+		case "--running-balance":
+			opts.RunningBalance = true
+		case "--jsonl":
+			opts.JSONL = true
+		case "--sort":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--sort requires a key (date, amount, abs-amount or payee)")
+			}
+			opts.Sort = args[i+1]
+			i++
+		case "--desc":
+			opts.Desc = true
+		case "--watch":
+			opts.Watch = true
+		case "--interval":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--interval requires a number of seconds")
+			}
+			seconds, err := strconv.Atoi(args[i+1])
+			if err != nil || seconds <= 0 {
+				return fmt.Errorf("--interval must be a positive number of seconds: %s", args[i+1])
+			}
+			opts.Interval = time.Duration(seconds) * time.Second
+			i++
+		case "--min", "--max":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires an amount", args[i])
+			}
+			f, err := strconv.ParseFloat(args[i+1], 64)
+			if err != nil || f < 0 {
+				return fmt.Errorf("invalid %s amount: %s", args[i], args[i+1])
+			}
+			milliunits := transform.DollarsToMilliunits(f)
+			if args[i] == "--min" {
+				opts.MinAmount = &milliunits
+			} else {
+				opts.MaxAmount = &milliunits
+			}
 			i++
 		default:
 			return fmt.Errorf("unknown flag: %s", args[i])
 		}
 	}
 
-	return cmd.TransactionsCmd(client, sinceDate, accountFilter, categoryFilter, payeeFilter, limit, jsonOutput)
+	if opts.Interval != 0 && !opts.Watch {
+		return fmt.Errorf("--interval needs --watch")
+	}
+
+	return cmd.TransactionsCmd(client, opts, jsonOutput)
 }
 
-// handleEditCommand parses and executes the edit command.
-func handleEditCommand(client *api.Client, args []string, jsonOutput bool) error {
-	if len(args) < 1 {
-		return fmt.Errorf("edit requires a transaction ID\n\nUsage: ynab edit <transaction_id> [--amount <amt>] [--payee <name>] [--category <name>] [--memo <text>] [--date <date>] [--cleared]")
+// handlePayeesCommand parses and executes the payees command.
+func handlePayeesCommand(client *api.Client, args []string, jsonOutput bool) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "rename":
+			if len(args) != 3 {
+				return fmt.Errorf("payees rename requires the payee and a new name\n\nUsage: ynab payees rename <payee> <new name>")
+			}
+			return cmd.PayeeRenameCmd(client, args[1], args[2], jsonOutput)
+		case "merge":
+			if len(args) != 3 {
+				return fmt.Errorf("payees merge requires a source and a destination payee\n\nUsage: ynab payees merge <source> <dest>")
+			}
+			return cmd.PayeeMergeCmd(client, args[1], args[2], jsonOutput)
+		}
 	}
 
-	transactionID := args[0]
-	args = args[1:]
-
-	var amount *int64
-	payee := ""
-	category := ""
-	memo := ""
-	date := ""
-	cleared := false
+	opts := cmd.PayeesOptions{Sort: "name"}
 
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
-		case "--amount":
+		case "--filter":
 			if i+1 >= len(args) {
-				return fmt.Errorf("--amount requires an argument")
-			}
-			amtStr := args[i+1]
-			f, err := strconv.ParseFloat(strings.TrimPrefix(amtStr, "+"), 64)
-			if err != nil {
-				return fmt.Errorf("invalid amount: %s", amtStr)
-			}
-			milliunits := transform.DollarsToMilliunits(f)
-			if !strings.HasPrefix(amtStr, "+") && milliunits > 0 {
-				milliunits = -milliunits
+				return fmt.Errorf("--filter requires an argument")
 			}
-			amount = &milliunits
+			opts.Filter = args[i+1]
 			i++
-		case "--payee":
+		case "--limit":
 			if i+1 >= len(args) {
-				return fmt.Errorf("--payee requires an argument")
+				return fmt.Errorf("--limit requires a number")
 			}
-			payee = args[i+1]
-			i++
-		case "--category":
-			if i+1 >= len(args) {
-				return fmt.Errorf("--category requires an argument")
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil {
+				return fmt.Errorf("--limit must be a number: %s", args[i+1])
 			}
-			category = args[i+1]
+			opts.Limit = n
 			i++
-		case "--memo":
+		case "--jsonl":
+			opts.JSONL = true
+		case "--sort":
 			if i+1 >= len(args) {
-				return fmt.Errorf("--memo requires an argument")
+				return fmt.Errorf("--sort requires an argument (name, id, none)")
 			}
-			memo = args[i+1]
-			i++
-		case "--date":
-			if i+1 >= len(args) {
-				return fmt.Errorf("--date requires an argument")
+			sortKey, err := parseSortKey(args[i+1])
+			if err != nil {
+				return err
 			}
-			date = args[i+1]
+			opts.Sort = sortKey
 			i++
-		case "--cleared":
-			cleared = true
+		case "--reverse":
+			opts.Reverse = true
 		default:
-			return fmt.Errorf("unknown flag: %s", args[i])
+			if strings.HasPrefix(args[i], "--") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			// Positional filter, kept for backwards compatibility
+			opts.Filter = args[i]
 		}
 	}
 
-	return cmd.EditCmd(client, transactionID, amount, payee, category, memo, date, cleared, jsonOutput)
+	return cmd.PayeesCmd(client, opts, jsonOutput)
 }
 
-// handleMoveCommand parses and executes the move command.
-func handleMoveCommand(client *api.Client, args []string, jsonOutput bool) error {
-	if len(args) < 1 {
-		return fmt.Errorf("move requires an amount\n\nUsage: ynab move <amount> --from <category> --to <category> [--month <YYYY-MM>]")
-	}
-
-	amountStr := args[0]
-	f, err := strconv.ParseFloat(amountStr, 64)
-	if err != nil {
-		return fmt.Errorf("invalid amount: %s", amountStr)
-	}
-	amountMilliunits := transform.DollarsToMilliunits(f)
-	args = args[1:]
-
-	fromCategory := ""
-	toCategory := ""
-	month := ""
+// handleCategoriesCommand parses and executes the categories command.
+func handleCategoriesCommand(client *api.Client, args []string, jsonOutput bool) error {
+	opts := cmd.CategoriesOptions{}
 
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
-		case "--from":
+		case "--filter":
 			if i+1 >= len(args) {
-				return fmt.Errorf("--from requires a category name")
+				return fmt.Errorf("--filter requires an argument")
 			}
-			fromCategory = args[i+1]
+			opts.Filter = args[i+1]
 			i++
-		case "--to":
+		case "--limit":
 			if i+1 >= len(args) {
-				return fmt.Errorf("--to requires a category name")
+				return fmt.Errorf("--limit requires a number")
 			}
-			toCategory = args[i+1]
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil {
+				return fmt.Errorf("--limit must be a number: %s", args[i+1])
+			}
+			opts.Limit = n
 			i++
-		case "--month":
+		case "--jsonl":
+			opts.JSONL = true
+		case "--sort":
 			if i+1 >= len(args) {
-				return fmt.Errorf("--month requires a month (YYYY-MM)")
+				return fmt.Errorf("--sort requires an argument (name, id, none)")
 			}
-			month = args[i+1]
+			sortKey, err := parseSortKey(args[i+1])
+			if err != nil {
+				return err
+			}
+			opts.Sort = sortKey
+			i++
+		case "--reverse":
+			opts.Reverse = true
+		case "--group":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--group requires a category group name")
+			}
+			opts.Group = args[i+1]
 			i++
+		case "--with-amounts":
+			opts.WithAmounts = true
 		default:
 			return fmt.Errorf("unknown flag: %s", args[i])
 		}
 	}
 
-	if fromCategory == "" || toCategory == "" {
-		return fmt.Errorf("--from and --to are required\n\nUsage: ynab move <amount> --from <category> --to <category> [--month <YYYY-MM>]")
-	}
+	return cmd.CategoriesCmd(client, opts, jsonOutput)
+}
+
+// parseSortKey validates a --sort value for the categories and payees commands.
+func parseSortKey(arg string) (string, error) {
+	switch arg {
+	case "name", "id", "none":
+		return arg, nil
+	default:
+		return "", fmt.Errorf("invalid --sort value: %s (expected name, id, or none)", arg)
+	}
+}
+
+// handleMonthsCommand parses and executes the months command.
+func handleMonthsCommand(client *api.Client, args []string, jsonOutput bool) error {
+	monthArg := ""
+	against := ""
+	vsPrevious := false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--compare":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--compare requires a month (YYYY-MM)")
+			}
+			against = args[i+1]
+			i++
+		case "--vs-previous":
+			vsPrevious = true
+		default:
+			if strings.HasPrefix(args[i], "--") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			if monthArg != "" {
+				return fmt.Errorf("unexpected argument: %s", args[i])
+			}
+			monthArg = args[i]
+		}
+	}
+
+	if against != "" && vsPrevious {
+		return fmt.Errorf("choose one of --compare and --vs-previous")
+	}
+	if against != "" || vsPrevious {
+		if strings.Contains(monthArg, "..") {
+			return fmt.Errorf("--compare and --vs-previous take a single month, not a range")
+		}
+		return cmd.MonthsCompareCmd(client, monthArg, against, jsonOutput)
+	}
+	return cmd.MonthsCmd(client, monthArg, jsonOutput)
+}
+
+// handleEditCommand parses and executes the edit command.
+func handleEditCommand(client *api.Client, args []string, dryRun, jsonOutput bool) error {
+	if len(args) < 1 {
+		return fmt.Errorf("edit requires a transaction ID\n\nUsage: ynab edit <transaction_id> [--amount <amt>] [--payee <name>] [--category <name> | --category-id <id>] [--account-id <id>] [--memo <text> | --memo-append <text>] [--date <date>] [--flag <color>] [--cleared | --cleared-status <status>] [--force]")
+	}
+
+	transactionID := args[0]
+	args = args[1:]
+
+	var amount *int64
+	payee := ""
+	category := ""
+	accountID := ""
+	categoryID := ""
+	memo := ""
+	memoAppend := ""
+	date := ""
+	flag := ""
+	cleared := ""
+	force := false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--amount":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--amount requires an argument")
+			}
+			amtStr := args[i+1]
+			milliunits, err := transform.ParseDollarsToMilliunits(amtStr)
+			if err != nil {
+				return err
+			}
+			if !strings.HasPrefix(amtStr, "+") && milliunits > 0 {
+				milliunits = -milliunits
+			}
+			amount = &milliunits
+			i++
+		case "--payee":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--payee requires an argument")
+			}
+			payee = args[i+1]
+			i++
+		case "--category":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--category requires an argument")
+			}
+			category = args[i+1]
+			i++
+		case "--account-id":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--account-id requires an argument")
+			}
+			accountID = args[i+1]
+			i++
+		case "--category-id":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--category-id requires an argument")
+			}
+			categoryID = args[i+1]
+			i++
+		case "--memo":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--memo requires an argument")
+			}
+			memo = args[i+1]
+			i++
+		case "--memo-append":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--memo-append requires an argument")
+			}
+			memoAppend = args[i+1]
+			i++
+		case "--date":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--date requires an argument")
+			}
+			date = args[i+1]
+			i++
+		case "--flag":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--flag requires a color (red, orange, yellow, green, blue, purple or none)")
+			}
+			flag = args[i+1]
+			i++
+		case "--cleared", "--cleared-status":
+			status := "cleared"
+			if args[i] == "--cleared-status" {
+				if i+1 >= len(args) {
+					return fmt.Errorf("--cleared-status requires a status (cleared, uncleared or reconciled)")
+				}
+				status = args[i+1]
+				i++
+			}
+			if cleared != "" && cleared != status {
+				return fmt.Errorf("choose one of --cleared and --cleared-status")
+			}
+			cleared = status
+		case "--force":
+			force = true
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	return cmd.EditCmd(client, transactionID, amount, payee, category, accountID, categoryID, memo, memoAppend, date, flag, cleared, force, dryRun, jsonOutput)
+}
+
+// handleDeleteCommand parses and executes the delete command.
+func handleDeleteCommand(client *api.Client, args []string, dryRun, jsonOutput bool) error {
+	transactionID := ""
+	yes, force := false, false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--yes":
+			yes = true
+		case "--force":
+			force = true
+		default:
+			if strings.HasPrefix(args[i], "--") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			if transactionID != "" {
+				return fmt.Errorf("unexpected argument: %s", args[i])
+			}
+			transactionID = args[i]
+		}
+	}
+
+	if transactionID == "" {
+		return fmt.Errorf("delete requires a transaction ID\n\nUsage: ynab delete <transaction_id> [--yes] [--force]")
+	}
+
+	return cmd.DeleteCmd(client, transactionID, yes, force, dryRun, jsonOutput)
+}
+
+// handleRestoreCommand parses and executes the restore command.
+func handleRestoreCommand(client *api.Client, args []string, jsonOutput bool) error {
+	transactionID := ""
+	list := false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--list":
+			list = true
+		default:
+			if strings.HasPrefix(args[i], "--") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			if transactionID != "" {
+				return fmt.Errorf("unexpected argument: %s", args[i])
+			}
+			transactionID = args[i]
+		}
+	}
+
+	if list {
+		if transactionID != "" {
+			return fmt.Errorf("--list takes no transaction ID")
+		}
+		return cmd.RestoreListCmd(client, jsonOutput)
+	}
+	if transactionID == "" {
+		return fmt.Errorf("restore requires a transaction ID\n\nUsage: ynab restore <transaction_id>\n       ynab restore --list")
+	}
+
+	return cmd.RestoreCmd(client, transactionID, jsonOutput)
+}
+
+// handleUndoCommand parses and executes the undo command.
+func handleUndoCommand(client *api.Client, args []string, jsonOutput bool) error {
+	steps := 1
+	stepsSet, list := false, false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--list":
+			list = true
+		case "--steps":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--steps requires a number")
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("--steps must be a positive number: %s", args[i+1])
+			}
+			steps, stepsSet = n, true
+			i++
+		default:
+			if strings.HasPrefix(args[i], "--") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			return fmt.Errorf("unexpected argument: %s", args[i])
+		}
+	}
+
+	if list {
+		if stepsSet {
+			return fmt.Errorf("--list can't be combined with --steps")
+		}
+		return cmd.UndoListCmd(client, jsonOutput)
+	}
+	return cmd.UndoCmd(client, steps, jsonOutput)
+}
+
+// handleSweepCommand parses and executes the sweep command.
+func handleSweepCommand(client *api.Client, args []string, jsonOutput bool) error {
+	opts := cmd.SweepOptions{}
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--to":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--to requires a category name")
+			}
+			opts.To = args[i+1]
+			i++
+		case "--from-group":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--from-group requires a group name")
+			}
+			opts.FromGroup = args[i+1]
+			i++
+		case "--min":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--min requires an amount")
+			}
+			f, err := strconv.ParseFloat(args[i+1], 64)
+			if err != nil || f < 0 {
+				return fmt.Errorf("invalid --min amount: %s", args[i+1])
+			}
+			opts.Min = transform.DollarsToMilliunits(f)
+			i++
+		case "--exclude":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--exclude requires a category name")
+			}
+			for _, name := range strings.Split(args[i+1], ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					opts.Exclude = append(opts.Exclude, name)
+				}
+			}
+			i++
+		case "--month":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--month requires an argument")
+			}
+			opts.Month = args[i+1]
+			i++
+		case "--dry-run":
+			opts.DryRun = true
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	if opts.To == "" {
+		return fmt.Errorf("sweep requires --to\n\nUsage: ynab sweep --to <category> [--from-group <group>] [--min <amt>] [--exclude <name,...>] [--month <YYYY-MM>] [--dry-run]")
+	}
+
+	return cmd.SweepCmd(client, opts, jsonOutput)
+}
+
+// handleAliasCommand parses and executes the alias subcommands.
+func handleAliasCommand(args []string, jsonOutput bool) error {
+	usage := "Usage: ynab alias set <name> <target> | ynab alias list | ynab alias remove <name>"
+	if len(args) == 0 {
+		return cmd.AliasListCmd(jsonOutput)
+	}
+
+	switch args[0] {
+	case "list":
+		return cmd.AliasListCmd(jsonOutput)
+	case "set":
+		if len(args) != 3 {
+			return fmt.Errorf("alias set requires a name and a target\n\n%s", usage)
+		}
+		return cmd.AliasSetCmd(args[1], args[2], jsonOutput)
+	case "remove", "rm":
+		if len(args) != 2 {
+			return fmt.Errorf("alias remove requires a name\n\n%s", usage)
+		}
+		return cmd.AliasRemoveCmd(args[1], jsonOutput)
+	default:
+		return fmt.Errorf("unknown alias subcommand: %s\n\n%s", args[0], usage)
+	}
+}
+
+// handleExportCommand parses and executes the export command.
+func handleExportCommand(client *api.Client, args []string, jsonOutput bool) error {
+	if jsonOutput {
+		return fmt.Errorf("export writes its own format; use --format instead of --json")
+	}
+
+	opts := cmd.ExportOptions{
+		Format:       "json",
+		Version:      version,
+		DefaultSince: config.ResolveDefaultSince(),
+	}
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--format":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--format requires an argument (json or ledger)")
+			}
+			opts.Format = args[i+1]
+			i++
+		case "--output":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--output requires a file path, or - for stdout")
+			}
+			opts.Output = args[i+1]
+			i++
+		case "--since":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--since requires a date (YYYY-MM-DD, today, or a time ago like 7d, 2w or 1m)")
+			}
+			opts.SinceDate = args[i+1]
+			i++
+		case "--account":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--account requires an argument")
+			}
+			opts.Account = args[i+1]
+			i++
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	if opts.Format == "json" && (opts.SinceDate != "" || opts.Account != "") {
+		return fmt.Errorf("--since and --account apply to --format ledger; the JSON export is the whole budget")
+	}
+
+	return cmd.ExportCmd(client, opts)
+}
+
+// handleMoveCommand parses and executes the move command.
+func handleMoveCommand(client *api.Client, args []string, dryRun, jsonOutput bool) error {
+	if len(args) < 1 {
+		return fmt.Errorf("move requires an amount\n\nUsage: ynab move <amount> --from <category> --to <category> [--month <YYYY-MM>] [--allow-negative]")
+	}
+
+	amountMilliunits, err := transform.ParseDollarsToMilliunits(args[0])
+	if err != nil {
+		return err
+	}
+	args = args[1:]
+
+	fromCategory := ""
+	toCategory := ""
+	month := ""
+	allowNegative := false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--from":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--from requires a category name")
+			}
+			fromCategory = args[i+1]
+			i++
+		case "--to":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--to requires a category name")
+			}
+			toCategory = args[i+1]
+			i++
+		case "--month":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--month requires a month (YYYY-MM)")
+			}
+			month = args[i+1]
+			i++
+		case "--allow-negative":
+			allowNegative = true
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	if fromCategory == "" || toCategory == "" {
+		return fmt.Errorf("--from and --to are required\n\nUsage: ynab move <amount> --from <category> --to <category> [--month <YYYY-MM>] [--allow-negative]")
+	}
+
+	return cmd.MoveCmd(client, amountMilliunits, fromCategory, toCategory, month, allowNegative, dryRun, jsonOutput)
+}
+
+// handleBalanceCommand parses and executes the balance command.
+func handleBalanceCommand(client *api.Client, args []string, jsonOutput bool) error {
+	var opts cmd.BalanceOptions
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--type":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--type requires an account type (e.g. checking, creditCard, cc)")
+			}
+			opts.Type = args[i+1]
+			i++
+		case "--group-by-type":
+			opts.GroupByType = true
+		case "--jsonl":
+			opts.JSONL = true
+		case "--sort":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--sort requires a key (name, balance or type)")
+			}
+			opts.Sort = args[i+1]
+			i++
+		case "--desc":
+			opts.Desc = true
+		default:
+			if strings.HasPrefix(args[i], "--") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			opts.Filter = args[i]
+		}
+	}
+	return cmd.BalanceCmd(client, opts, jsonOutput)
+}
+
+// handleScheduledCommand parses and executes the scheduled command.
+func handleScheduledCommand(client *api.Client, args []string, jsonOutput bool) error {
+	upcoming := 0
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--upcoming":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--upcoming requires a number")
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid --upcoming value: %s", args[i+1])
+			}
+			upcoming = n
+			i++
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	return cmd.ScheduledCmd(client, upcoming, jsonOutput)
+}
+
+// handleScheduleCommand parses and executes schedule add.
+func handleScheduleCommand(client *api.Client, args []string, jsonOutput bool) error {
+	const usage = "Usage: ynab schedule add <amount> <payee> --date <YYYY-MM-DD> [--frequency <freq>] [--category <name>] [--account <name>] [--memo <text>] [--flag <color>]"
+	if len(args) == 0 || args[0] != "add" {
+		return fmt.Errorf("schedule requires a subcommand; 'ynab scheduled' lists schedules\n\n%s", usage)
+	}
+	if len(args) < 3 {
+		return fmt.Errorf("schedule add requires an amount and a payee\n\n%s", usage)
+	}
+
+	opts := cmd.ScheduleAddOptions{
+		Amount:         args[1],
+		Payee:          args[2],
+		DefaultAccount: config.ResolveDefaultAccount(),
+	}
+	args = args[3:]
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--frequency":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--frequency requires an argument (e.g. monthly, weekly, yearly)")
+			}
+			opts.Frequency = args[i+1]
+			i++
+		case "--date":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--date requires an argument")
+			}
+			opts.Date = args[i+1]
+			i++
+		case "--category":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--category requires an argument")
+			}
+			opts.Category = args[i+1]
+			i++
+		case "--account":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--account requires an argument")
+			}
+			opts.Account = args[i+1]
+			i++
+		case "--memo":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--memo requires an argument")
+			}
+			opts.Memo = args[i+1]
+			i++
+		case "--flag":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--flag requires a color")
+			}
+			opts.Flag = args[i+1]
+			i++
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	return cmd.ScheduleAddCmd(client, opts, jsonOutput)
+}
+
+// handleStatsCommand parses and executes the stats command.
+func handleStatsCommand(client *api.Client, args []string, jsonOutput bool) error {
+	month := ""
+	top := 5
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--month":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--month requires a month (YYYY-MM)")
+			}
+			month = args[i+1]
+			i++
+		case "--top":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--top requires a number")
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid --top value: %s", args[i+1])
+			}
+			top = n
+			i++
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	return cmd.StatsCmd(client, month, top, jsonOutput)
+}
+
+// handleReportCommand parses and executes report payees.
+func handleReportCommand(client *api.Client, args []string, jsonOutput bool) error {
+	if len(args) == 0 || args[0] != "payees" {
+		return fmt.Errorf("report requires a report name\n\nUsage: ynab report payees [--since <date>] [--top <n>] [--net] [--include-transfers]")
+	}
+
+	opts := cmd.PayeeReportOptions{
+		DefaultSince: config.ResolveDefaultSince(),
+		Top:          10,
+	}
+	args = args[1:]
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--since":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--since requires a date (YYYY-MM-DD, today, or a time ago like 7d, 2w or 1m)")
+			}
+			opts.SinceDate = args[i+1]
+			i++
+		case "--top":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--top requires a number")
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil {
+				return fmt.Errorf("--top must be a number: %s", args[i+1])
+			}
+			opts.Top = n
+			i++
+		case "--net":
+			opts.Net = true
+		case "--include-transfers":
+			opts.IncludeTransfers = true
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	return cmd.PayeeReportCmd(client, opts, jsonOutput)
+}
+
+// handleNetWorthCommand parses and executes the net-worth command.
+func handleNetWorthCommand(client *api.Client, args []string, jsonOutput bool) error {
+	excludeClosed := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--exclude-closed":
+			excludeClosed = true
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+	return cmd.NetWorthCmd(client, excludeClosed, jsonOutput)
+}
+
+// handleAssignCommand parses and executes the assign command.
+func handleAssignCommand(client *api.Client, args []string, jsonOutput bool) error {
+	const usage = "Usage: ynab assign <amount> --category <name> [--month <YYYY-MM>]"
+	if len(args) < 1 {
+		return fmt.Errorf("assign requires an amount\n\n%s", usage)
+	}
+
+	amountMilliunits, err := transform.ParseDollarsToMilliunits(args[0])
+	if err != nil {
+		return err
+	}
+	args = args[1:]
+
+	category := ""
+	month := ""
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--category":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--category requires a category name")
+			}
+			category = args[i+1]
+			i++
+		case "--month":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--month requires a month (YYYY-MM)")
+			}
+			month = args[i+1]
+			i++
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	if category == "" {
+		return fmt.Errorf("--category is required\n\n%s", usage)
+	}
+
+	return cmd.AssignCmd(client, amountMilliunits, category, month, jsonOutput)
+}
+
+// handleSearchCommand parses and executes the search command.
+func handleSearchCommand(client *api.Client, args []string, jsonOutput bool) error {
+	opts := cmd.SearchOptions{
+		Limit:        50,
+		DefaultSince: config.ResolveDefaultSince(),
+	}
+
+	// Words outside quotes are joined, so `search whole foods` finds the phrase
+	var words []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--since":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--since requires a date (YYYY-MM-DD, today, or a time ago like 7d, 2w or 1m)")
+			}
+			opts.SinceDate = args[i+1]
+			i++
+		case "--limit":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--limit requires a number")
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil {
+				return fmt.Errorf("--limit must be a number: %s", args[i+1])
+			}
+			opts.Limit = n
+			i++
+		case "--exact":
+			opts.Exact = true
+		default:
+			if strings.HasPrefix(args[i], "--") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			words = append(words, args[i])
+		}
+	}
+
+	opts.Query = strings.Join(words, " ")
+	if opts.Query == "" {
+		return fmt.Errorf("search requires a query\n\nUsage: ynab search <query> [--since <date>] [--limit <n>] [--exact]")
+	}
+
+	return cmd.SearchCmd(client, opts, jsonOutput)
+}
+
+// handleImportCommand parses and executes the import command.
+func handleImportCommand(client *api.Client, args []string, jsonOutput bool) error {
+	const usage = "Usage: ynab import <file.csv|file.ofx> --account <name> [--date-col <n>] [--amount-col <n>] [--payee-col <n>] [--memo-col <n>] [--date-format <layout>] [--dry-run]"
+	opts := cmd.ImportOptions{DateCol: 0, AmountCol: 1, PayeeCol: 2, MemoCol: -1}
+
+	columns := map[string]*int{
+		"--date-col":   &opts.DateCol,
+		"--amount-col": &opts.AmountCol,
+		"--payee-col":  &opts.PayeeCol,
+		"--memo-col":   &opts.MemoCol,
+	}
 
-	return cmd.MoveCmd(client, amountMilliunits, fromCategory, toCategory, month, jsonOutput)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if col, ok := columns[arg]; ok {
+			if i+1 >= len(args) {
+				return fmt.Errorf("%s requires a column number (counting from 0)", arg)
+			}
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n < 0 {
+				return fmt.Errorf("invalid %s: %s (expected a column number counting from 0)", arg, args[i+1])
+			}
+			*col = n
+			i++
+			continue
+		}
+		switch {
+		case arg == "--account":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--account requires an account name")
+			}
+			opts.Account = args[i+1]
+			i++
+		case arg == "--date-format":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--date-format requires a layout (e.g. 01/02/2006)")
+			}
+			opts.DateFormat = args[i+1]
+			i++
+		case arg == "--dry-run":
+			opts.DryRun = true
+		case strings.HasPrefix(arg, "--"):
+			return fmt.Errorf("unknown flag: %s", arg)
+		case opts.File == "":
+			opts.File = arg
+		default:
+			return fmt.Errorf("unexpected argument: %s\n\n%s", arg, usage)
+		}
+	}
+
+	if opts.File == "" || opts.Account == "" {
+		return fmt.Errorf("import requires a CSV or OFX file and --account\n\n%s", usage)
+	}
+
+	return cmd.ImportCmd(client, opts, jsonOutput)
+}
+
+// handleApproveCommand parses and executes the approve command.
+func handleApproveCommand(client *api.Client, args []string, jsonOutput bool) error {
+	const usage = "Usage: ynab approve <id>... | ynab approve --all [--since <date>]"
+	opts := cmd.ApproveOptions{DefaultSince: config.ResolveDefaultSince()}
+
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--all":
+			opts.All = true
+		case args[i] == "--since":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--since requires a date (YYYY-MM-DD, today, or a time ago like 7d, 2w or 1m)")
+			}
+			opts.SinceDate = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--"):
+			return fmt.Errorf("unknown flag: %s", args[i])
+		default:
+			opts.IDs = append(opts.IDs, args[i])
+		}
+	}
+
+	if opts.All == (len(opts.IDs) > 0) {
+		return fmt.Errorf("approve requires transaction IDs or --all, not both\n\n%s", usage)
+	}
+	if opts.SinceDate != "" && !opts.All {
+		return fmt.Errorf("--since only applies with --all")
+	}
+
+	return cmd.ApproveCmd(client, opts, jsonOutput)
+}
+
+// handleReconcileCommand parses and executes the reconcile command.
+func handleReconcileCommand(client *api.Client, args []string, jsonOutput bool) error {
+	const usage = "Usage: ynab reconcile <account> <statement-balance> [--dry-run]"
+	opts := cmd.ReconcileOptions{}
+
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--dry-run":
+			opts.DryRun = true
+		case strings.HasPrefix(args[i], "--"):
+			return fmt.Errorf("unknown flag: %s", args[i])
+		default:
+			positional = append(positional, args[i])
+		}
+	}
+
+	if len(positional) != 2 {
+		return fmt.Errorf("reconcile requires an account and a statement balance\n\n%s", usage)
+	}
+	opts.Account = positional[0]
+
+	// Negative for credit cards and other liabilities, as on the statement
+	f, err := strconv.ParseFloat(strings.ReplaceAll(positional[1], ",", ""), 64)
+	if err != nil {
+		return fmt.Errorf("invalid statement balance: %s (expected decimal number like 1234.56)", positional[1])
+	}
+	opts.StatementBalance = transform.DollarsToMilliunits(f)
+
+	return cmd.ReconcileCmd(client, opts, jsonOutput)
+}
+
+// handleTransferCommand parses and executes the transfer command.
+func handleTransferCommand(client *api.Client, args []string, jsonOutput bool) error {
+	const usage = "Usage: ynab transfer <amount> --from <account> --to <account> [--date <YYYY-MM-DD>] [--memo <text>] [--category <name>] [--no-approve]"
+	if len(args) < 1 || strings.HasPrefix(args[0], "--") {
+		return fmt.Errorf("transfer requires an amount\n\n%s", usage)
+	}
+
+	opts := cmd.TransferOptions{
+		Amount:   args[0],
+		Approved: config.ResolveApproveOnAdd(),
+	}
+	args = args[1:]
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--from":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--from requires an account name")
+			}
+			opts.From = args[i+1]
+			i++
+		case "--to":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--to requires an account name")
+			}
+			opts.To = args[i+1]
+			i++
+		case "--date":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--date requires an argument")
+			}
+			opts.Date = args[i+1]
+			i++
+		case "--memo":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--memo requires an argument")
+			}
+			opts.Memo = args[i+1]
+			i++
+		case "--category":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--category requires a category name")
+			}
+			opts.Category = args[i+1]
+			i++
+		case "--no-approve":
+			opts.Approved = false
+		default:
+			return fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	if opts.From == "" || opts.To == "" {
+		return fmt.Errorf("--from and --to are required\n\n%s", usage)
+	}
+
+	return cmd.TransferCmd(client, opts, jsonOutput)
 }
 
 // handleAddAccountCommand parses and executes the add-account command.
 func handleAddAccountCommand(client *api.Client, args []string, jsonOutput bool) error {
 	if len(args) < 2 {
-		return fmt.Errorf("add-account requires name and type\n\nUsage: ynab add-account <name> <type> [balance]\n\nTypes: checking, savings, creditCard, cash, lineOfCredit, otherAsset, otherLiability")
+		return fmt.Errorf("add-account requires name and type\n\nUsage: ynab add-account <name> <type> [balance]\n\nTypes: checking, savings, creditCard (credit), cash, lineOfCredit (loc), otherAsset, otherLiability")
 	}
 
 	name := args[0]
@@ -381,6 +1768,63 @@ func handleAddAccountCommand(client *api.Client, args []string, jsonOutput bool)
 	return cmd.AddAccountCmd(client, name, accountType, balance, jsonOutput)
 }
 
+// handleAccountCommand parses and executes the account subcommands.
+func handleAccountCommand(client *api.Client, args []string, jsonOutput bool) error {
+	const usage = "Usage: ynab account edit <name> [--note <text>] [--close [--force]] [--reopen]"
+	if len(args) == 0 || args[0] != "edit" {
+		return fmt.Errorf("account requires a subcommand\n\n%s", usage)
+	}
+	if len(args) < 2 || strings.HasPrefix(args[1], "--") {
+		return fmt.Errorf("account edit requires an account name\n\n%s", usage)
+	}
+
+	name := args[1]
+	var opts cmd.AccountEditOptions
+	for i := 2; i < len(args); i++ {
+		switch args[i] {
+		case "--note":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--note requires text (\"\" clears the note)")
+			}
+			note := args[i+1]
+			opts.Note = &note
+			i++
+		case "--close":
+			opts.Close = true
+		case "--reopen":
+			opts.Reopen = true
+		case "--force":
+			opts.Force = true
+		default:
+			if strings.HasPrefix(args[i], "--") {
+				return fmt.Errorf("unknown flag: %s", args[i])
+			}
+			return fmt.Errorf("unexpected argument: %s", args[i])
+		}
+	}
+
+	return cmd.AccountEditCmd(client, name, opts, jsonOutput)
+}
+
+// printVersion prints the version line, or the full build metadata as JSON.
+func printVersion(jsonOutput bool) error {
+	if !jsonOutput {
+		fmt.Printf("ynab version %s\n", version)
+		return nil
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(VersionOutput{
+		Version:   version,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Commit:    commit,
+		BuildDate: buildDate,
+	})
+}
+
 func printUsage() {
 	fmt.Printf(`ynab - YNAB command-line interface (v%s)
 
@@ -388,62 +1832,291 @@ USAGE:
     ynab <command> [options]
 
 COMMANDS:
-    status                  Show budget status and metadata
+    status                  Show budget metadata, To Be Budgeted and Age of Money
     balance [filter]        Show account balances
+                            (--type <type>, --group-by-type for subtotals per type,
+                            --jsonl for one JSON object per account,
+                            --sort name|balance|type with --desc to reverse)
     budget                  Show current month's budget
+    net-worth               Total assets and liabilities across all accounts
+    stats                   Income, spending, savings rate and top categories for a month
+    report payees           Spending and income ranked by payee
     categories              List all categories with IDs
     transactions            List transactions (with filters)
+    search <query>          Find transactions by payee, category or memo
     payees [filter]         List all payees
     months [YYYY-MM]        List months or show month detail
+                            (YYYY-MM..YYYY-MM lists a range; --compare <YYYY-MM>
+                            or --vs-previous shows per-category changes)
     scheduled               List scheduled/recurring transactions
+                            (--upcoming <n>: expand each into its next n dates)
+    schedule add            Create a scheduled/recurring transaction
     add                     Add a new transaction
     edit                    Edit an existing transaction
     delete                  Delete a transaction
+    restore                 Recreate a transaction removed by delete
+    undo                    Reverse the last add, edit, move or assign
+    approve                 Approve imported transactions
+    import <file>           Import transactions from a bank CSV, OFX or QFX export
     move                    Move money between categories
+    assign                  Set a category's budgeted amount
+    transfer                Transfer money between accounts
+    reconcile               Match an account's cleared balance to a statement
+    sweep                   Sweep leftover category balances into one category
     add-account             Create a new account
+    account edit <name>     Change an account's note, or close or reopen it
+    export                  Back up the whole budget as JSON, or export a ledger journal
+    sync [--full]           Update the local budget cache (only changes after the first sync)
+    alias                   Manage short names for accounts, categories and payees
     configure               Set up YNAB access token and default budget
     configure show          Show current configuration
     doctor                  Validate installation and configuration
+    whoami                  Show the YNAB user the access token belongs to
+    completion <shell>      Print a tab-completion script for bash, zsh or fish
+
+BUDGET:
+    ynab budget [options]
+        --goals                 Goal progress for each category
+        --goals-only            Like --goals, leaving out categories without a goal
+        --fail-on-overspend     Exit non-zero if any category is overspent, e.g. for cron alerts
 
 TRANSACTIONS:
     ynab transactions [options]
-        --since <YYYY-MM-DD>    Start date (default: 30 days ago)
+        --since <date>          Start date: YYYY-MM-DD, today, or Nd, Nw or Nm ago
+                                (default: default_since, then 30d)
+        --until <date>          End date, inclusive, in the same forms (default: no end)
         --account <name>        Filter by account
         --category <name>       Filter by category
         --payee <name>          Filter by payee
         --limit <n>             Max results (default: 50)
+        --include-scheduled     Also show scheduled transactions due in the next 30 days
+        --memo-grep <regexp>    Only transactions whose memo matches
+        --extract <regexp>      Show the pattern's first capture group from each memo
+        --group-by account      Per-account inflow/outflow/net summary (use --account all)
+        --group-by extracted    Subtotal by the --extract value
+        --group-by <period>     List by day, week, month or Nd (N-day blocks from --since),
+                                with a subtotal per period
+        --week-start <day>      First day of a --group-by week: monday (ISO, default) or sunday
+        --include-transfers     Count transfers between accounts in --group-by totals
+        --no-transfers          Leave out transfers between accounts
+        --unapproved            Only unapproved transactions, e.g. new imports to review
+        --approved              Only approved transactions
+        --min <amount>          Only amounts of at least this size, inflow or outflow
+        --max <amount>          Only amounts of at most this size (both bounds inclusive)
+        --running-balance       Show the account balance after each transaction (needs --account)
+        --jsonl                 Stream one JSON object per transaction, per line
+        --sort <key>            Order by date, amount (signed), abs-amount or payee;
+                                --limit then keeps the first n, e.g. the largest outflows
+        --desc                  Reverse the --sort order
+        --watch                 Keep polling and print each new transaction (Ctrl-C to stop)
+        --interval <seconds>    Time between --watch polls (default: 60)
+
+SEARCH:
+    ynab search <query> [options]
+        --since <date>          Start date: YYYY-MM-DD, today, or Nd, Nw or Nm ago
+                                (default: default_since, then 30d)
+        --limit <n>             Max results (default: 50)
+        --exact                 Match whole words only
+
+CATEGORIES / PAYEES:
+    ynab categories [options]
+    ynab payees [filter] [options]
+        --filter <text>         Filter by name
+        --limit <n>             Stop after n results
+        --jsonl                 Stream one JSON object per line
+        --sort <key>            Order by name, id, or none (API order)
+                                (payees default: name; categories default: none)
+        --reverse               Reverse the order
+        --group <name>          Categories: only groups whose name contains this
+        --with-amounts          Categories: add this month's budgeted, activity
+                                and balance
+    ynab payees rename <payee> <new name>
+    ynab payees merge <source> <dest>
+                                Move all of source's transactions to dest
 
 ADD TRANSACTION:
     ynab add <amount> <payee> [category] [options]
-        --account <name>        Account (default: first on-budget)
+        --account <name>        Account (default: default_account, then first on-budget)
+        --account-id <id>       Account by ID, skipping name lookup
+        --category-id <id>      Category by ID, instead of the category argument
         --date <YYYY-MM-DD>     Date (default: today)
         --memo <text>           Memo
+        --import-id <id>        Idempotency key (max 36 chars); re-running is a no-op
+        --flag <color>          Flag: red, orange, yellow, green, blue or purple
+        --no-approve            Leave the transaction unapproved for review
+                                (default: approve_on_add, then approved)
+        --verify                Re-fetch the account and check its balance moved by the amount
+        --split <cat:amt>       Split line (repeatable, instead of category); must sum to the amount
+        --force                 Skip the check for a matching transaction within
+                                duplicate_window_days (default: 3)
+
+SCHEDULE TRANSACTION:
+    ynab schedule add <amount> <payee> --date <YYYY-MM-DD> [options]
+        --date <YYYY-MM-DD>     First date; must be in the future, at most 5 years out
+        --frequency <freq>      monthly (default), never, daily, weekly, everyOtherWeek,
+                                twiceAMonth, every4Weeks, everyOtherMonth, every3Months,
+                                every4Months, twiceAYear, yearly or everyOtherYear
+        --category <name>       Category
+        --account <name>        Account (default: default_account, then first on-budget)
+        --memo <text>           Memo
+        --flag <color>          Flag: red, orange, yellow, green, blue or purple
 
 EDIT TRANSACTION:
     ynab edit <transaction_id> [options]
         --amount <amt>          New amount
         --payee <name>          New payee
         --category <name>       New category
+        --category-id <id>      New category by ID, skipping name lookup
+        --account-id <id>       Move to the account with this ID
         --memo <text>           New memo
+        --memo-append <text>    Add to the end of the current memo
         --date <YYYY-MM-DD>     New date
+        --flag <color>          New flag color, or none to clear it
         --cleared               Mark as cleared
+        --cleared-status <s>    Set the cleared status: cleared, uncleared or reconciled
+        --force                 Allow editing a reconciled transaction
+
+DELETE TRANSACTION:
+    ynab delete <transaction_id> [--yes] [--force]
+                                Asks for confirmation first
+        --yes                   Delete without asking
+        --force                 Allow deleting a reconciled transaction (also
+                                skips the confirmation)
+
+RESTORE TRANSACTION:
+    ynab restore <transaction_id>
+                                Recreate a deleted transaction (it gets a new ID)
+    ynab restore --list         Recently deleted transactions (~/.ynab/trash)
+
+UNDO:
+    ynab undo [--steps <n>]     Reverse the last n add, edit, move or assign
+                                actions (default: 1), most recent first
+    ynab undo --list            Actions that can be undone (~/.ynab/history.jsonl)
+
+STATS:
+    ynab stats [--month <YYYY-MM>] [--top <n>]
+                                Month summary (default: this month) with the n
+                                categories that spent the most (default: 5)
+
+REPORT:
+    ynab report payees [options]
+        --since <date>          Start date: YYYY-MM-DD, today, or Nd, Nw or Nm ago
+                                (default: default_since, then 30d)
+        --top <n>               Payees listed per table (default: 10)
+        --net                   One ranking of net totals instead of separate
+                                spending and income
+        --include-transfers     Count transfers between accounts (left out by default)
+
+NET WORTH:
+    ynab net-worth [--exclude-closed]
+                                Sum on- and off-budget accounts into assets and
+                                liabilities; closed accounts count unless excluded
 
 MOVE MONEY:
     ynab move <amount> --from <category> --to <category> [--month <YYYY-MM>]
+        --allow-negative        Move more than the source category has available
+
+ASSIGN:
+    ynab assign <amount> --category <name> [--month <YYYY-MM>]
+                                Set the category's budgeted amount (default: this month)
+
+TRANSFER BETWEEN ACCOUNTS:
+    ynab transfer <amount> --from <account> --to <account> [options]
+        --date <YYYY-MM-DD>     Transfer date (default: today)
+        --memo <text>           Memo
+        --category <name>       Category, for transfers to or from an off-budget account
+        --no-approve            Leave the transfer unapproved for review in YNAB
+
+IMPORT:
+    ynab import <file.csv|file.ofx|file.qfx> --account <name> [options]
+        OFX and QFX files are recognized by extension or content; the column
+        options below apply to CSV only.
+        --date-col <n>          Date column, counting from 0 (default: 0)
+        --amount-col <n>        Amount column (default: 1); negative amounts are outflows
+        --payee-col <n>         Payee column (default: 2)
+        --memo-col <n>          Memo column (default: none)
+        --date-format <layout>  Go date layout, e.g. 02.01.2006
+                                (default: YYYY-MM-DD or MM/DD/YYYY)
+        --dry-run               Show the parsed rows without creating them
+
+APPROVE:
+    ynab approve <id>...                Approve the given transactions
+    ynab approve --all [--since <date>] Approve every unapproved transaction in the window
+                                        (default: default_since, then 30d)
+
+RECONCILE:
+    ynab reconcile <account> <statement-balance> [--dry-run]
+        Creates a cleared "Reconciliation Balance Adjustment" for any
+        difference between the statement and the cleared balance
+        --dry-run               Show the adjustment without creating it
+
+SWEEP LEFTOVERS:
+    ynab sweep --to <category> [options]
+        --from-group <group>    Only sweep categories in this group
+        --min <amt>             Only sweep balances above this amount
+        --exclude <name,...>    Categories to leave alone (repeatable)
+        --month <YYYY-MM>       Month (default: current)
+        --dry-run               Show what would move without changing anything
+
+EXPORT:
+    ynab export [options]
+        --output <file>         Write to a file instead of stdout (- for stdout)
+        --format <fmt>          json (default): the whole budget in one file, with
+                                exported_at and the CLI version
+                                ledger: transactions as a ledger/hledger journal
+        --since <date>          Ledger start: YYYY-MM-DD, today, or Nd, Nw or Nm ago
+                                (default: default_since, then 30d)
+        --account <name>        Only export this account (ledger)
+
+ALIASES:
+    ynab alias set <name> <target>   Map a short name to an account, category or payee
+    ynab alias list                  List aliases
+    ynab alias remove <name>         Remove an alias
+    Aliases are checked before name matching wherever a name is accepted.
 
 ADD ACCOUNT:
     ynab add-account <name> <type> [balance]
     Types: checking, savings, creditCard, cash, lineOfCredit, otherAsset, otherLiability
+    Aliases: credit, cc, card (creditCard), loc (lineOfCredit), asset, liability
+
+ACCOUNT:
+    ynab account edit <name> [options]
+        --note <text>           Set the account's note ("" clears it)
+        --close                 Close the account (its balance must be zero)
+        --force                 With --close, close it despite a balance
+        --reopen                Reopen a closed account
 
 GLOBAL OPTIONS:
     --json              Output in JSON format
+    --csv               Output CSV (transactions, balance, budget, categories, payees)
+    --format <f>        Output format: table (default), json or csv
+    --max-backoff <d>   Cap each retry wait, including Retry-After (e.g. 10s)
+    --retry-budget <d>  Give up once retries have waited this long in total (e.g. 1m)
+    --profile <name>    Use the token and budget of a [profile.<name>] config section
+    --budget <name>     Use this budget instead of the default: an ID, a full
+                        name or part of one
+    --env-file <path>   Load KEY=VALUE lines (YNAB_ACCESS_TOKEN, YNAB_DEFAULT_BUDGET_ID)
+                        over the shell environment and top-level config keys
+    --strict-json       Fail if an API response has fields this version doesn't know
+    --no-cache          Look accounts and categories up from the API and drop
+                        the lookup cache (see lookup_cache_ttl)
+    --dry-run           add, edit, move, delete: print the API requests instead of
+                        sending them (sweep, import and reconcile preview too)
+    --table-style <s>   Table style: plain (default, or table_style from config),
+                        box or markdown
+    --plain             Plain tables, overriding table_style, e.g. for a narrow terminal
+    --color             Color amounts: red outflows, green inflows
+    --no-color          Never color output (default: color on a terminal
+                        unless NO_COLOR is set)
     --help, -h          Show this help
-    --version, -v       Show version
+    --version, -v       Show version (add --json for build metadata)
 
 CONFIGURATION:
     ynab configure              Interactive setup (like 'aws configure')
-    ynab configure show         Show current config (token masked)
+    ynab configure show         Show current config and profiles (tokens masked)
     ynab doctor                 Validate setup and troubleshoot
+    ynab doctor --fix           Also repair config permissions and a missing
+                                default budget (when there is only one)
     Config file: ~/.ynab/config
 
 EXAMPLES:
@@ -457,7 +2130,11 @@ EXAMPLES:
     ynab edit <id> --amount 75 --memo "Updated"         # Edit transaction
     ynab delete <id>                                    # Delete transaction
     ynab move 100 --from "Eating Out" --to "Groceries"  # Move money
+    ynab transfer 200 --from Checking --to Savings      # Transfer between accounts
+    ynab sweep --to "Savings" --dry-run                 # Preview a month-end sweep
     ynab months 2025-01                                 # View month detail
+    ynab export --output budget.json                    # Full budget backup
+    ynab export --format ledger > ynab.journal          # Plain-text accounting
     ynab add-account "Savings" savings 1000             # Create account
 
 For more information, visit: https://api.ynab.com
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/config"
+)
+
+func TestParseGlobalFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		subcommand string
+		args       []string
+		wantJSON   bool
+		wantCSV    bool
+		wantArgs   []string
+	}{
+		{"export ledger", "export", []string{"--format", "ledger", "--since", "30d"}, false, false, []string{"--format", "ledger", "--since", "30d"}},
+		{"export json", "export", []string{"--format", "json"}, false, false, []string{"--format", "json"}},
+		{"transactions json", "transactions", []string{"--format", "json", "--limit", "5"}, true, false, []string{"--limit", "5"}},
+		{"balance csv", "balance", []string{"--format", "csv"}, false, true, nil},
+		{"sweep dry run", "sweep", []string{"--dry-run"}, false, false, []string{"--dry-run"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags, err := parseGlobalFlags(tt.subcommand, tt.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if flags.json != tt.wantJSON || flags.csv != tt.wantCSV {
+				t.Errorf("json, csv = %t, %t, want %t, %t", flags.json, flags.csv, tt.wantJSON, tt.wantCSV)
+			}
+			if !slices.Equal(flags.args, tt.wantArgs) {
+				t.Errorf("args = %q, want %q", flags.args, tt.wantArgs)
+			}
+		})
+	}
+
+	if _, err := parseGlobalFlags("balance", []string{"--format", "ledger"}); err == nil {
+		t.Error("expected an error for --format ledger outside export")
+	}
+}
+
+// TestExportCommandFormats runs export --format ledger and --format json
+// through the global flag parsing, as the CLI does.
+func TestExportCommandFormats(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/budgets":
+			w.Write([]byte(`{"data":{"budgets":[{"id":"b1","name":"Home"}]}}`))
+		case "/budgets/b1":
+			w.Write([]byte(`{"data":{"budget":{"id":"b1","name":"Home","accounts":[],"payees":[],"transactions":[]},"server_knowledge":1}}`))
+		case "/budgets/b1/accounts":
+			w.Write([]byte(`{"data":{"accounts":[{"id":"a1","name":"Checking","type":"checking","on_budget":true}]}}`))
+		case "/budgets/b1/transactions":
+			w.Write([]byte(`{"data":{"transactions":[{"id":"t1","date":"2025-01-15","amount":-12500,"payee_name":"Grocer","category_name":"Groceries","account_id":"a1","account_name":"Checking"}]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := api.NewClient("test-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetBaseURL(server.URL)
+	client.SetDefaultBudgetID("b1")
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"ledger", "Expenses:Groceries"},
+		{"json", `"budget_id": "b1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			output := filepath.Join(t.TempDir(), "export")
+			flags, err := parseGlobalFlags("export", []string{"--format", tt.format, "--output", output})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := handleExportCommand(client, flags.args, flags.json); err != nil {
+				t.Fatalf("export --format %s: %v", tt.format, err)
+			}
+			data, err := os.ReadFile(output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(data), tt.want) {
+				t.Errorf("export --format %s does not contain %q:\n%s", tt.format, tt.want, data)
+			}
+		})
+	}
+}
+
+// TestOAuthRefreshConfig tests that only the config's own access token is
+// ever refreshed.
+func TestOAuthRefreshConfig(t *testing.T) {
+	oauth := config.Config{RefreshToken: "refresh", OAuthClientID: "id", OAuthClientSecret: "secret"}
+
+	withToken := oauth
+	withToken.AccessToken = "oauth-token"
+	if got := oauthRefreshConfig(&withToken, "oauth-token"); got == nil || got.RefreshToken != "refresh" {
+		t.Errorf("config token: got %+v, want refresh enabled", got)
+	}
+
+	// A personal access token from YNAB_ACCESS_TOKEN, with no access_token
+	// in the config, is left alone
+	if got := oauthRefreshConfig(&oauth, "pat-from-env"); got != nil {
+		t.Errorf("env token with no config token: got %+v, want nil", got)
+	}
+	if got := oauthRefreshConfig(&withToken, "pat-from-env"); got != nil {
+		t.Errorf("env token: got %+v, want nil", got)
+	}
+
+	if got := oauthRefreshConfig(&config.Config{AccessToken: "pat"}, "pat"); got != nil {
+		t.Errorf("no OAuth settings: got %+v, want nil", got)
+	}
+}
+
+// TestOAuthRefreshConfig_EnvFile tests that a project token from --env-file
+// never triggers a refresh of the config account's token.
+func TestOAuthRefreshConfig_EnvFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("YNAB_ACCESS_TOKEN", "")
+
+	cfg := &config.Config{
+		AccessToken:       "config-token",
+		RefreshToken:      "refresh",
+		OAuthClientID:     "id",
+		OAuthClientSecret: "secret",
+	}
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	envFile := filepath.Join(t.TempDir(), ".env.ynab")
+	if err := os.WriteFile(envFile, []byte("YNAB_ACCESS_TOKEN=project-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.LoadEnvFile(envFile); err != nil {
+		t.Fatal(err)
+	}
+
+	token := config.ResolveToken("")
+	if token != "project-token" {
+		t.Fatalf("ResolveToken() = %q, want project-token", token)
+	}
+	loaded, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := oauthRefreshConfig(loaded, token); got != nil {
+		t.Errorf("env file token: got %+v, want no refresh", got)
+	}
+}
+
+// TestHandleAssignCommand_InvalidAmount tests that amounts ParseFloat would
+// accept but that aren't money are refused before any request is made.
+func TestHandleAssignCommand_InvalidAmount(t *testing.T) {
+	for _, amount := range []string{"NaN", "Inf", "-Inf", "1e30", "0x10", "12.3456"} {
+		err := handleAssignCommand(nil, []string{amount, "--category", "Groceries"}, false)
+		if err == nil {
+			t.Errorf("assign %s: expected an error", amount)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Defaults for the circuit breaker NewClient installs: five failed attempts
+// in a row, all within a minute, stop requests for 30 seconds.
+const (
+	BreakerThreshold = 5
+	BreakerWindow    = time.Minute
+	BreakerCooldown  = 30 * time.Second
+)
+
+// CircuitBreaker stops a client from hammering an API that keeps failing.
+// After threshold consecutive failed attempts (5xx responses or network
+// errors) within window, it opens: requests fail at once with a
+// CircuitOpenError instead of waiting out their retries. Once cooldown has
+// passed, one request is let through; if it fails the breaker opens again,
+// and any answer from YNAB closes it. On its own it only sees requests
+// made by this process; Record and Restore carry its state between runs.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+	failures  []time.Time      // consecutive failures within window, oldest first
+	openUntil time.Time        // zero while closed
+	now       func() time.Time // nil means time.Now
+}
+
+// BreakerState is a snapshot of a CircuitBreaker.
+type BreakerState struct {
+	Open     bool          // requests are being refused
+	Failures int           // consecutive failures counted so far
+	RetryIn  time.Duration // while open, how long until a request is let through
+}
+
+// BreakerRecord is the state of a CircuitBreaker as saved between
+// processes; see Record and Restore.
+type BreakerRecord struct {
+	Failures  []time.Time `json:"failures,omitempty"`
+	OpenUntil time.Time   `json:"open_until,omitzero"`
+}
+
+// IsZero reports whether r records a closed breaker with no failures.
+func (r BreakerRecord) IsZero() bool {
+	return len(r.Failures) == 0 && r.OpenUntil.IsZero()
+}
+
+// NewCircuitBreaker creates a breaker that opens after threshold
+// consecutive failures within window and stays open for cooldown.
+func NewCircuitBreaker(threshold int, window, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, window: window, cooldown: cooldown}
+}
+
+// Allow reports whether a request may be sent now.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.openUntil.IsZero() || !b.clock().Before(b.openUntil)
+}
+
+// Success records an answer from the API and closes the breaker.
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = nil
+	b.openUntil = time.Time{}
+}
+
+// Failure records a failed attempt, opening the breaker once there have
+// been threshold of them in a row within window. A failure after the
+// cooldown reopens it straight away.
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock()
+	cutoff := now.Add(-b.window)
+	i := 0
+	for i < len(b.failures) && !b.failures[i].After(cutoff) {
+		i++
+	}
+	b.failures = append(b.failures[i:], now)
+
+	if !b.openUntil.IsZero() || len(b.failures) >= b.threshold {
+		b.openUntil = now.Add(b.cooldown)
+	}
+}
+
+// State returns the breaker's current state.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := BreakerState{Failures: len(b.failures)}
+	if !b.openUntil.IsZero() {
+		if retryIn := b.openUntil.Sub(b.clock()); retryIn > 0 {
+			s.Open, s.RetryIn = true, retryIn
+		}
+	}
+	return s
+}
+
+// Record returns the breaker's failures and cooldown for saving.
+func (b *CircuitBreaker) Record() BreakerRecord {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return BreakerRecord{Failures: slices.Clone(b.failures), OpenUntil: b.openUntil}
+}
+
+// Restore replaces the breaker's state with r, as saved by an earlier
+// process. Failures older than window are dropped, and so is a cooldown
+// that ended more than window ago, so a stale record cannot trip the
+// breaker.
+func (b *CircuitBreaker) Restore(r BreakerRecord) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cutoff := b.clock().Add(-b.window)
+	b.failures = nil
+	for _, t := range r.Failures {
+		if t.After(cutoff) {
+			b.failures = append(b.failures, t)
+		}
+	}
+	b.openUntil = time.Time{}
+	if r.OpenUntil.After(cutoff) {
+		b.openUntil = r.OpenUntil
+	}
+}
+
+func (b *CircuitBreaker) clock() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	breaker := NewCircuitBreaker(3, time.Minute, 30*time.Second)
+	breaker.now = clock.now
+
+	for i := 0; i < 2; i++ {
+		breaker.Failure()
+		clock.t = clock.t.Add(time.Second)
+	}
+	if !breaker.Allow() {
+		t.Fatal("breaker opened before the threshold")
+	}
+	breaker.Failure()
+	if breaker.Allow() {
+		t.Fatal("breaker still closed after 3 failures in a row")
+	}
+	if s := breaker.State(); !s.Open || s.Failures != 3 || s.RetryIn != 30*time.Second {
+		t.Errorf("State() = %+v", s)
+	}
+
+	// After the cooldown one request goes through; failing reopens at once
+	clock.t = clock.t.Add(30 * time.Second)
+	if !breaker.Allow() {
+		t.Fatal("breaker still open after the cooldown")
+	}
+	breaker.Failure()
+	if breaker.Allow() {
+		t.Fatal("a failed trial request didn't reopen the breaker")
+	}
+
+	clock.t = clock.t.Add(30 * time.Second)
+	breaker.Success()
+	if s := breaker.State(); s.Open || s.Failures != 0 || !breaker.Allow() {
+		t.Errorf("after success, State() = %+v", s)
+	}
+}
+
+func TestCircuitBreaker_Window(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	breaker := NewCircuitBreaker(3, time.Minute, 30*time.Second)
+	breaker.now = clock.now
+
+	// Failures spread out further than the window never add up
+	for i := 0; i < 5; i++ {
+		breaker.Failure()
+		clock.t = clock.t.Add(40 * time.Second)
+	}
+	if !breaker.Allow() {
+		t.Errorf("breaker opened on failures %s apart, State() = %+v", 40*time.Second, breaker.State())
+	}
+
+	// A success in between resets the count
+	breaker.Failure()
+	breaker.Failure()
+	breaker.Success()
+	breaker.Failure()
+	if s := breaker.State(); s.Failures != 1 || s.Open {
+		t.Errorf("State() = %+v, want 1 failure, closed", s)
+	}
+}
+
+// TestCircuitBreaker_RecordRestore verifies that a breaker opened by one
+// process stays open in the next, and that stale records are ignored.
+func TestCircuitBreaker_RecordRestore(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	first := NewCircuitBreaker(3, time.Minute, 30*time.Second)
+	first.now = clock.now
+	for i := 0; i < 3; i++ {
+		first.Failure()
+	}
+	record := first.Record()
+
+	clock.t = clock.t.Add(10 * time.Second)
+	next := NewCircuitBreaker(3, time.Minute, 30*time.Second)
+	next.now = clock.now
+	next.Restore(record)
+	if s := next.State(); !s.Open || s.Failures != 3 || s.RetryIn != 20*time.Second {
+		t.Errorf("restored State() = %+v, want open for 20s after 3 failures", s)
+	}
+
+	// An hour later the record is stale
+	clock.t = clock.t.Add(time.Hour)
+	later := NewCircuitBreaker(3, time.Minute, 30*time.Second)
+	later.now = clock.now
+	later.Restore(record)
+	later.Failure()
+	if s := later.State(); s.Open || s.Failures != 1 {
+		t.Errorf("State() after a stale restore = %+v, want closed with 1 failure", s)
+	}
+	if !later.Record().OpenUntil.IsZero() {
+		t.Error("stale cooldown was kept")
+	}
+}
+
+// TestClient_CircuitBreakerFailsFast verifies that once the breaker opens,
+// requests return at once instead of waiting out their retries.
+func TestClient_CircuitBreakerFailsFast(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error": {"id": "500", "name": "internal_server_error", "detail": "Server error"}}`))
+	}))
+	defer server.Close()
+
+	var waits int
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		breaker:    NewCircuitBreaker(BreakerThreshold, BreakerWindow, BreakerCooldown),
+		sleep:      func(time.Duration) { waits++ },
+	}
+
+	// The first call uses all MaxRetries+1 attempts: 4 failures
+	if _, err := client.GetBudgets(); err == nil || IsCircuitOpenError(err) {
+		t.Fatalf("first call: err = %v, want a server error", err)
+	}
+	// The fifth failure opens the breaker, so the second call stops there
+	_, err := client.GetBudgets()
+	if !IsCircuitOpenError(err) {
+		t.Fatalf("second call: err = %v, want CircuitOpenError", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != BreakerThreshold {
+		t.Errorf("%d attempts, want %d", got, BreakerThreshold)
+	}
+
+	// Now nothing is sent and nothing waited for
+	waitsBefore := waits
+	if _, err := client.GetBudgets(); !IsCircuitOpenError(err) {
+		t.Errorf("third call: err = %v, want CircuitOpenError", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != BreakerThreshold || waits != waitsBefore {
+		t.Errorf("open breaker sent %d request(s) and waited %d time(s)", got-BreakerThreshold, waits-waitsBefore)
+	}
+	if !client.CircuitBreaker().State().Open {
+		t.Error("State() reports the breaker closed")
+	}
+}
@@ -2,13 +2,20 @@
 package api
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -21,16 +28,81 @@ const (
 
 	// InitialBackoff is the initial backoff duration
 	InitialBackoff = 1 * time.Second
+
+	// BackoffMultiplier is how much each backoff grows over the last
+	BackoffMultiplier = 2.0
+
+	// DefaultJitter randomizes each backoff by up to ±20%
+	DefaultJitter = 0.2
 )
 
+// RetryConfig controls how failed requests are retried. Zero fields take
+// the package defaults, so the zero value retries MaxRetries times with
+// unjittered exponential backoff.
+type RetryConfig struct {
+	MaxRetries     int           // retries after the first attempt; negative disables retries
+	InitialBackoff time.Duration // wait before the first retry
+	Multiplier     float64       // growth of each wait over the last
+	Jitter         float64       // each wait is randomized by up to ±Jitter (0.2 = ±20%)
+}
+
+// DefaultRetryConfig returns the retry settings NewClient uses: the default
+// backoff with ±20% jitter, so concurrent invocations that fail together
+// don't retry in lockstep.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries:     MaxRetries,
+		InitialBackoff: InitialBackoff,
+		Multiplier:     BackoffMultiplier,
+		Jitter:         DefaultJitter,
+	}
+}
+
+// withDefaults fills in zero fields.
+func (r RetryConfig) withDefaults() RetryConfig {
+	switch {
+	case r.MaxRetries == 0:
+		r.MaxRetries = MaxRetries
+	case r.MaxRetries < 0:
+		r.MaxRetries = 0
+	}
+	if r.InitialBackoff <= 0 {
+		r.InitialBackoff = InitialBackoff
+	}
+	if r.Multiplier <= 0 {
+		r.Multiplier = BackoffMultiplier
+	}
+	return r
+}
+
 // Client is the YNAB API client.
 type Client struct {
-	token            string
-	baseURL          string
-	httpClient       *http.Client
-	defaultBudgetID  string
-}
+	auth            *authState // access token, shared with WithContext copies
+	baseURL         string
+	httpClient      *http.Client
+	defaultBudgetID string
+	oauth           *OAuthConfig
+
+	// Retry limits (zero means unlimited); see SetRetryLimits
+	maxBackoff  time.Duration
+	retryBudget time.Duration
+	sleep       func(time.Duration) // nil means time.Sleep
+
+	// retry sets the retry count and backoff; see SetRetryConfig
+	retry RetryConfig
 
+	// strictJSON rejects response fields the types don't model; see SetStrictJSON
+	strictJSON bool
+
+	// limiter throttles outgoing requests (nil means unlimited); see SetRateLimiter
+	limiter *RateLimiter
+
+	// breaker fails requests fast while YNAB keeps failing (nil disables it); see SetCircuitBreaker
+	breaker *CircuitBreaker
+
+	// ctx bounds every request (nil means context.Background); see WithContext
+	ctx context.Context
+}
 
 // NewClient creates a new YNAB API client.
 // If token is empty, it will attempt to read from YNAB_ACCESS_TOKEN environment variable.
@@ -43,50 +115,153 @@ func NewClient(token string) (*Client, error) {
 	}
 
 	return &Client{
-		token:   token,
+		auth:    newAuthState(token),
 		baseURL: BaseURL,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		limiter: NewRateLimiter(RateLimitRequests, RateLimitWindow),
+		breaker: NewCircuitBreaker(BreakerThreshold, BreakerWindow, BreakerCooldown),
+		retry:   DefaultRetryConfig(),
 	}, nil
 }
 
-// request performs an HTTP request with retry logic and rate limit handling.
+// WithContext returns a copy of the client whose requests are bound to ctx:
+// cancelling it aborts the request in flight and any retry wait, so every
+// method of the copy can be interrupted. The copy shares the rate limiter
+// and circuit breaker.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("api: nil context")
+	}
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+// Context returns the client's context; see WithContext.
+func (c *Client) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// request performs an HTTP request with retry logic and rate limit handling,
+// bound to the client's context.
 func (c *Client) request(method, endpoint string, body io.Reader) ([]byte, error) {
+	return c.requestCtx(c.Context(), method, endpoint, body)
+}
+
+// requestCtx is request bound to ctx instead. Errors never carry the
+// client's credentials.
+func (c *Client) requestCtx(ctx context.Context, method, endpoint string, body io.Reader) ([]byte, error) {
+	respBody, err := c.doRequest(ctx, method, endpoint, body, nil)
+	if err != nil {
+		return nil, c.sanitizeError(err)
+	}
+	return respBody, nil
+}
+
+// requestStream performs a GET like request, but hands a successful
+// response body to consume as it arrives, decompressed, instead of reading
+// it into memory first. Failures before the body starts are retried as
+// usual; once consume has the body, its error is returned as is, since
+// part of the response has already been used.
+func (c *Client) requestStream(endpoint string, consume func(io.Reader) error) error {
+	_, err := c.doRequest(c.Context(), "GET", endpoint, nil, consume)
+	return c.sanitizeError(err)
+}
+
+// doRequest is requestCtx without the error redaction. With consume set, a
+// successful response is streamed to it and no body is returned.
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, body io.Reader, consume func(io.Reader) error) ([]byte, error) {
 	var lastErr error
-	backoff := InitialBackoff
+	retry := c.retry.withDefaults()
+	backoff := retry.InitialBackoff
+	refreshed := false
+	skipBackoff := false
+	var waited time.Duration
+
+	// Buffer the body so it can be resent on retries
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	for attempt := 0; attempt <= retry.MaxRetries; attempt++ {
+		// Fail fast, skipping the backoff, while YNAB keeps failing
+		if c.breaker != nil && !c.breaker.Allow() {
+			state := c.breaker.State()
+			return nil, &CircuitOpenError{Failures: state.Failures, RetryIn: state.RetryIn}
+		}
 
-	for attempt := 0; attempt <= MaxRetries; attempt++ {
-		if attempt > 0 {
+		if attempt > 0 && !skipBackoff {
 			// Wait before retrying
-			time.Sleep(backoff)
-			backoff *= 2 // Exponential backoff
+			ok, err := c.pause(ctx, jitter(backoff, retry.Jitter, rand.Float64()), &waited)
+			if err != nil {
+				return nil, canceledError(err, lastErr)
+			}
+			if !ok {
+				return nil, c.retryBudgetError(lastErr)
+			}
+			backoff = time.Duration(float64(backoff) * retry.Multiplier) // Exponential backoff
+		}
+		skipBackoff = false
+
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
 		}
 
 		// Create request
 		url := c.baseURL + endpoint
-		req, err := http.NewRequest(method, url, body)
+		req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
 
 		// Set headers
-		req.Header.Set("Authorization", "Bearer "+c.token)
+		token := c.auth.current()
+		req.Header.Set("Authorization", "Bearer "+token)
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("User-Agent", "Via-YNAB/2.0")
+		req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+		// Refuse rather than block once the client-side quota is spent;
+		// waiting could take up to an hour
+		if c.limiter != nil && !c.limiter.Allow() {
+			retryAfter := int(c.limiter.ResetIn().Seconds()) + 1
+			return nil, fmt.Errorf("client-side limit of %d requests per %s reached: %w",
+				c.limiter.requests, c.limiter.window, NewRateLimitError(retryAfter))
+		}
 
 		// Execute request
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
-			lastErr = fmt.Errorf("request failed: %w", err)
+			if ctx.Err() != nil {
+				return nil, canceledError(ctx.Err(), lastErr)
+			}
+			lastErr = newNetworkError(err)
+			c.breakerFailure()
 			continue // Retry on network errors
 		}
 
-		// Read response body
-		respBody, err := io.ReadAll(resp.Body)
+		if consume != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			c.breakerSuccess()
+			return nil, streamResponseBody(resp, consume)
+		}
+
+		// Read (and decompress) response body
+		respBody, err := readResponseBody(resp)
 		resp.Body.Close()
 		if err != nil {
 			lastErr = fmt.Errorf("failed to read response: %w", err)
+			c.breakerFailure()
 			continue
 		}
 
@@ -100,7 +275,13 @@ func (c *Client) request(method, endpoint string, body io.Reader) ([]byte, error
 			}
 			lastErr = NewRateLimitError(retryAfter)
 			// Wait for the specified retry-after period before retrying
-			time.Sleep(time.Duration(retryAfter) * time.Second)
+			ok, err := c.pause(ctx, time.Duration(retryAfter)*time.Second, &waited)
+			if err != nil {
+				return nil, canceledError(err, lastErr)
+			}
+			if !ok {
+				return nil, c.retryBudgetError(lastErr)
+			}
 			continue
 		}
 
@@ -131,30 +312,222 @@ func (c *Client) request(method, endpoint string, body io.Reader) ([]byte, error
 				}
 			}
 
-			// Special handling for authentication errors (401)
+			// Special handling for authentication errors (401).
+			// With OAuth configured, refresh once and replay the request.
 			if resp.StatusCode == http.StatusUnauthorized {
+				if c.oauth != nil && !refreshed {
+					refreshed = true
+					if err := c.refreshAccessToken(ctx, token); err != nil {
+						return nil, fmt.Errorf("%w (token refresh failed: %v)", NewAuthError(), err)
+					}
+					attempt--
+					skipBackoff = true
+					continue
+				}
 				return nil, NewAuthError()
 			}
 
 			// Retry server errors (5xx) with exponential backoff
 			if ynabErr.IsServerError() {
 				lastErr = ynabErr
+				c.breakerFailure()
 				continue
 			}
 
-			// Don't retry client errors (4xx except 429)
+			// Don't retry client errors (4xx except 429); YNAB is up
+			c.breakerSuccess()
 			return nil, ynabErr
 		}
 
 		// Success
+		c.breakerSuccess()
 		return respBody, nil
 	}
 
 	// All retries exhausted
 	if lastErr != nil {
-		return nil, fmt.Errorf("request failed after %d retries: %w", MaxRetries, lastErr)
+		return nil, fmt.Errorf("request failed after %d retries: %w", retry.MaxRetries, lastErr)
+	}
+	return nil, fmt.Errorf("request failed after %d retries", retry.MaxRetries)
+}
+
+// jitter spreads d by up to ±fraction of itself; r, in [0, 1), picks where
+// in that range the result falls.
+func jitter(d time.Duration, fraction, r float64) time.Duration {
+	if fraction <= 0 {
+		return d
 	}
-	return nil, fmt.Errorf("request failed after %d retries", MaxRetries)
+	return time.Duration(float64(d) * (1 + fraction*(2*r-1)))
+}
+
+// SetRetryConfig sets how many times failed requests are retried and how
+// the waits between them grow. NewClient starts with DefaultRetryConfig.
+func (c *Client) SetRetryConfig(cfg RetryConfig) {
+	c.retry = cfg
+}
+
+// SetRetryLimits caps how long requests wait between retries. maxBackoff
+// clamps each individual wait, including server-provided Retry-After values;
+// retryBudget caps the total time spent waiting across all retries of one
+// request, after which the request is abandoned. Zero disables either limit.
+func (c *Client) SetRetryLimits(maxBackoff, retryBudget time.Duration) {
+	c.maxBackoff = maxBackoff
+	c.retryBudget = retryBudget
+}
+
+// SetCircuitBreaker replaces the circuit breaker. NewClient installs one
+// with the Breaker* defaults; nil disables it.
+func (c *Client) SetCircuitBreaker(b *CircuitBreaker) {
+	c.breaker = b
+}
+
+// CircuitBreaker returns the client's circuit breaker, or nil if there is
+// none, so callers can report its state.
+func (c *Client) CircuitBreaker() *CircuitBreaker {
+	return c.breaker
+}
+
+func (c *Client) breakerFailure() {
+	if c.breaker != nil {
+		c.breaker.Failure()
+	}
+}
+
+func (c *Client) breakerSuccess() {
+	if c.breaker != nil {
+		c.breaker.Success()
+	}
+}
+
+// SetRateLimiter replaces the client-side request limiter. NewClient installs
+// one matching YNAB's quota; nil disables client-side limiting.
+func (c *Client) SetRateLimiter(l *RateLimiter) {
+	c.limiter = l
+}
+
+// RateLimiter returns the client-side request limiter, or nil if there is
+// none, so callers can check the remaining quota.
+func (c *Client) RateLimiter() *RateLimiter {
+	return c.limiter
+}
+
+// pause waits d (clamped to maxBackoff) before a retry and adds it to waited.
+// It returns false without waiting if the wait would exceed the retry budget,
+// and ctx's error if ctx is done before the wait is over.
+func (c *Client) pause(ctx context.Context, d time.Duration, waited *time.Duration) (bool, error) {
+	if c.maxBackoff > 0 && d > c.maxBackoff {
+		d = c.maxBackoff
+	}
+	if c.retryBudget > 0 && *waited+d > c.retryBudget {
+		return false, nil
+	}
+	*waited += d
+
+	if c.sleep != nil {
+		c.sleep(d)
+		return true, ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true, nil
+	case <-ctx.Done():
+		return true, ctx.Err()
+	}
+}
+
+// canceledError reports a request abandoned because its context is done,
+// keeping the failure that was being retried, if any.
+func canceledError(ctxErr, lastErr error) error {
+	if lastErr != nil {
+		return fmt.Errorf("request canceled: %w (last error: %v)", ctxErr, lastErr)
+	}
+	return fmt.Errorf("request canceled: %w", ctxErr)
+}
+
+// retryBudgetError wraps the last failure once the retry budget is spent.
+func (c *Client) retryBudgetError(lastErr error) error {
+	if lastErr != nil {
+		return fmt.Errorf("retry budget of %s exhausted: %w", c.retryBudget, lastErr)
+	}
+	return fmt.Errorf("retry budget of %s exhausted", c.retryBudget)
+}
+
+// readResponseBody reads the response body, transparently decompressing
+// gzip and deflate encodings. Setting Accept-Encoding ourselves disables
+// net/http's automatic decompression, so it has to happen here.
+func readResponseBody(resp *http.Response) ([]byte, error) {
+	reader, err := responseReader(resp)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// streamResponseBody passes resp's decompressed body to consume, then
+// closes it.
+func streamResponseBody(resp *http.Response, consume func(io.Reader) error) error {
+	defer resp.Body.Close()
+	reader, err := responseReader(resp)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	defer reader.Close()
+	return consume(reader)
+}
+
+// responseReader returns resp's body decompressed according to its
+// Content-Encoding. Closing the reader doesn't close resp.Body.
+func responseReader(resp *http.Response) (io.ReadCloser, error) {
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip":
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip response: %w", err)
+		}
+		return gz, nil
+	case "deflate":
+		// HTTP "deflate" is zlib-wrapped, but some servers send raw deflate
+		raw, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if zr, err := zlib.NewReader(bytes.NewReader(raw)); err == nil {
+			return zr, nil
+		}
+		return flate.NewReader(bytes.NewReader(raw)), nil
+	}
+	return io.NopCloser(resp.Body), nil
+}
+
+// SetStrictJSON makes response parsing fail on any field the client's types
+// don't recognize, instead of silently dropping it. Off by default so new
+// fields added by YNAB don't break the CLI; useful for catching schema drift.
+func (c *Client) SetStrictJSON(strict bool) {
+	c.strictJSON = strict
+}
+
+// decode parses an API response body into v, honoring strict mode.
+func (c *Client) decode(body []byte, v interface{}) error {
+	if !c.strictJSON {
+		return json.Unmarshal(body, v)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("strict JSON: %w", err)
+	}
+	return nil
+}
+
+// SetBaseURL points the client at another API root, such as an httptest
+// server in tests or a proxy. NewClient uses BaseURL.
+func (c *Client) SetBaseURL(url string) {
+	c.baseURL = strings.TrimSuffix(url, "/")
 }
 
 // SetDefaultBudgetID sets the default budget ID (from config file).
@@ -1,7 +1,10 @@
 package api
 
 import (
+	"compress/gzip"
+	"compress/zlib"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -230,6 +233,36 @@ func TestClient_GetBudgets(t *testing.T) {
 	}
 }
 
+func TestClient_StrictJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"budgets": [{"id": "budget-1", "name": "My Budget", "brand_new_field": true}]}}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-token")
+	client.baseURL = server.URL
+
+	// Lenient (default): the unknown field is ignored
+	budgets, err := client.GetBudgets()
+	if err != nil {
+		t.Fatalf("lenient mode: unexpected error: %v", err)
+	}
+	if len(budgets) != 1 || budgets[0].ID != "budget-1" {
+		t.Errorf("lenient mode: unexpected budgets %+v", budgets)
+	}
+
+	// Strict: the unknown field is an error that names it
+	client.SetStrictJSON(true)
+	_, err = client.GetBudgets()
+	if err == nil {
+		t.Fatal("strict mode: expected error for unknown field")
+	}
+	if !strings.Contains(err.Error(), "brand_new_field") {
+		t.Errorf("strict mode: error should name the field, got %v", err)
+	}
+}
+
 func TestClient_GetDefaultBudgetID(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -366,11 +399,71 @@ func TestTransactionRequest_Validate(t *testing.T) {
 			if !tt.wantError && err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
-			if !tt.wantError && tt.req.Cleared == "" {
-				if tt.req.Cleared != "uncleared" {
-					t.Errorf("expected cleared to default to 'uncleared', got '%s'", tt.req.Cleared)
+			if !tt.wantError {
+				if tt.req.Cleared != "" {
+					t.Errorf("Validate changed cleared to '%s'", tt.req.Cleared)
+				}
+				if cleared := transactionBody(tt.req)["cleared"]; cleared != "uncleared" {
+					t.Errorf("expected cleared to default to 'uncleared', got '%v'", cleared)
 				}
 			}
 		})
 	}
 }
+
+func TestClient_Request_GzipResponse(t *testing.T) {
+	writeGzip := func(w http.ResponseWriter, status int, body string) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(status)
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(body))
+		gz.Close()
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ae := r.Header.Get("Accept-Encoding"); !strings.Contains(ae, "gzip") {
+			t.Errorf("expected Accept-Encoding to include gzip, got %q", ae)
+		}
+		switch r.URL.Path {
+		case "/budgets":
+			writeGzip(w, http.StatusOK, `{"data": {"budgets": [{"id": "b1", "name": "Compressed"}]}}`)
+		case "/deflate":
+			w.Header().Set("Content-Encoding", "deflate")
+			zw := zlib.NewWriter(w)
+			zw.Write([]byte(`{"data": {"ok": true}}`))
+			zw.Close()
+		default:
+			writeGzip(w, http.StatusNotFound, `{"error": {"id": "404", "name": "not_found", "detail": "Missing"}}`)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-token")
+	client.baseURL = server.URL
+
+	budgets, err := client.GetBudgets()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(budgets) != 1 || budgets[0].Name != "Compressed" {
+		t.Errorf("expected decompressed budget, got %+v", budgets)
+	}
+
+	body, err := client.request("GET", "/deflate", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != `{"data": {"ok": true}}` {
+		t.Errorf("expected inflated body, got %q", body)
+	}
+
+	// Error responses must still be parsed after decompression
+	_, err = client.request("GET", "/missing", nil)
+	var ynabErr *YNABError
+	if !errors.As(err, &ynabErr) {
+		t.Fatalf("expected YNABError, got %v", err)
+	}
+	if ynabErr.ErrorID != "404" || ynabErr.Detail != "Missing" {
+		t.Errorf("expected parsed error details, got %+v", ynabErr)
+	}
+}
@@ -3,7 +3,9 @@ package api
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"time"
 )
 
 // YNABError represents an error from the YNAB API.
@@ -53,12 +55,68 @@ func (e *YNABError) IsBadRequestError() bool {
 	return e.StatusCode == http.StatusBadRequest
 }
 
-// IsRetryable returns true if the error is potentially retryable.
-// This includes rate limit errors, server errors, and network errors.
+// IsRetryable returns true if the error is potentially retryable: rate
+// limit and server errors. Network failures are NetworkErrors instead.
 func (e *YNABError) IsRetryable() bool {
 	return e.IsRateLimitError() || e.IsServerError()
 }
 
+// NetworkError is a request that got no HTTP response from YNAB: the
+// connection was refused or dropped, DNS failed, or it timed out. Unlike
+// a YNABError, YNAB never saw or rejected the request.
+type NetworkError struct {
+	Err     error // The underlying transport error
+	Timeout bool  // Whether the request timed out
+}
+
+func (e *NetworkError) Error() string {
+	if e.Timeout {
+		return fmt.Sprintf("network timeout: %v", e.Err)
+	}
+	return fmt.Sprintf("network error: %v", e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryable returns true: network failures, timeouts included, are
+// usually transient.
+func (e *NetworkError) IsRetryable() bool {
+	return true
+}
+
+// newNetworkError wraps a transport error, noting whether it was a timeout.
+func newNetworkError(err error) *NetworkError {
+	var netErr net.Error
+	return &NetworkError{
+		Err:     err,
+		Timeout: errors.As(err, &netErr) && netErr.Timeout(),
+	}
+}
+
+// CircuitOpenError is returned without sending anything while the client's
+// circuit breaker is open, after repeated server or network failures.
+type CircuitOpenError struct {
+	Failures int           // consecutive failures that opened the breaker
+	RetryIn  time.Duration // how long until a request is let through again
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("YNAB is unavailable (%d failed requests in a row); not retrying for %s",
+		e.Failures, e.RetryIn.Round(time.Second))
+}
+
+// DuplicateImportError is returned when YNAB skipped creating a transaction
+// because one with the same import_id already exists.
+type DuplicateImportError struct {
+	ImportID string
+}
+
+func (e *DuplicateImportError) Error() string {
+	return fmt.Sprintf("a transaction with import_id %s already exists", e.ImportID)
+}
+
 // Helper functions for error checking
 
 // IsYNABError returns true if the error is a YNABError.
@@ -67,6 +125,19 @@ func IsYNABError(err error) bool {
 	return errors.As(err, &ynabErr)
 }
 
+// IsNetworkError returns true if the request failed before YNAB answered.
+func IsNetworkError(err error) bool {
+	var netErr *NetworkError
+	return errors.As(err, &netErr)
+}
+
+// IsCircuitOpenError returns true if the request was refused by an open
+// circuit breaker.
+func IsCircuitOpenError(err error) bool {
+	var openErr *CircuitOpenError
+	return errors.As(err, &openErr)
+}
+
 // IsAuthError returns true if the error is a YNAB authentication error.
 func IsAuthError(err error) bool {
 	var ynabErr *YNABError
@@ -103,6 +174,12 @@ func IsNotFoundError(err error) bool {
 	return false
 }
 
+// IsDuplicateImportError returns true if the error reports a duplicate import_id.
+func IsDuplicateImportError(err error) bool {
+	var dupErr *DuplicateImportError
+	return errors.As(err, &dupErr)
+}
+
 // NewAuthError creates a new authentication error.
 func NewAuthError() *YNABError {
 	return &YNABError{
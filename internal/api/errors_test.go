@@ -2,6 +2,8 @@ package api
 
 import (
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"testing"
 )
@@ -154,6 +156,26 @@ func TestIsYNABError(t *testing.T) {
 	}
 }
 
+func TestIsNetworkError(t *testing.T) {
+	refused := fmt.Errorf("failed to get budgets: %w", newNetworkError(errors.New("connection refused")))
+	timeout := newNetworkError(&net.DNSError{Err: "i/o timeout", IsTimeout: true})
+
+	if !IsNetworkError(refused) {
+		t.Error("IsNetworkError() should see through wrapping")
+	}
+	if IsNetworkError(&YNABError{StatusCode: http.StatusServiceUnavailable}) {
+		t.Error("IsNetworkError() should return false for a YNAB response")
+	}
+
+	var netErr *NetworkError
+	if errors.As(refused, &netErr) && netErr.Timeout {
+		t.Error("connection refused should not be a timeout")
+	}
+	if !timeout.Timeout || !timeout.IsRetryable() {
+		t.Errorf("timeout = %+v, want a retryable timeout", timeout)
+	}
+}
+
 func TestIsAuthError(t *testing.T) {
 	authErr := &YNABError{StatusCode: http.StatusUnauthorized}
 	otherErr := &YNABError{StatusCode: http.StatusBadRequest}
@@ -3,10 +3,35 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 )
 
+// GetUser retrieves the user the access token belongs to.
+func (c *Client) GetUser() (*User, error) {
+	respBody, err := c.request("GET", "/user", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response UserResponse
+	if err := c.decode(respBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w", err)
+	}
+	if response.Data.User == nil {
+		return nil, fmt.Errorf("user response did not include a user")
+	}
+
+	return response.Data.User, nil
+}
+
 // GetBudgets retrieves all budgets for the authenticated user.
 func (c *Client) GetBudgets() ([]*Budget, error) {
 	respBody, err := c.request("GET", "/budgets", nil)
@@ -15,7 +40,7 @@ func (c *Client) GetBudgets() ([]*Budget, error) {
 	}
 
 	var response BudgetsResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse budgets response: %w", err)
 	}
 
@@ -36,17 +61,19 @@ func (c *Client) GetBudget(budgetID string, lastKnowledge int64) (*BudgetDetail,
 	}
 
 	var response BudgetResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse budget response: %w", err)
 	}
 
+	budget := response.Data.Budget
 	return &BudgetDetail{
-		Budget:          response.Data.Budget,
+		Budget:          &budget.Budget,
 		ServerKnowledge: response.Data.ServerKnowledge,
-		Accounts:        response.Data.Accounts,
-		CategoryGroups:  response.Data.CategoryGroups,
-		Payees:          response.Data.Payees,
-		Transactions:    response.Data.Transactions,
+		Accounts:        budget.Accounts,
+		CategoryGroups:  budget.CategoryGroups,
+		Categories:      budget.Categories,
+		Payees:          budget.Payees,
+		Transactions:    budget.Transactions,
 	}, nil
 }
 
@@ -67,15 +94,16 @@ func (c *Client) GetCategories(budgetID string) ([]*CategoryGroup, error) {
 	}
 
 	var response CategoriesResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse categories response: %w", err)
 	}
 
 	return response.Data.CategoryGroups, nil
 }
 
-// UpdateCategoryBudget updates the budgeted amount for a category in a specific month.
-func (c *Client) UpdateCategoryBudget(categoryID string, budgeted int64, month string, budgetID string) (*Category, error) {
+// BuildUpdateCategoryBudget builds the request UpdateCategoryBudget would send,
+// without sending it.
+func (c *Client) BuildUpdateCategoryBudget(categoryID string, budgeted int64, month string, budgetID string) (*PreparedRequest, error) {
 	if categoryID == "" {
 		return nil, fmt.Errorf("category_id is required")
 	}
@@ -102,18 +130,23 @@ func (c *Client) UpdateCategoryBudget(categoryID string, budgeted int64, month s
 			"budgeted": budgeted,
 		},
 	}
-	bodyBytes, err := json.Marshal(requestBody)
+	return newPreparedRequest("PATCH", endpoint, requestBody)
+}
+
+// UpdateCategoryBudget updates the budgeted amount for a category in a specific month.
+func (c *Client) UpdateCategoryBudget(categoryID string, budgeted int64, month string, budgetID string) (*Category, error) {
+	prepared, err := c.BuildUpdateCategoryBudget(categoryID, budgeted, month, budgetID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		return nil, err
 	}
 
-	respBody, err := c.request("PATCH", endpoint, bytes.NewReader(bodyBytes))
+	respBody, err := c.send(prepared)
 	if err != nil {
 		return nil, err
 	}
 
 	var response CategoryResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse category response: %w", err)
 	}
 
@@ -137,15 +170,96 @@ func (c *Client) GetAccounts(budgetID string) ([]*Account, error) {
 	}
 
 	var response AccountsResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse accounts response: %w", err)
 	}
 
 	return response.Data.Accounts, nil
 }
 
-// CreateTransaction creates a new transaction.
-func (c *Client) CreateTransaction(req *TransactionRequest) (*Transaction, error) {
+// BudgetFetchWorkers bounds how many budgets GetAccountsForBudgets fetches
+// at once.
+const BudgetFetchWorkers = 4
+
+// GetAccountsForBudgets retrieves the accounts of several budgets at once,
+// keyed by budget ID. Requests go through the client's rate limiter and
+// circuit breaker like any other, at most BudgetFetchWorkers at a time. A
+// failing budget does not stop the others: the map holds every budget that
+// succeeded, and the error joins one "budget <id>: ..." error per failure.
+// An empty ID means the default budget.
+func (c *Client) GetAccountsForBudgets(budgetIDs []string) (map[string][]*Account, error) {
+	// Resolve the default budget up front so the workers never race to cache it
+	ids := make([]string, 0, len(budgetIDs))
+	for _, id := range budgetIDs {
+		if id == "" {
+			var err error
+			if id, err = c.GetDefaultBudgetID(); err != nil {
+				return nil, err
+			}
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	accounts := make([][]*Account, len(ids))
+	errs := make([]error, len(ids))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(BudgetFetchWorkers, len(ids)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				accounts[i], errs[i] = c.GetAccounts(ids[i])
+			}
+		}()
+	}
+	for i := range ids {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	result := make(map[string][]*Account, len(ids))
+	var failed []error
+	for i, id := range ids {
+		if errs[i] != nil {
+			failed = append(failed, fmt.Errorf("budget %s: %w", id, errs[i]))
+			continue
+		}
+		result[id] = accounts[i]
+	}
+	return result, errors.Join(failed...)
+}
+
+// GetAccount retrieves a single account, including its current balances.
+func (c *Client) GetAccount(budgetID, accountID string) (*Account, error) {
+	if budgetID == "" {
+		var err error
+		budgetID, err = c.GetDefaultBudgetID()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	endpoint := fmt.Sprintf("/budgets/%s/accounts/%s", budgetID, accountID)
+	respBody, err := c.request("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response AccountResponse
+	if err := c.decode(respBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse account response: %w", err)
+	}
+
+	return response.Data.Account, nil
+}
+
+// BuildCreateTransaction builds the request CreateTransaction would send,
+// without sending it.
+func (c *Client) BuildCreateTransaction(req *TransactionRequest) (*PreparedRequest, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
@@ -161,16 +275,30 @@ func (c *Client) CreateTransaction(req *TransactionRequest) (*Transaction, error
 
 	endpoint := fmt.Sprintf("/budgets/%s/transactions", budgetID)
 
-	// Build transaction object
+	requestBody := map[string]interface{}{
+		"transaction": transactionBody(req),
+	}
+	return newPreparedRequest("POST", endpoint, requestBody)
+}
+
+// transactionBody builds the JSON object for a validated create request. An
+// empty Cleared is sent as "uncleared".
+func transactionBody(req *TransactionRequest) map[string]interface{} {
+	cleared := req.Cleared
+	if cleared == "" {
+		cleared = "uncleared"
+	}
 	txn := map[string]interface{}{
 		"account_id": req.AccountID,
 		"date":       req.Date,
 		"amount":     req.Amount,
-		"cleared":    req.Cleared,
+		"cleared":    cleared,
 		"approved":   req.Approved,
 	}
 
-	if req.PayeeName != "" {
+	if req.PayeeID != "" {
+		txn["payee_id"] = req.PayeeID
+	} else if req.PayeeName != "" {
 		txn["payee_name"] = req.PayeeName
 	}
 	if req.CategoryID != "" {
@@ -179,29 +307,132 @@ func (c *Client) CreateTransaction(req *TransactionRequest) (*Transaction, error
 	if req.Memo != "" {
 		txn["memo"] = req.Memo
 	}
-
-	requestBody := map[string]interface{}{
-		"transaction": txn,
+	if req.ImportID != "" {
+		txn["import_id"] = req.ImportID
+	}
+	if req.FlagColor != "" {
+		txn["flag_color"] = req.FlagColor
+	}
+	if len(req.Subtransactions) > 0 {
+		subs := make([]map[string]interface{}, 0, len(req.Subtransactions))
+		for _, sub := range req.Subtransactions {
+			item := map[string]interface{}{"amount": sub.Amount}
+			if sub.CategoryID != "" {
+				item["category_id"] = sub.CategoryID
+			}
+			if sub.Memo != "" {
+				item["memo"] = sub.Memo
+			}
+			subs = append(subs, item)
+		}
+		txn["subtransactions"] = subs
 	}
+	return txn
+}
 
-	bodyBytes, err := json.Marshal(requestBody)
+// CreateTransaction creates a new transaction.
+func (c *Client) CreateTransaction(req *TransactionRequest) (*Transaction, error) {
+	prepared, err := c.BuildCreateTransaction(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		return nil, err
 	}
 
-	respBody, err := c.request("POST", endpoint, bytes.NewReader(bodyBytes))
+	respBody, err := c.send(prepared)
 	if err != nil {
+		var ynabErr *YNABError
+		if req.ImportID != "" && errors.As(err, &ynabErr) && ynabErr.StatusCode == http.StatusConflict {
+			return nil, &DuplicateImportError{ImportID: req.ImportID}
+		}
 		return nil, err
 	}
 
 	var response TransactionResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse transaction response: %w", err)
 	}
 
+	// YNAB answers a duplicate import_id with success but no transaction
+	if response.Data.Transaction == nil && len(response.Data.DuplicateImportIDs) > 0 {
+		return nil, &DuplicateImportError{ImportID: response.Data.DuplicateImportIDs[0]}
+	}
+
 	return response.Data.Transaction, nil
 }
 
+// BuildCreateTransactions builds the request CreateTransactions would send,
+// without sending it. Every request is validated first, and requests without
+// an ImportID are sent with a generated one (see ImportID); reqs themselves
+// are left unchanged.
+func (c *Client) BuildCreateTransactions(budgetID string, reqs []*TransactionRequest) (*PreparedRequest, error) {
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("no transactions to create")
+	}
+	if budgetID == "" {
+		var err error
+		budgetID, err = c.GetDefaultBudgetID()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Generated IDs count occurrences per account, amount and date, the way
+	// YNAB numbers identical lines in a file import
+	occurrences := make(map[string]int)
+	transactions := make([]map[string]interface{}, 0, len(reqs))
+	for i, req := range reqs {
+		if req.BudgetID != "" && req.BudgetID != budgetID {
+			return nil, fmt.Errorf("transaction %d is for budget %s, not %s", i+1, req.BudgetID, budgetID)
+		}
+		body := *req
+		if body.ImportID == "" {
+			key := fmt.Sprintf("%s:%d:%s", req.AccountID, req.Amount, req.Date)
+			occurrences[key]++
+			body.ImportID = ImportID(req.Amount, req.Date, occurrences[key])
+		}
+		if err := body.Validate(); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i+1, err)
+		}
+		transactions = append(transactions, transactionBody(&body))
+	}
+
+	endpoint := fmt.Sprintf("/budgets/%s/transactions", budgetID)
+
+	requestBody := map[string]interface{}{
+		"transactions": transactions,
+	}
+	return newPreparedRequest("POST", endpoint, requestBody)
+}
+
+// CreateTransactions creates several transactions in one request. YNAB skips
+// any whose import_id it has already seen in the same account; their import
+// IDs are returned as duplicates rather than as an error.
+func (c *Client) CreateTransactions(budgetID string, reqs []*TransactionRequest) (created []*Transaction, duplicates []string, err error) {
+	prepared, err := c.BuildCreateTransactions(budgetID, reqs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody, err := c.send(prepared)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response TransactionResponse
+	if err := c.decode(respBody, &response); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse transactions response: %w", err)
+	}
+
+	return response.Data.Transactions, response.Data.DuplicateImportIDs, nil
+}
+
+// ImportID returns an import_id in YNAB's own format,
+// YNAB:<milliunits>:<date>:<occurrence>, where occurrence numbers identical
+// amount and date pairs in one account from 1. Re-importing the same lines
+// produces the same IDs, so YNAB skips them.
+func ImportID(amount int64, date string, occurrence int) string {
+	return fmt.Sprintf("YNAB:%d:%s:%d", amount, date, occurrence)
+}
+
 // GetTransactions retrieves transactions for a budget.
 // If sinceDate is non-empty, only transactions on or after that date are returned.
 func (c *Client) GetTransactions(budgetID string, sinceDate string) ([]*Transaction, error) {
@@ -224,13 +455,130 @@ func (c *Client) GetTransactions(budgetID string, sinceDate string) ([]*Transact
 	}
 
 	var response TransactionsResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse transactions response: %w", err)
 	}
 
 	return response.Data.Transactions, nil
 }
 
+// GetTransactionsFunc calls fn with each transaction in the budget, on or
+// after sinceDate if given, without building the whole list. The response is
+// decoded one transaction at a time as it arrives, so neither the raw
+// response nor the parsed transactions are held in memory. An error from fn
+// stops the iteration and is returned unchanged.
+func (c *Client) GetTransactionsFunc(budgetID, sinceDate string, fn func(*Transaction) error) error {
+	if budgetID == "" {
+		var err error
+		budgetID, err = c.GetDefaultBudgetID()
+		if err != nil {
+			return err
+		}
+	}
+
+	endpoint := fmt.Sprintf("/budgets/%s/transactions", budgetID)
+	if sinceDate != "" {
+		endpoint += fmt.Sprintf("?since_date=%s", sinceDate)
+	}
+
+	var fnErr, parseErr error
+	err := c.requestStream(endpoint, func(body io.Reader) error {
+		parseErr = c.streamTransactions(body, func(t *Transaction) error {
+			fnErr = fn(t)
+			return fnErr
+		})
+		return parseErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if parseErr != nil {
+		return fmt.Errorf("failed to parse transactions response: %w", parseErr)
+	}
+	return err
+}
+
+// streamTransactions walks a transactions list response, decoding and
+// passing on each element of data.transactions as it is reached.
+func (c *Client) streamTransactions(body io.Reader, fn func(*Transaction) error) error {
+	decoder := json.NewDecoder(body)
+	if c.strictJSON {
+		decoder.DisallowUnknownFields()
+	}
+
+	return c.walkObject(decoder, func(key string) error {
+		if key != "data" {
+			return c.skipField(decoder, key)
+		}
+		return c.walkObject(decoder, func(key string) error {
+			if key != "transactions" {
+				if key == "server_knowledge" {
+					var knowledge int64
+					return decoder.Decode(&knowledge)
+				}
+				return c.skipField(decoder, key)
+			}
+			if err := expectDelim(decoder, '['); err != nil {
+				return err
+			}
+			for decoder.More() {
+				var t Transaction
+				if err := decoder.Decode(&t); err != nil {
+					return err
+				}
+				if err := fn(&t); err != nil {
+					return err
+				}
+			}
+			return expectDelim(decoder, ']')
+		})
+	})
+}
+
+// walkObject reads a JSON object from decoder, calling field with each key
+// once the decoder is positioned at its value. field must consume the value.
+func (c *Client) walkObject(decoder *json.Decoder, field func(key string) error) error {
+	if err := expectDelim(decoder, '{'); err != nil {
+		return err
+	}
+	for decoder.More() {
+		tok, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected object key, got %v", tok)
+		}
+		if err := field(key); err != nil {
+			return err
+		}
+	}
+	return expectDelim(decoder, '}')
+}
+
+// skipField consumes the value of a field the stream doesn't use. In strict
+// mode an unknown field is an error, as it is for decode.
+func (c *Client) skipField(decoder *json.Decoder, key string) error {
+	if c.strictJSON {
+		return fmt.Errorf("strict JSON: json: unknown field %q", key)
+	}
+	var skip json.RawMessage
+	return decoder.Decode(&skip)
+}
+
+// expectDelim reads the next token and checks it is delim.
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	tok, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("expected %v, got %v", delim, tok)
+	}
+	return nil
+}
+
 // GetTransactionsByAccount retrieves transactions for a specific account.
 func (c *Client) GetTransactionsByAccount(budgetID, accountID, sinceDate string) ([]*Transaction, error) {
 	if budgetID == "" {
@@ -252,7 +600,35 @@ func (c *Client) GetTransactionsByAccount(budgetID, accountID, sinceDate string)
 	}
 
 	var response TransactionsResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse transactions response: %w", err)
+	}
+
+	return response.Data.Transactions, nil
+}
+
+// GetTransactionsByPayee retrieves transactions for a specific payee.
+func (c *Client) GetTransactionsByPayee(budgetID, payeeID, sinceDate string) ([]*Transaction, error) {
+	if budgetID == "" {
+		var err error
+		budgetID, err = c.GetDefaultBudgetID()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	endpoint := fmt.Sprintf("/budgets/%s/payees/%s/transactions", budgetID, payeeID)
+	if sinceDate != "" {
+		endpoint += fmt.Sprintf("?since_date=%s", sinceDate)
+	}
+
+	respBody, err := c.request("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response TransactionsResponse
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse transactions response: %w", err)
 	}
 
@@ -280,7 +656,7 @@ func (c *Client) GetTransactionsByCategory(budgetID, categoryID, sinceDate strin
 	}
 
 	var response TransactionsResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse transactions response: %w", err)
 	}
 
@@ -304,15 +680,16 @@ func (c *Client) GetTransaction(budgetID, transactionID string) (*Transaction, e
 	}
 
 	var response TransactionResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse transaction response: %w", err)
 	}
 
 	return response.Data.Transaction, nil
 }
 
-// UpdateTransaction updates an existing transaction.
-func (c *Client) UpdateTransaction(budgetID, transactionID string, txn map[string]interface{}) (*Transaction, error) {
+// BuildUpdateTransaction builds the request UpdateTransaction would send,
+// without sending it.
+func (c *Client) BuildUpdateTransaction(budgetID, transactionID string, txn map[string]interface{}) (*PreparedRequest, error) {
 	if budgetID == "" {
 		var err error
 		budgetID, err = c.GetDefaultBudgetID()
@@ -321,31 +698,101 @@ func (c *Client) UpdateTransaction(budgetID, transactionID string, txn map[strin
 		}
 	}
 
+	txn, err := sanitizeTransactionUpdate(txn)
+	if err != nil {
+		return nil, err
+	}
+
 	endpoint := fmt.Sprintf("/budgets/%s/transactions/%s", budgetID, transactionID)
 
 	requestBody := map[string]interface{}{
 		"transaction": txn,
 	}
-	bodyBytes, err := json.Marshal(requestBody)
+	return newPreparedRequest("PUT", endpoint, requestBody)
+}
+
+// UpdateTransaction updates an existing transaction.
+func (c *Client) UpdateTransaction(budgetID, transactionID string, txn map[string]interface{}) (*Transaction, error) {
+	prepared, err := c.BuildUpdateTransaction(budgetID, transactionID, txn)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		return nil, err
 	}
 
-	respBody, err := c.request("PUT", endpoint, bytes.NewReader(bodyBytes))
+	respBody, err := c.send(prepared)
 	if err != nil {
 		return nil, err
 	}
 
 	var response TransactionResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse transaction response: %w", err)
 	}
 
 	return response.Data.Transaction, nil
 }
 
-// DeleteTransaction deletes a transaction by ID.
-func (c *Client) DeleteTransaction(budgetID, transactionID string) (*Transaction, error) {
+// BuildPatchTransactions builds the request PatchTransactions would send,
+// without sending it.
+func (c *Client) BuildPatchTransactions(budgetID string, txns []map[string]interface{}) (*PreparedRequest, error) {
+	if budgetID == "" {
+		var err error
+		budgetID, err = c.GetDefaultBudgetID()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(txns) == 0 {
+		return nil, fmt.Errorf("no transactions to update")
+	}
+
+	transactions := make([]map[string]interface{}, 0, len(txns))
+	for i, txn := range txns {
+		id, _ := txn["id"].(string)
+		if id == "" {
+			return nil, fmt.Errorf("transaction %d has no id", i+1)
+		}
+		clean, err := sanitizeTransactionUpdate(txn)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", id, err)
+		}
+		// id is read-only for single updates but keys the bulk endpoint
+		clean["id"] = id
+		transactions = append(transactions, clean)
+	}
+
+	endpoint := fmt.Sprintf("/budgets/%s/transactions", budgetID)
+
+	requestBody := map[string]interface{}{
+		"transactions": transactions,
+	}
+	return newPreparedRequest("PATCH", endpoint, requestBody)
+}
+
+// PatchTransactions updates several transactions in one request. Each map
+// holds a transaction's "id" and the fields to change; read-only fields are
+// dropped as in UpdateTransaction. It returns the transactions as saved.
+func (c *Client) PatchTransactions(budgetID string, txns []map[string]interface{}) ([]*Transaction, error) {
+	prepared, err := c.BuildPatchTransactions(budgetID, txns)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := c.send(prepared)
+	if err != nil {
+		return nil, err
+	}
+
+	var response TransactionResponse
+	if err := c.decode(respBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse transactions response: %w", err)
+	}
+
+	return response.Data.Transactions, nil
+}
+
+// BuildDeleteTransaction builds the request DeleteTransaction would send,
+// without sending it.
+func (c *Client) BuildDeleteTransaction(budgetID, transactionID string) (*PreparedRequest, error) {
 	if budgetID == "" {
 		var err error
 		budgetID, err = c.GetDefaultBudgetID()
@@ -355,13 +802,23 @@ func (c *Client) DeleteTransaction(budgetID, transactionID string) (*Transaction
 	}
 
 	endpoint := fmt.Sprintf("/budgets/%s/transactions/%s", budgetID, transactionID)
-	respBody, err := c.request("DELETE", endpoint, nil)
+	return newPreparedRequest("DELETE", endpoint, nil)
+}
+
+// DeleteTransaction deletes a transaction by ID.
+func (c *Client) DeleteTransaction(budgetID, transactionID string) (*Transaction, error) {
+	prepared, err := c.BuildDeleteTransaction(budgetID, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := c.send(prepared)
 	if err != nil {
 		return nil, err
 	}
 
 	var response TransactionResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse transaction response: %w", err)
 	}
 
@@ -385,13 +842,47 @@ func (c *Client) GetPayees(budgetID string) ([]*Payee, error) {
 	}
 
 	var response PayeesResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse payees response: %w", err)
 	}
 
 	return response.Data.Payees, nil
 }
 
+// UpdatePayee renames a payee.
+func (c *Client) UpdatePayee(budgetID, payeeID, newName string) (*Payee, error) {
+	if budgetID == "" {
+		var err error
+		budgetID, err = c.GetDefaultBudgetID()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if strings.TrimSpace(newName) == "" {
+		return nil, fmt.Errorf("payee name is required")
+	}
+
+	endpoint := fmt.Sprintf("/budgets/%s/payees/%s", budgetID, payeeID)
+	prepared, err := newPreparedRequest("PATCH", endpoint, map[string]interface{}{
+		"payee": map[string]interface{}{"name": newName},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := c.send(prepared)
+	if err != nil {
+		return nil, err
+	}
+
+	var response PayeeResponse
+	if err := c.decode(respBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse payee response: %w", err)
+	}
+
+	return response.Data.Payee, nil
+}
+
 // GetMonths retrieves all budget months.
 func (c *Client) GetMonths(budgetID string) ([]*Month, error) {
 	if budgetID == "" {
@@ -409,7 +900,7 @@ func (c *Client) GetMonths(budgetID string) ([]*Month, error) {
 	}
 
 	var response MonthsResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse months response: %w", err)
 	}
 
@@ -433,7 +924,7 @@ func (c *Client) GetMonth(budgetID, month string) (*Month, error) {
 	}
 
 	var response MonthResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse month response: %w", err)
 	}
 
@@ -457,13 +948,77 @@ func (c *Client) GetScheduledTransactions(budgetID string) ([]*ScheduledTransact
 	}
 
 	var response ScheduledTransactionsResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse scheduled transactions response: %w", err)
 	}
 
 	return response.Data.ScheduledTransactions, nil
 }
 
+// BuildCreateScheduledTransaction builds the request
+// CreateScheduledTransaction would send, without sending it.
+func (c *Client) BuildCreateScheduledTransaction(budgetID string, req *ScheduledTransactionRequest) (*PreparedRequest, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if budgetID == "" {
+		var err error
+		budgetID, err = c.GetDefaultBudgetID()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scheduled := map[string]interface{}{
+		"account_id": req.AccountID,
+		"date":       req.Date,
+		"frequency":  req.Frequency,
+		"amount":     req.Amount,
+	}
+	if req.PayeeID != "" {
+		scheduled["payee_id"] = req.PayeeID
+	} else if req.PayeeName != "" {
+		scheduled["payee_name"] = req.PayeeName
+	}
+	if req.CategoryID != "" {
+		scheduled["category_id"] = req.CategoryID
+	}
+	if req.Memo != "" {
+		scheduled["memo"] = req.Memo
+	}
+	if req.FlagColor != "" {
+		scheduled["flag_color"] = req.FlagColor
+	}
+
+	endpoint := fmt.Sprintf("/budgets/%s/scheduled_transactions", budgetID)
+
+	requestBody := map[string]interface{}{
+		"scheduled_transaction": scheduled,
+	}
+	return newPreparedRequest("POST", endpoint, requestBody)
+}
+
+// CreateScheduledTransaction creates a scheduled transaction, which YNAB
+// enters on req.Date and then again at each req.Frequency interval.
+func (c *Client) CreateScheduledTransaction(budgetID string, req *ScheduledTransactionRequest) (*ScheduledTransaction, error) {
+	prepared, err := c.BuildCreateScheduledTransaction(budgetID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := c.send(prepared)
+	if err != nil {
+		return nil, err
+	}
+
+	var response ScheduledTransactionResponse
+	if err := c.decode(respBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse scheduled transaction response: %w", err)
+	}
+
+	return response.Data.ScheduledTransaction, nil
+}
+
 // CreateAccount creates a new account in a budget.
 func (c *Client) CreateAccount(budgetID string, name string, accountType string, balance int64) (*Account, error) {
 	if budgetID == "" {
@@ -494,27 +1049,189 @@ func (c *Client) CreateAccount(budgetID string, name string, accountType string,
 	}
 
 	var response AccountResponse
-	if err := json.Unmarshal(respBody, &response); err != nil {
+	if err := c.decode(respBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse account response: %w", err)
+	}
+
+	return response.Data.Account, nil
+}
+
+// UpdatableAccountFields are the keys UpdateAccount sends.
+var UpdatableAccountFields = []string{"name", "note", "closed"}
+
+// UpdateAccount changes an account's name, note or closed status. fields
+// holds only the keys to change; any key outside UpdatableAccountFields is
+// rejected before anything is sent.
+func (c *Client) UpdateAccount(budgetID, accountID string, fields map[string]interface{}) (*Account, error) {
+	if budgetID == "" {
+		var err error
+		budgetID, err = c.GetDefaultBudgetID()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no account fields to update")
+	}
+	for key := range fields {
+		if !slices.Contains(UpdatableAccountFields, key) {
+			return nil, fmt.Errorf("account field %q can't be updated (allowed: %s)", key, strings.Join(UpdatableAccountFields, ", "))
+		}
+	}
+
+	endpoint := fmt.Sprintf("/budgets/%s/accounts/%s", budgetID, accountID)
+	prepared, err := newPreparedRequest("PATCH", endpoint, map[string]interface{}{
+		"account": fields,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := c.send(prepared)
+	if err != nil {
+		return nil, err
+	}
+
+	var response AccountResponse
+	if err := c.decode(respBody, &response); err != nil {
 		return nil, fmt.Errorf("failed to parse account response: %w", err)
 	}
 
 	return response.Data.Account, nil
 }
 
+// UpdatableTransactionFields are the keys YNAB accepts when updating a
+// transaction.
+var UpdatableTransactionFields = []string{
+	"account_id", "date", "amount", "payee_id", "payee_name", "category_id",
+	"memo", "cleared", "approved", "flag_color", "subtransactions",
+}
+
+// readOnlyTransactionFields are server-computed keys that appear on fetched
+// transactions. They are dropped from updates so a fetched transaction can be
+// edited and sent back without YNAB rejecting it.
+var readOnlyTransactionFields = map[string]bool{
+	"id": true, "account_name": true, "category_name": true, "flag_name": true,
+	"transfer_account_id": true, "transfer_transaction_id": true,
+	"matched_transaction_id": true, "import_id": true, "import_payee_name": true,
+	"import_payee_name_original": true, "debt_transaction_type": true, "deleted": true,
+}
+
+// sanitizeTransactionUpdate returns a copy of txn without read-only fields.
+// Keys that are neither updatable nor known read-only are rejected, since
+// they are almost always typos that YNAB would answer with a 400.
+func sanitizeTransactionUpdate(txn map[string]interface{}) (map[string]interface{}, error) {
+	allowed := make(map[string]bool, len(UpdatableTransactionFields))
+	for _, field := range UpdatableTransactionFields {
+		allowed[field] = true
+	}
+
+	clean := make(map[string]interface{}, len(txn))
+	var unknown []string
+	for key, value := range txn {
+		switch {
+		case allowed[key]:
+			clean[key] = value
+		case readOnlyTransactionFields[key]:
+			// Server-computed; drop silently
+		default:
+			unknown = append(unknown, key)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("cannot update transaction field(s): %s (updatable fields: %s)",
+			strings.Join(unknown, ", "), strings.Join(UpdatableTransactionFields, ", "))
+	}
+	return clean, nil
+}
+
 // TransactionRequest represents a request to create a transaction.
 type TransactionRequest struct {
 	BudgetID   string
 	AccountID  string
 	Date       string // ISO format: YYYY-MM-DD
 	Amount     int64  // Amount in milliunits (negative for outflow)
+	PayeeID    string // Takes precedence over PayeeName; an account's transfer payee makes a transfer
+	PayeeName  string
+	CategoryID string
+	Memo       string
+	Cleared    string // One of ClearedStatuses
+	Approved   bool   // Sent as-is; false leaves the transaction for review in YNAB
+	ImportID   string // Optional idempotency key; YNAB skips duplicates per account
+	FlagColor  string // Optional; one of FlagColors
+
+	// Subtransactions make a split; their amounts must sum to Amount
+	Subtransactions []SubTransactionRequest
+}
+
+// SubTransactionRequest represents one line of a split transaction.
+type SubTransactionRequest struct {
+	Amount     int64 // Amount in milliunits
+	CategoryID string
+	Memo       string
+}
+
+// ScheduledTransactionRequest represents a request to create a scheduled
+// transaction.
+type ScheduledTransactionRequest struct {
+	AccountID  string
+	Date       string // First occurrence, YYYY-MM-DD; YNAB wants a future date at most 5 years out
+	Frequency  string // One of ScheduledFrequencies
+	Amount     int64  // Amount in milliunits (negative for outflow)
+	PayeeID    string // Takes precedence over PayeeName
 	PayeeName  string
 	CategoryID string
 	Memo       string
-	Cleared    string // "cleared", "uncleared", "reconciled"
-	Approved   bool
+	FlagColor  string // Optional; one of FlagColors
+}
+
+// ScheduledFrequencies are the frequency values YNAB accepts for a scheduled
+// transaction.
+var ScheduledFrequencies = []string{
+	"never", "daily", "weekly", "everyOtherWeek", "twiceAMonth", "every4Weeks",
+	"monthly", "everyOtherMonth", "every3Months", "every4Months", "twiceAYear",
+	"yearly", "everyOtherYear",
 }
 
-// Validate validates the transaction request.
+// Validate validates the scheduled transaction request.
+func (r *ScheduledTransactionRequest) Validate() error {
+	if r.AccountID == "" {
+		return fmt.Errorf("account_id is required")
+	}
+	if r.Date == "" {
+		return fmt.Errorf("date is required")
+	}
+	if !slices.Contains(ScheduledFrequencies, r.Frequency) {
+		return fmt.Errorf("invalid frequency: %q (expected %s)", r.Frequency, strings.Join(ScheduledFrequencies, ", "))
+	}
+	if r.FlagColor != "" && !ValidFlagColor(r.FlagColor) {
+		return fmt.Errorf("invalid flag_color: %s (expected %s)", r.FlagColor, strings.Join(FlagColors, ", "))
+	}
+	return nil
+}
+
+// ClearedStatuses are the cleared values YNAB accepts for a transaction.
+var ClearedStatuses = []string{"cleared", "uncleared", "reconciled"}
+
+// FlagColors are the transaction flag colors YNAB accepts.
+var FlagColors = []string{"red", "orange", "yellow", "green", "blue", "purple"}
+
+// ValidFlagColor reports whether color is one of FlagColors.
+func ValidFlagColor(color string) bool {
+	for _, c := range FlagColors {
+		if c == color {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxImportIDLength is the longest import_id YNAB accepts.
+const MaxImportIDLength = 36
+
+// Validate validates the transaction request without changing it.
 func (r *TransactionRequest) Validate() error {
 	if r.AccountID == "" {
 		return fmt.Errorf("account_id is required")
@@ -522,9 +1239,20 @@ func (r *TransactionRequest) Validate() error {
 	if r.Date == "" {
 		return fmt.Errorf("date is required")
 	}
-	if r.Cleared == "" {
-		r.Cleared = "uncleared"
+	if len(r.ImportID) > MaxImportIDLength {
+		return fmt.Errorf("import_id must be at most %d characters (got %d)", MaxImportIDLength, len(r.ImportID))
+	}
+	if r.FlagColor != "" && !ValidFlagColor(r.FlagColor) {
+		return fmt.Errorf("invalid flag_color: %s (expected %s)", r.FlagColor, strings.Join(FlagColors, ", "))
+	}
+	if len(r.Subtransactions) > 0 {
+		var sum int64
+		for _, sub := range r.Subtransactions {
+			sum += sub.Amount
+		}
+		if sum != r.Amount {
+			return fmt.Errorf("subtransactions sum to %d milliunits but amount is %d", sum, r.Amount)
+		}
 	}
-	// Approved defaults to true if not set
 	return nil
 }
@@ -1,13 +1,117 @@
 package api
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
 
+// TestGetUser tests the GetUser method.
+func TestGetUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			t.Errorf("Expected path /user, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Expected bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`{"data":{"user":{"id":"user-123"}}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		strictJSON: true,
+	}
+
+	user, err := client.GetUser()
+	if err != nil {
+		t.Fatalf("GetUser failed: %v", err)
+	}
+	if user.ID != "user-123" {
+		t.Errorf("Expected user ID user-123, got %s", user.ID)
+	}
+}
+
+// TestUpdatePayee tests the UpdatePayee method.
+func TestUpdatePayee(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/budgets/budget-1/payees/payee-1" {
+			t.Errorf("Expected PATCH /budgets/budget-1/payees/payee-1, got %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["payee"]["name"] != "Whole Foods" {
+			t.Errorf("Expected payee name in body, got %v (%v)", body, err)
+		}
+		w.Write([]byte(`{"data":{"payee":{"id":"payee-1","name":"Whole Foods","deleted":false}}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+
+	payee, err := client.UpdatePayee("budget-1", "payee-1", "Whole Foods")
+	if err != nil {
+		t.Fatalf("UpdatePayee failed: %v", err)
+	}
+	if payee.Name != "Whole Foods" {
+		t.Errorf("Expected renamed payee, got %+v", payee)
+	}
+
+	if _, err := client.UpdatePayee("budget-1", "payee-1", "  "); err == nil {
+		t.Error("Expected error for an empty name")
+	}
+}
+
+// TestUpdateAccount tests the UpdateAccount method.
+func TestUpdateAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/budgets/budget-1/accounts/acct-1" {
+			t.Errorf("Expected PATCH /budgets/budget-1/accounts/acct-1, got %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["account"]["closed"] != true || body["account"]["note"] != "paid off" {
+			t.Errorf("Expected closed and note in body, got %v (%v)", body, err)
+		}
+		w.Write([]byte(`{"data":{"account":{"id":"acct-1","name":"Car Loan","note":"paid off","closed":true}}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+
+	account, err := client.UpdateAccount("budget-1", "acct-1", map[string]interface{}{"closed": true, "note": "paid off"})
+	if err != nil {
+		t.Fatalf("UpdateAccount failed: %v", err)
+	}
+	if !account.Closed || account.Note != "paid off" {
+		t.Errorf("Expected closed account with note, got %+v", account)
+	}
+
+	if _, err := client.UpdateAccount("budget-1", "acct-1", map[string]interface{}{"balance": 0}); err == nil {
+		t.Error("Expected error for a field that can't be updated")
+	}
+	if _, err := client.UpdateAccount("budget-1", "acct-1", nil); err == nil {
+		t.Error("Expected error for no fields")
+	}
+}
+
 // TestGetBudgets tests the GetBudgets method.
 func TestGetBudgets(t *testing.T) {
 	// Create test server
@@ -29,7 +133,7 @@ func TestGetBudgets(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
-		token:      "test-token",
+		auth:       newAuthState("test-token"),
 		baseURL:    server.URL,
 		httpClient: &http.Client{Timeout: 5 * time.Second},
 	}
@@ -64,7 +168,7 @@ func TestGetAccounts(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
-		token:      "test-token",
+		auth:       newAuthState("test-token"),
 		baseURL:    server.URL,
 		httpClient: &http.Client{Timeout: 5 * time.Second},
 	}
@@ -82,6 +186,244 @@ func TestGetAccounts(t *testing.T) {
 	}
 }
 
+// TestGetAccountsForBudgets tests fetching several budgets' accounts at once.
+func TestGetAccountsForBudgets(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	accountsHandler := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+
+			response := AccountsResponse{}
+			response.Data.Accounts = []*Account{{ID: name + "-acc", Name: name}}
+			json.NewEncoder(w).Encode(response)
+		}
+	}
+
+	mux := http.NewServeMux()
+	budgetIDs := []string{"b1", "b2", "b3", "b4", "b5", "missing", "b6"}
+	for _, id := range budgetIDs {
+		if id == "missing" {
+			continue
+		}
+		mux.HandleFunc("/budgets/"+id+"/accounts", accountsHandler(id))
+	}
+	mux.HandleFunc("/budgets/missing/accounts", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"id":"404.2","name":"resource_not_found","detail":"Resource not found"}}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		limiter:    NewRateLimiter(100, time.Minute),
+	}
+
+	result, err := client.GetAccountsForBudgets(budgetIDs)
+	if err == nil {
+		t.Fatal("Expected an error for the missing budget")
+	}
+	if !strings.Contains(err.Error(), "budget missing:") {
+		t.Errorf("Expected the error to name the budget, got %v", err)
+	}
+	var ynabErr *YNABError
+	if !errors.As(err, &ynabErr) || ynabErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected a wrapped 404 YNABError, got %v", err)
+	}
+
+	if len(result) != 6 {
+		t.Fatalf("Expected accounts for 6 budgets, got %d", len(result))
+	}
+	for _, id := range []string{"b1", "b2", "b3", "b4", "b5", "b6"} {
+		accounts := result[id]
+		if len(accounts) != 1 || accounts[0].Name != id {
+			t.Errorf("Budget %s: unexpected accounts %v", id, accounts)
+		}
+	}
+	if _, ok := result["missing"]; ok {
+		t.Error("Expected no entry for the missing budget")
+	}
+
+	if p := peak.Load(); p > BudgetFetchWorkers {
+		t.Errorf("Expected at most %d requests in flight, saw %d", BudgetFetchWorkers, p)
+	}
+	if got := client.RateLimiter().Remaining(); got != 100-len(budgetIDs) {
+		t.Errorf("Expected %d requests counted by the rate limiter, got %d remaining", len(budgetIDs), got)
+	}
+}
+
+// TestGetAccount tests the GetAccount method.
+func TestGetAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/budgets/test-budget/accounts/acc-1" {
+			t.Errorf("Expected path /budgets/test-budget/accounts/acc-1, got %s", r.URL.Path)
+		}
+
+		response := AccountResponse{}
+		response.Data.Account = &Account{ID: "acc-1", Name: "Checking", Type: "checking", Balance: 100000}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+
+	account, err := client.GetAccount("test-budget", "acc-1")
+	if err != nil {
+		t.Fatalf("GetAccount failed: %v", err)
+	}
+
+	if account.ID != "acc-1" || account.Balance != 100000 {
+		t.Errorf("Expected acc-1 with balance 100000, got %+v", account)
+	}
+}
+
+// TestGetTransactionsFunc_Streams tests that transactions reach fn while
+// the gzipped response is still arriving.
+func TestGetTransactionsFunc_Streams(t *testing.T) {
+	seen := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`{"data":{"transactions":[{"id":"t1","amount":-1000},`))
+		gz.Flush()
+		w.(http.Flusher).Flush()
+
+		// The rest is only sent once the client has decoded t1
+		select {
+		case <-seen:
+		case <-time.After(5 * time.Second):
+			t.Error("t1 was not decoded before the response finished")
+		}
+		gz.Write([]byte(`{"id":"t2","amount":2000}],"server_knowledge":1}}`))
+		gz.Close()
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+
+	var ids []string
+	err := client.GetTransactionsFunc("test-budget", "", func(txn *Transaction) error {
+		ids = append(ids, txn.ID)
+		if txn.ID == "t1" {
+			close(seen)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("GetTransactionsFunc failed: %v", err)
+	}
+	if strings.Join(ids, ",") != "t1,t2" {
+		t.Errorf("got ids %v, want t1,t2", ids)
+	}
+}
+
+// TestGetTransactionsFunc tests streaming transactions without a list.
+func TestGetTransactionsFunc(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/budgets/test-budget/transactions" || r.URL.Query().Get("since_date") != "2025-01-01" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"data":{"transactions":[
+			{"id":"t1","amount":-1000},
+			{"id":"t2","amount":2000},
+			{"id":"t3","amount":-3000}
+		],"server_knowledge":42}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+
+	var ids []string
+	var total int64
+	err := client.GetTransactionsFunc("test-budget", "2025-01-01", func(txn *Transaction) error {
+		ids = append(ids, txn.ID)
+		total += txn.Amount
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("GetTransactionsFunc failed: %v", err)
+	}
+	if strings.Join(ids, ",") != "t1,t2,t3" || total != -2000 {
+		t.Errorf("got ids %v, total %d", ids, total)
+	}
+
+	// An error from fn stops the stream and comes back unchanged
+	stop := errors.New("stop")
+	calls := 0
+	err = client.GetTransactionsFunc("test-budget", "2025-01-01", func(*Transaction) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("got err %v after %d calls, want stop after 1", err, calls)
+	}
+}
+
+// TestGetBudget tests that GetBudget sends the delta parameter and reads the
+// entity lists nested in the budget object.
+func TestGetBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/budgets/test-budget" {
+			t.Errorf("Expected path /budgets/test-budget, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("last_knowledge_of_server"); got != "41" {
+			t.Errorf("Expected last_knowledge_of_server=41, got %q", got)
+		}
+
+		w.Write([]byte(`{"data": {"server_knowledge": 42, "budget": {
+			"id": "test-budget", "name": "Household",
+			"accounts": [{"id": "acc-1", "name": "Checking"}],
+			"categories": [{"id": "cat-1", "name": "Groceries", "deleted": true}],
+			"transactions": [{"id": "txn-1", "amount": -5000}]
+		}}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+
+	detail, err := client.GetBudget("test-budget", 41)
+	if err != nil {
+		t.Fatalf("GetBudget failed: %v", err)
+	}
+
+	if detail.ServerKnowledge != 42 || detail.Budget.Name != "Household" {
+		t.Errorf("Expected knowledge 42 for Household, got %d for %q", detail.ServerKnowledge, detail.Budget.Name)
+	}
+	if len(detail.Accounts) != 1 || len(detail.Categories) != 1 || len(detail.Transactions) != 1 {
+		t.Fatalf("Expected one account, category and transaction, got %d, %d, %d",
+			len(detail.Accounts), len(detail.Categories), len(detail.Transactions))
+	}
+	if !detail.Categories[0].Deleted || detail.Transactions[0].Amount != -5000 {
+		t.Errorf("Unexpected entities: %+v, %+v", detail.Categories[0], detail.Transactions[0])
+	}
+}
+
 // TestGetCategories tests the GetCategories method.
 func TestGetCategories(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -105,7 +447,7 @@ func TestGetCategories(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
-		token:      "test-token",
+		auth:       newAuthState("test-token"),
 		baseURL:    server.URL,
 		httpClient: &http.Client{Timeout: 5 * time.Second},
 	}
@@ -157,7 +499,7 @@ func TestCreateTransaction(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
-		token:      "test-token",
+		auth:       newAuthState("test-token"),
 		baseURL:    server.URL,
 		httpClient: &http.Client{Timeout: 5 * time.Second},
 	}
@@ -185,6 +527,320 @@ func TestCreateTransaction(t *testing.T) {
 	}
 }
 
+// TestCreateScheduledTransaction tests the CreateScheduledTransaction method.
+func TestCreateScheduledTransaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/budgets/test-budget/scheduled_transactions" {
+			t.Errorf("Expected path /budgets/test-budget/scheduled_transactions, got %s", r.URL.Path)
+		}
+
+		var reqBody struct {
+			ScheduledTransaction map[string]interface{} `json:"scheduled_transaction"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		sent := reqBody.ScheduledTransaction
+		if sent["frequency"] != "monthly" || sent["date"] != "2025-02-01" || sent["payee_name"] != "Landlord" {
+			t.Errorf("Unexpected request body: %v", sent)
+		}
+		if _, ok := sent["memo"]; ok {
+			t.Errorf("Expected no memo in the request, got %v", sent["memo"])
+		}
+
+		response := ScheduledTransactionResponse{}
+		response.Data.ScheduledTransaction = &ScheduledTransaction{
+			ID:        "sched-1",
+			DateFirst: "2025-02-01",
+			DateNext:  "2025-02-01",
+			Frequency: "monthly",
+			Amount:    -1500000,
+			AccountID: "acc-1",
+			PayeeName: "Landlord",
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+
+	req := &ScheduledTransactionRequest{
+		AccountID: "acc-1",
+		Date:      "2025-02-01",
+		Frequency: "monthly",
+		Amount:    -1500000,
+		PayeeName: "Landlord",
+	}
+	scheduled, err := client.CreateScheduledTransaction("test-budget", req)
+	if err != nil {
+		t.Fatalf("CreateScheduledTransaction failed: %v", err)
+	}
+	if scheduled.ID != "sched-1" || scheduled.DateNext != "2025-02-01" {
+		t.Errorf("Unexpected scheduled transaction: %+v", scheduled)
+	}
+
+	// An unknown frequency fails before anything is sent
+	req.Frequency = "fortnightly"
+	if _, err := client.CreateScheduledTransaction("test-budget", req); err == nil || !strings.Contains(err.Error(), "invalid frequency") {
+		t.Errorf("Expected an invalid frequency error, got %v", err)
+	}
+}
+
+// TestCreateTransaction_DuplicateImportID tests that a skipped duplicate
+// import_id is reported instead of a created transaction.
+func TestCreateTransaction_DuplicateImportID(t *testing.T) {
+	var sentImportID interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody struct {
+			Transaction map[string]interface{} `json:"transaction"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		sentImportID = reqBody.Transaction["import_id"]
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {"transaction_ids": [], "duplicate_import_ids": ["sync-42"], "server_knowledge": 10}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+
+	txn, err := client.CreateTransaction(&TransactionRequest{
+		BudgetID:  "test-budget",
+		AccountID: "acc-1",
+		Date:      "2024-01-15",
+		Amount:    -5000,
+		ImportID:  "sync-42",
+	})
+	if txn != nil {
+		t.Errorf("Expected no transaction for a duplicate, got %+v", txn)
+	}
+	if !IsDuplicateImportError(err) {
+		t.Fatalf("Expected DuplicateImportError, got %v", err)
+	}
+	if sentImportID != "sync-42" {
+		t.Errorf("Expected import_id sync-42 in request body, got %v", sentImportID)
+	}
+}
+
+// TestCreateTransactions tests bulk creation: generated import IDs, and
+// duplicates reported instead of failing.
+func TestCreateTransactions(t *testing.T) {
+	var sent []map[string]interface{}
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != "POST" || r.URL.Path != "/budgets/test-budget/transactions" {
+			t.Errorf("Expected POST /budgets/test-budget/transactions, got %s %s", r.Method, r.URL.Path)
+		}
+		var reqBody struct {
+			Transactions []map[string]interface{} `json:"transactions"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		sent = reqBody.Transactions
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data": {
+			"transaction_ids": ["txn-1", "txn-2"],
+			"transactions": [{"id": "txn-1"}, {"id": "txn-2"}],
+			"duplicate_import_ids": ["bank-7"]}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+
+	reqs := []*TransactionRequest{
+		{AccountID: "acc-1", Date: "2024-01-15", Amount: -5000},
+		{AccountID: "acc-1", Date: "2024-01-15", Amount: -5000},
+		{AccountID: "acc-2", Date: "2024-01-15", Amount: -5000},
+		{AccountID: "acc-1", Date: "2024-01-16", Amount: -1200, ImportID: "bank-7"},
+	}
+	created, duplicates, err := client.CreateTransactions("test-budget", reqs)
+	if err != nil {
+		t.Fatalf("CreateTransactions failed: %v", err)
+	}
+	if len(created) != 2 || len(duplicates) != 1 || duplicates[0] != "bank-7" {
+		t.Errorf("Expected 2 created and duplicate bank-7, got %d and %v", len(created), duplicates)
+	}
+
+	wantIDs := []string{"YNAB:-5000:2024-01-15:1", "YNAB:-5000:2024-01-15:2", "YNAB:-5000:2024-01-15:1", "bank-7"}
+	if len(sent) != len(wantIDs) {
+		t.Fatalf("Expected %d transactions sent, got %d", len(wantIDs), len(sent))
+	}
+	for i, want := range wantIDs {
+		if sent[i]["import_id"] != want {
+			t.Errorf("transaction %d: import_id %v, want %s", i+1, sent[i]["import_id"], want)
+		}
+	}
+
+	// The generated IDs are only sent, not written back
+	for i, req := range reqs[:3] {
+		if req.ImportID != "" || req.Cleared != "" {
+			t.Errorf("request %d was changed: import_id %q, cleared %q", i+1, req.ImportID, req.Cleared)
+		}
+	}
+
+	t.Run("invalid request sends nothing", func(t *testing.T) {
+		calls = 0
+		_, _, err := client.CreateTransactions("test-budget", []*TransactionRequest{
+			{AccountID: "acc-1", Date: "2024-01-15", Amount: -5000},
+			{AccountID: "acc-1", Amount: -5000},
+		})
+		if err == nil || !strings.Contains(err.Error(), "transaction 2") {
+			t.Errorf("Expected an error naming transaction 2, got %v", err)
+		}
+		if calls != 0 {
+			t.Errorf("Expected no request, got %d", calls)
+		}
+	})
+}
+
+// TestUpdateTransaction_StripsReadOnlyFields tests that only updatable
+// fields reach the server.
+func TestUpdateTransaction_StripsReadOnlyFields(t *testing.T) {
+	var sent map[string]interface{}
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var reqBody struct {
+			Transaction map[string]interface{} `json:"transaction"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		sent = reqBody.Transaction
+		w.Write([]byte(`{"data": {"transaction": {"id": "txn-1"}}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+
+	mixed := map[string]interface{}{
+		"amount":                 int64(-5000),
+		"memo":                   "updated",
+		"account_name":           "Checking",
+		"matched_transaction_id": "txn-0",
+		"id":                     "txn-1",
+	}
+	if _, err := client.UpdateTransaction("test-budget", "txn-1", mixed); err != nil {
+		t.Fatalf("UpdateTransaction failed: %v", err)
+	}
+	if len(sent) != 2 || sent["memo"] != "updated" || sent["amount"] == nil {
+		t.Errorf("Expected only amount and memo to be sent, got %v", sent)
+	}
+	if _, ok := mixed["account_name"]; !ok {
+		t.Error("Caller's map must not be modified")
+	}
+
+	t.Run("unknown field rejected", func(t *testing.T) {
+		calls = 0
+		_, err := client.UpdateTransaction("test-budget", "txn-1", map[string]interface{}{"ammount": 1})
+		if err == nil || !strings.Contains(err.Error(), "ammount") {
+			t.Fatalf("Expected error naming the unknown field, got %v", err)
+		}
+		if calls != 0 {
+			t.Errorf("Expected no request for an invalid update, got %d", calls)
+		}
+	})
+}
+
+// TestPatchTransactions tests the bulk PATCH update and its request body.
+func TestPatchTransactions(t *testing.T) {
+	var rawBody map[string]json.RawMessage
+	var sent []map[string]interface{}
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != "PATCH" || r.URL.Path != "/budgets/test-budget/transactions" {
+			t.Errorf("Expected PATCH /budgets/test-budget/transactions, got %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&rawBody); err != nil {
+			t.Errorf("Failed to decode request body: %v", err)
+		}
+		if err := json.Unmarshal(rawBody["transactions"], &sent); err != nil {
+			t.Errorf("Failed to decode transactions array: %v", err)
+		}
+		w.Write([]byte(`{"data": {"transaction_ids": ["txn-1", "txn-2"], "transactions": [
+			{"id": "txn-1", "approved": true}, {"id": "txn-2", "category_id": "cat-1"}]}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+
+	first := map[string]interface{}{"id": "txn-1", "approved": true, "account_name": "Checking"}
+	saved, err := client.PatchTransactions("test-budget", []map[string]interface{}{
+		first,
+		{"id": "txn-2", "category_id": "cat-1"},
+	})
+	if err != nil {
+		t.Fatalf("PatchTransactions failed: %v", err)
+	}
+
+	// Body is {"transactions": [{"id": ..., <fields>}, ...]} and nothing else
+	if len(rawBody) != 1 {
+		t.Errorf("Expected only a transactions key, got %v", rawBody)
+	}
+	if len(sent) != 2 {
+		t.Fatalf("Expected 2 transactions, got %v", sent)
+	}
+	if len(sent[0]) != 2 || sent[0]["id"] != "txn-1" || sent[0]["approved"] != true {
+		t.Errorf("Unexpected first transaction: %v", sent[0])
+	}
+	if len(sent[1]) != 2 || sent[1]["id"] != "txn-2" || sent[1]["category_id"] != "cat-1" {
+		t.Errorf("Unexpected second transaction: %v", sent[1])
+	}
+	if len(saved) != 2 || !saved[0].Approved || saved[1].CategoryID != "cat-1" {
+		t.Errorf("Unexpected saved transactions: %+v", saved)
+	}
+	if _, ok := first["account_name"]; !ok {
+		t.Error("Caller's map must not be modified")
+	}
+
+	t.Run("invalid input sends nothing", func(t *testing.T) {
+		calls = 0
+		invalid := [][]map[string]interface{}{
+			nil,
+			{{"approved": true}},
+			{{"id": "txn-1", "aproved": true}},
+		}
+		for _, txns := range invalid {
+			if _, err := client.PatchTransactions("test-budget", txns); err == nil {
+				t.Errorf("Expected an error for %v", txns)
+			}
+		}
+		if calls != 0 {
+			t.Errorf("Expected no requests, got %d", calls)
+		}
+	})
+}
+
 // TestTransactionRequestValidation tests the Validate method.
 func TestTransactionRequestValidation(t *testing.T) {
 	tests := []struct {
@@ -217,6 +873,50 @@ func TestTransactionRequestValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "import_id at limit",
+			req: &TransactionRequest{
+				AccountID: "acc-1",
+				Date:      "2024-01-15",
+				ImportID:  strings.Repeat("x", MaxImportIDLength),
+			},
+			wantErr: false,
+		},
+		{
+			name: "import_id too long",
+			req: &TransactionRequest{
+				AccountID: "acc-1",
+				Date:      "2024-01-15",
+				ImportID:  strings.Repeat("x", MaxImportIDLength+1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "splits sum to amount",
+			req: &TransactionRequest{
+				AccountID: "acc-1",
+				Date:      "2024-01-15",
+				Amount:    -100000,
+				Subtransactions: []SubTransactionRequest{
+					{Amount: -60000, CategoryID: "cat-1"},
+					{Amount: -40000, CategoryID: "cat-2"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "splits don't sum to amount",
+			req: &TransactionRequest{
+				AccountID: "acc-1",
+				Date:      "2024-01-15",
+				Amount:    -100000,
+				Subtransactions: []SubTransactionRequest{
+					{Amount: -60000, CategoryID: "cat-1"},
+					{Amount: -30000, CategoryID: "cat-2"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -228,3 +928,253 @@ func TestTransactionRequestValidation(t *testing.T) {
 		})
 	}
 }
+
+// TestBuildCreateTransaction_ApprovedState verifies that the approval state
+// is always sent explicitly, since YNAB treats a missing value as unapproved.
+func TestBuildCreateTransaction_ApprovedState(t *testing.T) {
+	client := &Client{auth: newAuthState("test-token")}
+
+	for _, approved := range []bool{true, false} {
+		prepared, err := client.BuildCreateTransaction(&TransactionRequest{
+			BudgetID:  "test-budget",
+			AccountID: "acc-1",
+			Date:      "2024-01-15",
+			Amount:    -5000,
+			Approved:  approved,
+		})
+		if err != nil {
+			t.Fatalf("BuildCreateTransaction failed: %v", err)
+		}
+
+		var body struct {
+			Transaction map[string]interface{} `json:"transaction"`
+		}
+		if err := json.Unmarshal(prepared.Body, &body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		got, ok := body.Transaction["approved"]
+		if !ok {
+			t.Fatalf("approved=%v: body has no approved field: %s", approved, prepared.Body)
+		}
+		if got != approved {
+			t.Errorf("approved = %v, want %v", got, approved)
+		}
+	}
+}
+
+// TestBuildCreateTransaction_PayeeID verifies that a payee ID (e.g. an
+// account's transfer payee) is sent instead of a payee name.
+func TestBuildCreateTransaction_PayeeID(t *testing.T) {
+	client := &Client{auth: newAuthState("test-token")}
+
+	prepared, err := client.BuildCreateTransaction(&TransactionRequest{
+		BudgetID:  "test-budget",
+		AccountID: "acc-1",
+		Date:      "2024-01-15",
+		Amount:    -5000,
+		PayeeID:   "transfer-payee-2",
+		PayeeName: "Transfer : Savings",
+	})
+	if err != nil {
+		t.Fatalf("BuildCreateTransaction failed: %v", err)
+	}
+
+	var body struct {
+		Transaction map[string]interface{} `json:"transaction"`
+	}
+	if err := json.Unmarshal(prepared.Body, &body); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	if got := body.Transaction["payee_id"]; got != "transfer-payee-2" {
+		t.Errorf("payee_id = %v, want transfer-payee-2", got)
+	}
+	if _, ok := body.Transaction["payee_name"]; ok {
+		t.Errorf("payee_name should be omitted when payee_id is set: %s", prepared.Body)
+	}
+}
+
+// TestBuildCreateTransaction_FlagColor verifies that a flag is sent and an
+// unknown color is rejected before sending.
+func TestBuildCreateTransaction_FlagColor(t *testing.T) {
+	client := &Client{auth: newAuthState("test-token")}
+	req := &TransactionRequest{
+		BudgetID:  "test-budget",
+		AccountID: "acc-1",
+		Date:      "2024-01-15",
+		Amount:    -5000,
+		FlagColor: "red",
+	}
+
+	prepared, err := client.BuildCreateTransaction(req)
+	if err != nil {
+		t.Fatalf("BuildCreateTransaction failed: %v", err)
+	}
+	if !strings.Contains(string(prepared.Body), `"flag_color":"red"`) {
+		t.Errorf("flag_color missing from body: %s", prepared.Body)
+	}
+
+	req.FlagColor = "pink"
+	if _, err := client.BuildCreateTransaction(req); err == nil || !strings.Contains(err.Error(), "flag_color") {
+		t.Errorf("expected an invalid flag_color error, got %v", err)
+	}
+}
+
+// TestBuildCreateTransaction_Subtransactions verifies that splits are sent
+// as a subtransactions array.
+func TestBuildCreateTransaction_Subtransactions(t *testing.T) {
+	client := &Client{auth: newAuthState("test-token")}
+
+	prepared, err := client.BuildCreateTransaction(&TransactionRequest{
+		BudgetID:  "test-budget",
+		AccountID: "acc-1",
+		Date:      "2024-01-15",
+		Amount:    -100000,
+		Subtransactions: []SubTransactionRequest{
+			{Amount: -60000, CategoryID: "cat-groceries"},
+			{Amount: -40000, CategoryID: "cat-household"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BuildCreateTransaction failed: %v", err)
+	}
+
+	var body struct {
+		Transaction struct {
+			Subtransactions []struct {
+				Amount     int64  `json:"amount"`
+				CategoryID string `json:"category_id"`
+			} `json:"subtransactions"`
+		} `json:"transaction"`
+	}
+	if err := json.Unmarshal(prepared.Body, &body); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	subs := body.Transaction.Subtransactions
+	if len(subs) != 2 {
+		t.Fatalf("expected 2 subtransactions, got %s", prepared.Body)
+	}
+	if subs[0].Amount != -60000 || subs[0].CategoryID != "cat-groceries" {
+		t.Errorf("first subtransaction = %+v", subs[0])
+	}
+	if subs[1].Amount != -40000 || subs[1].CategoryID != "cat-household" {
+		t.Errorf("second subtransaction = %+v", subs[1])
+	}
+}
+
+// TestBuildRequests_MatchSentBody verifies that the Build* methods produce
+// exactly the bytes the corresponding mutating call sends.
+func TestBuildRequests_MatchSentBody(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		gotBody, _ = io.ReadAll(r.Body)
+		w.Write([]byte(`{"data": {"transaction": {"id": "txn-1"}, "category": {"id": "cat-1"}, "scheduled_transaction": {"id": "sched-1"}}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+
+	txnReq := func() *TransactionRequest {
+		return &TransactionRequest{
+			BudgetID:   "test-budget",
+			AccountID:  "acc-1",
+			Date:       "2024-01-15",
+			Amount:     -12340,
+			PayeeName:  "Coffee Shop",
+			CategoryID: "cat-1",
+			Memo:       "latte",
+			Approved:   true,
+		}
+	}
+	updates := map[string]interface{}{"amount": int64(-5000), "memo": "updated", "approved": true}
+	scheduledReq := &ScheduledTransactionRequest{
+		AccountID: "acc-1",
+		Date:      "2025-02-01",
+		Frequency: "monthly",
+		Amount:    -1500000,
+		PayeeName: "Landlord",
+	}
+
+	tests := []struct {
+		name  string
+		build func() (*PreparedRequest, error)
+		send  func() error
+	}{
+		{
+			name:  "create transaction",
+			build: func() (*PreparedRequest, error) { return client.BuildCreateTransaction(txnReq()) },
+			send:  func() error { _, err := client.CreateTransaction(txnReq()); return err },
+		},
+		{
+			name: "update transaction",
+			build: func() (*PreparedRequest, error) {
+				return client.BuildUpdateTransaction("test-budget", "txn-1", updates)
+			},
+			send: func() error { _, err := client.UpdateTransaction("test-budget", "txn-1", updates); return err },
+		},
+		{
+			name: "update category budget",
+			build: func() (*PreparedRequest, error) {
+				return client.BuildUpdateCategoryBudget("cat-1", 250000, "2024-01-01", "test-budget")
+			},
+			send: func() error {
+				_, err := client.UpdateCategoryBudget("cat-1", 250000, "2024-01-01", "test-budget")
+				return err
+			},
+		},
+		{
+			name: "create scheduled transaction",
+			build: func() (*PreparedRequest, error) {
+				return client.BuildCreateScheduledTransaction("test-budget", scheduledReq)
+			},
+			send: func() error {
+				_, err := client.CreateScheduledTransaction("test-budget", scheduledReq)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prepared, err := tt.build()
+			if err != nil {
+				t.Fatalf("build failed: %v", err)
+			}
+			if err := tt.send(); err != nil {
+				t.Fatalf("send failed: %v", err)
+			}
+
+			if prepared.Method != gotMethod {
+				t.Errorf("method: built %s, sent %s", prepared.Method, gotMethod)
+			}
+			if prepared.Endpoint != gotPath {
+				t.Errorf("endpoint: built %s, sent %s", prepared.Endpoint, gotPath)
+			}
+			if !bytes.Equal(prepared.Body, gotBody) {
+				t.Errorf("body mismatch:\nbuilt: %s\nsent:  %s", prepared.Body, gotBody)
+			}
+		})
+	}
+}
+
+// TestBuildDeleteTransaction verifies the request delete --dry-run shows.
+func TestBuildDeleteTransaction(t *testing.T) {
+	client := &Client{auth: newAuthState("test-token")}
+
+	prepared, err := client.BuildDeleteTransaction("test-budget", "txn-1")
+	if err != nil {
+		t.Fatalf("BuildDeleteTransaction failed: %v", err)
+	}
+	if prepared.Method != "DELETE" || prepared.Endpoint != "/budgets/test-budget/transactions/txn-1" {
+		t.Errorf("request = %s %s", prepared.Method, prepared.Endpoint)
+	}
+	if prepared.Body != nil {
+		t.Errorf("body = %s, want none", prepared.Body)
+	}
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// OAuthTokenURL is the YNAB OAuth token endpoint used for refresh grants.
+const OAuthTokenURL = "https://app.ynab.com/oauth/token"
+
+// OAuthConfig holds the credentials needed to refresh an expired OAuth
+// access token. Personal Access Token users never set this.
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+
+	// TokenURL overrides OAuthTokenURL (used by tests).
+	TokenURL string
+
+	// OnRefresh is called with the new tokens after a successful refresh so
+	// the caller can persist them. The refresh token may be empty if YNAB
+	// did not rotate it.
+	OnRefresh func(accessToken, refreshToken string) error
+}
+
+// oauthTokenResponse is the body returned by the OAuth token endpoint.
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// SetOAuthConfig enables token refresh on 401 responses.
+// Passing nil disables it again.
+func (c *Client) SetOAuthConfig(cfg *OAuthConfig) {
+	c.oauth = cfg
+}
+
+// authState holds a client's access token. It is shared by the client's
+// WithContext copies and safe for concurrent use, so workers such as
+// GetAccountsForBudgets' can all hit a 401 at once: the first refreshes,
+// the others wait for it and reuse the new token.
+type authState struct {
+	mu         sync.Mutex
+	token      string
+	refreshing chan struct{} // closed when the refresh in flight ends
+	refreshErr error         // result of the last refresh
+}
+
+func newAuthState(token string) *authState {
+	return &authState{token: token}
+}
+
+// current returns the access token to send.
+func (a *authState) current() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.token
+}
+
+// refreshAccessToken replaces stale, the access token a request was
+// rejected with, with a fresh one. If another request already replaced it,
+// nothing is sent; if a refresh is in flight, it waits for that one. YNAB
+// rotates refresh tokens, so only one exchange runs at a time.
+func (c *Client) refreshAccessToken(ctx context.Context, stale string) error {
+	a := c.auth
+	a.mu.Lock()
+	if a.token != stale {
+		a.mu.Unlock()
+		return nil
+	}
+	if done := a.refreshing; done != nil {
+		a.mu.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return a.refreshErr
+	}
+	if c.oauth == nil || c.oauth.RefreshToken == "" {
+		a.mu.Unlock()
+		return errors.New("no refresh token configured")
+	}
+	done := make(chan struct{})
+	a.refreshing = done
+	refreshToken := c.oauth.RefreshToken
+	a.mu.Unlock()
+
+	tokens, err := c.exchangeRefreshToken(ctx, refreshToken)
+	if err == nil {
+		a.mu.Lock()
+		a.token = tokens.AccessToken
+		if tokens.RefreshToken != "" {
+			c.oauth.RefreshToken = tokens.RefreshToken
+		}
+		a.mu.Unlock()
+
+		if c.oauth.OnRefresh != nil {
+			if saveErr := c.oauth.OnRefresh(tokens.AccessToken, tokens.RefreshToken); saveErr != nil {
+				err = fmt.Errorf("failed to save refreshed token: %w", saveErr)
+			}
+		}
+	}
+
+	a.mu.Lock()
+	a.refreshErr = err
+	a.refreshing = nil
+	a.mu.Unlock()
+	close(done)
+	return err
+}
+
+// exchangeRefreshToken sends a refresh grant for refreshToken and returns
+// the new tokens.
+func (c *Client) exchangeRefreshToken(ctx context.Context, refreshToken string) (*oauthTokenResponse, error) {
+	tokenURL := c.oauth.TokenURL
+	if tokenURL == "" {
+		tokenURL = OAuthTokenURL
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("client_id", c.oauth.ClientID)
+	form.Set("client_secret", c.oauth.ClientSecret)
+	form.Set("refresh_token", refreshToken)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("refresh request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read refresh response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("refresh rejected: %s", resp.Status)
+	}
+
+	var tokens oauthTokenResponse
+	if err := json.Unmarshal(respBody, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse refresh response: %w", err)
+	}
+	if tokens.AccessToken == "" {
+		return nil, errors.New("refresh response did not include an access token")
+	}
+	return &tokens, nil
+}
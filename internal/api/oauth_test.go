@@ -0,0 +1,192 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_RefreshOn401(t *testing.T) {
+	var apiCalls, refreshCalls int32
+	var savedAccess, savedRefresh string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/token":
+			atomic.AddInt32(&refreshCalls, 1)
+			if err := r.ParseForm(); err != nil {
+				t.Fatalf("failed to parse form: %v", err)
+			}
+			if r.Form.Get("grant_type") != "refresh_token" {
+				t.Errorf("expected grant_type refresh_token, got %q", r.Form.Get("grant_type"))
+			}
+			if r.Form.Get("refresh_token") != "old-refresh" {
+				t.Errorf("expected refresh_token old-refresh, got %q", r.Form.Get("refresh_token"))
+			}
+			w.Write([]byte(`{"access_token": "new-access", "token_type": "Bearer", "expires_in": 7200, "refresh_token": "new-refresh"}`))
+		case "/budgets":
+			atomic.AddInt32(&apiCalls, 1)
+			if r.Header.Get("Authorization") != "Bearer new-access" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error": {"id": "401", "name": "unauthorized", "detail": "expired"}}`))
+				return
+			}
+			w.Write([]byte(`{"data": {"budgets": [{"id": "b1", "name": "Budget"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("expired-access"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+	client.SetOAuthConfig(&OAuthConfig{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RefreshToken: "old-refresh",
+		TokenURL:     server.URL + "/oauth/token",
+		OnRefresh: func(accessToken, refreshToken string) error {
+			savedAccess = accessToken
+			savedRefresh = refreshToken
+			return nil
+		},
+	})
+
+	budgets, err := client.GetBudgets()
+	if err != nil {
+		t.Fatalf("expected success after refresh, got: %v", err)
+	}
+	if len(budgets) != 1 {
+		t.Errorf("expected 1 budget, got %d", len(budgets))
+	}
+	if atomic.LoadInt32(&refreshCalls) != 1 {
+		t.Errorf("expected 1 refresh call, got %d", refreshCalls)
+	}
+	if atomic.LoadInt32(&apiCalls) != 2 {
+		t.Errorf("expected 2 API calls (401 + replay), got %d", apiCalls)
+	}
+	if savedAccess != "new-access" || savedRefresh != "new-refresh" {
+		t.Errorf("expected new tokens persisted, got %q / %q", savedAccess, savedRefresh)
+	}
+	if client.auth.current() != "new-access" {
+		t.Errorf("expected client token to be updated, got %q", client.auth.current())
+	}
+}
+
+func TestClient_RefreshOnlyOnce(t *testing.T) {
+	var refreshCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			atomic.AddInt32(&refreshCalls, 1)
+			w.Write([]byte(`{"access_token": "still-bad"}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("expired-access"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+	client.SetOAuthConfig(&OAuthConfig{
+		RefreshToken: "refresh",
+		TokenURL:     server.URL + "/oauth/token",
+	})
+
+	_, err := client.GetBudgets()
+	if !IsAuthError(err) {
+		t.Fatalf("expected auth error, got %v", err)
+	}
+	if atomic.LoadInt32(&refreshCalls) != 1 {
+		t.Errorf("expected exactly 1 refresh attempt, got %d", refreshCalls)
+	}
+}
+
+func TestClient_NoRefreshWithoutOAuth(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("pat"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+
+	_, err := client.GetBudgets()
+	if !IsAuthError(err) {
+		t.Fatalf("expected auth error, got %v", err)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+// TestClient_ConcurrentRefresh tests that workers sharing a client refresh
+// an expired token once and all reuse the result. Run with -race.
+func TestClient_ConcurrentRefresh(t *testing.T) {
+	var refreshCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			atomic.AddInt32(&refreshCalls, 1)
+			// Give the other workers time to hit their 401s
+			time.Sleep(50 * time.Millisecond)
+			if err := r.ParseForm(); err != nil || r.Form.Get("refresh_token") != "old-refresh" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"access_token": "new-access", "refresh_token": "new-refresh"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer new-access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data": {"accounts": [{"id": "a1", "name": "Checking"}]}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		auth:       newAuthState("expired-access"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+	var saves int32
+	client.SetOAuthConfig(&OAuthConfig{
+		RefreshToken: "old-refresh",
+		TokenURL:     server.URL + "/oauth/token",
+		OnRefresh: func(accessToken, refreshToken string) error {
+			atomic.AddInt32(&saves, 1)
+			return nil
+		},
+	})
+
+	budgets := []string{"b1", "b2", "b3", "b4", "b5", "b6"}
+	byBudget, err := client.GetAccountsForBudgets(budgets)
+	if err != nil {
+		t.Fatalf("GetAccountsForBudgets: %v", err)
+	}
+	if len(byBudget) != len(budgets) {
+		t.Errorf("got accounts for %d budgets, want %d", len(byBudget), len(budgets))
+	}
+	if n := atomic.LoadInt32(&refreshCalls); n != 1 {
+		t.Errorf("expected 1 refresh, got %d", n)
+	}
+	if n := atomic.LoadInt32(&saves); n != 1 {
+		t.Errorf("expected the new tokens saved once, got %d", n)
+	}
+	if client.auth.current() != "new-access" {
+		t.Errorf("client token = %q, want new-access", client.auth.current())
+	}
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// PreparedRequest is a fully built API request that has not been sent.
+// The Build* methods return one so callers can preview exactly what a
+// mutating call would send (e.g. for --dry-run).
+type PreparedRequest struct {
+	Method   string          `json:"method"`
+	Endpoint string          `json:"endpoint"`
+	Body     json.RawMessage `json:"body,omitempty"`
+}
+
+// newPreparedRequest marshals body with the same encoder used for real requests.
+func newPreparedRequest(method, endpoint string, body interface{}) (*PreparedRequest, error) {
+	p := &PreparedRequest{Method: method, Endpoint: endpoint}
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		p.Body = bodyBytes
+	}
+	return p, nil
+}
+
+// send executes a prepared request.
+func (c *Client) send(p *PreparedRequest) ([]byte, error) {
+	if p.Body == nil {
+		return c.request(p.Method, p.Endpoint, nil)
+	}
+	return c.request(p.Method, p.Endpoint, bytes.NewReader(p.Body))
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// YNAB allows 200 requests per hour per access token, over a rolling window.
+const (
+	RateLimitRequests = 200
+	RateLimitWindow   = time.Hour
+)
+
+// RateLimiter is a sliding-window limiter: at most requests calls to Allow
+// succeed within any window. It only sees requests made by this process, so
+// it guards long-running loops rather than the token's overall quota.
+type RateLimiter struct {
+	mu       sync.Mutex
+	requests int
+	window   time.Duration
+	sent     []time.Time      // times of allowed requests, oldest first
+	now      func() time.Time // nil means time.Now
+}
+
+// NewRateLimiter creates a limiter allowing requests calls per window.
+func NewRateLimiter(requests int, window time.Duration) *RateLimiter {
+	return &RateLimiter{requests: requests, window: window}
+}
+
+// Allow records a request and reports whether it fits in the window.
+// A refused request is not recorded.
+func (l *RateLimiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock()
+	l.prune(now)
+	if len(l.sent) >= l.requests {
+		return false
+	}
+	l.sent = append(l.sent, now)
+	return true
+}
+
+// Remaining returns how many requests would be allowed right now.
+func (l *RateLimiter) Remaining() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.prune(l.clock())
+	return l.requests - len(l.sent)
+}
+
+// ResetIn returns how long until the next request would be allowed, or zero
+// if one would be allowed now.
+func (l *RateLimiter) ResetIn() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock()
+	l.prune(now)
+	if len(l.sent) < l.requests {
+		return 0
+	}
+	return l.sent[0].Add(l.window).Sub(now)
+}
+
+// prune drops requests that have left the window.
+func (l *RateLimiter) prune(now time.Time) {
+	cutoff := now.Add(-l.window)
+	i := 0
+	for i < len(l.sent) && !l.sent[i].After(cutoff) {
+		i++
+	}
+	l.sent = l.sent[i:]
+}
+
+func (l *RateLimiter) clock() time.Time {
+	if l.now != nil {
+		return l.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeClock is a settable time source for limiter tests.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func TestRateLimiter_Enforcement(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := NewRateLimiter(3, time.Minute)
+	limiter.now = clock.now
+
+	for i := 0; i < 3; i++ {
+		if !limiter.Allow() {
+			t.Fatalf("request %d should be allowed", i+1)
+		}
+		clock.t = clock.t.Add(10 * time.Second)
+	}
+	if limiter.Allow() {
+		t.Error("fourth request in the window should be refused")
+	}
+	if got := limiter.Remaining(); got != 0 {
+		t.Errorf("Remaining() = %d, want 0", got)
+	}
+	// The first request was 30s ago, so its slot frees in 30s
+	if got := limiter.ResetIn(); got != 30*time.Second {
+		t.Errorf("ResetIn() = %s, want 30s", got)
+	}
+}
+
+func TestRateLimiter_Reset(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := NewRateLimiter(2, time.Minute)
+	limiter.now = clock.now
+
+	limiter.Allow()
+	clock.t = clock.t.Add(30 * time.Second)
+	limiter.Allow()
+	if limiter.Allow() {
+		t.Fatal("limiter should be exhausted")
+	}
+
+	// The window slides: only the oldest request has expired
+	clock.t = clock.t.Add(30 * time.Second)
+	if got := limiter.Remaining(); got != 1 {
+		t.Errorf("Remaining() after first expiry = %d, want 1", got)
+	}
+	if !limiter.Allow() {
+		t.Error("request should be allowed once the oldest leaves the window")
+	}
+
+	clock.t = clock.t.Add(2 * time.Minute)
+	if got := limiter.Remaining(); got != 2 {
+		t.Errorf("Remaining() after a full window = %d, want 2", got)
+	}
+	if got := limiter.ResetIn(); got != 0 {
+		t.Errorf("ResetIn() with quota left = %s, want 0", got)
+	}
+}
+
+func TestClient_RateLimiterRefusesRequests(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{auth: newAuthState("test-token"), baseURL: server.URL, httpClient: server.Client()}
+	client.SetRateLimiter(NewRateLimiter(2, time.Hour))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.request("GET", "/test", nil); err != nil {
+			t.Fatalf("request %d: %v", i+1, err)
+		}
+	}
+	_, err := client.request("GET", "/test", nil)
+	if !IsRateLimitError(err) {
+		t.Fatalf("expected a rate limit error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("server saw %d requests, want 2", calls)
+	}
+	if got := client.RateLimiter().Remaining(); got != 0 {
+		t.Errorf("Remaining() = %d, want 0", got)
+	}
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Redacted replaces secrets removed from error messages.
+const Redacted = "[REDACTED]"
+
+var (
+	// bearerPattern matches the credential in an Authorization header value.
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[^\s"',;\[]+`)
+
+	// secretFieldPattern matches the value of a token-bearing key, whether in
+	// JSON ("access_token":"..."), a form or query string (refresh_token=...)
+	// or free text (token: ...).
+	secretFieldPattern = regexp.MustCompile(`(?i)("?(?:access_token|refresh_token|client_secret|authorization|api_key|token)"?\s*[:=]\s*"?)([^\s"&,;}\[]+)`)
+
+	// tokenPattern matches runs of token characters long enough to be a
+	// credential: personal access tokens are 64 hex digits and OAuth tokens
+	// are 43 URL-safe base64 characters. IDs are UUIDs, whose hyphenated
+	// 36 characters fall short.
+	tokenPattern = regexp.MustCompile(`[A-Za-z0-9_\-]{40,}`)
+
+	// idPattern matches the one YNAB ID long enough for tokenPattern: a
+	// scheduled transaction occurrence, its UUID followed by _YYYY-MM-DD.
+	idPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}_\d{4}-\d{2}-\d{2}$`)
+)
+
+// redactSensitiveData replaces anything in s that looks like a credential
+// with Redacted: the given secrets wherever they appear, bearer credentials,
+// values of token-bearing keys, and long token-like strings. Tokens are
+// found anywhere in s, not only when s is exactly a token.
+func redactSensitiveData(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, Redacted)
+		}
+	}
+	s = bearerPattern.ReplaceAllString(s, "${1}"+Redacted)
+	s = secretFieldPattern.ReplaceAllString(s, "${1}"+Redacted)
+	return tokenPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if looksLikeToken(match) {
+			return Redacted
+		}
+		return match
+	})
+}
+
+// containsToken reports whether s holds anything redactSensitiveData would
+// remove.
+func containsToken(s string, secrets ...string) bool {
+	return redactSensitiveData(s, secrets...) != s
+}
+
+// looksLikeToken reports whether a long run of token characters mixes
+// letters and digits, as generated credentials do; a long word, a rule of
+// dashes or a YNAB ID does not.
+func looksLikeToken(s string) bool {
+	if idPattern.MatchString(strings.ToLower(s)) {
+		return false
+	}
+	var letter, digit bool
+	for _, r := range s {
+		letter = letter || unicode.IsLetter(r)
+		digit = digit || unicode.IsDigit(r)
+	}
+	return letter && digit
+}
+
+// sanitizeError removes the client's credentials, and anything else that
+// looks like a token, from err's message. YNAB errors in the chain are
+// redacted in place so callers can still match them with errors.As.
+func (c *Client) sanitizeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	secrets := c.secrets()
+
+	var ynabErr *YNABError
+	if errors.As(err, &ynabErr) {
+		ynabErr.Message = redactSensitiveData(ynabErr.Message, secrets...)
+		ynabErr.Detail = redactSensitiveData(ynabErr.Detail, secrets...)
+	}
+
+	msg := err.Error()
+	if !containsToken(msg, secrets...) {
+		return err
+	}
+	return &redactedError{msg: redactSensitiveData(msg, secrets...), err: err}
+}
+
+// secrets returns the credentials the client holds.
+func (c *Client) secrets() []string {
+	c.auth.mu.Lock()
+	defer c.auth.mu.Unlock()
+	secrets := []string{c.auth.token}
+	if c.oauth != nil {
+		secrets = append(secrets, c.oauth.RefreshToken, c.oauth.ClientSecret)
+	}
+	return secrets
+}
+
+// redactedError is an error whose message had credentials removed. It
+// still unwraps to the original so errors.Is and errors.As keep working.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
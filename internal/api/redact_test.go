@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testPAT = "3f9a1c0e5b7d2468ace013579bdf2468ace013579bdf2468ace013579bdf2468"
+
+func TestRedactSensitiveData(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"bearer header", "Authorization: Bearer abc123", "Authorization: [REDACTED] [REDACTED]"},
+		{"bearer mid-string", "sent Bearer abc123 to server", "sent Bearer [REDACTED] to server"},
+		{"json field", `{"access_token":"short1","ok":true}`, `{"access_token":"[REDACTED]","ok":true}`},
+		{"form field", "grant_type=refresh_token&refresh_token=r3fr3sh&client_id=x", "grant_type=refresh_token&refresh_token=[REDACTED]&client_id=x"},
+		{"hex token mid-string", "token was " + testPAT + ", retrying", "token was [REDACTED], retrying"},
+		{"base64 token", "got xY7_kPq2-Lm9nRt4vWz8aBc1dEf5gHi3jKl6oPs0uVw here", "got [REDACTED] here"},
+		{"uuid kept", "budget 6ee704d9-ee24-4c36-b1a6-cb8ccf6a216c not found", "budget 6ee704d9-ee24-4c36-b1a6-cb8ccf6a216c not found"},
+		{"scheduled occurrence kept", "scheduled transaction 6ee704d9-ee24-4c36-b1a6-cb8ccf6a216c_2025-03-01 not found", "scheduled transaction 6ee704d9-ee24-4c36-b1a6-cb8ccf6a216c_2025-03-01 not found"},
+		{"token after uuid", "6ee704d9-ee24-4c36-b1a6-cb8ccf6a216c_" + testPAT, "[REDACTED]"},
+		{"long word kept", strings.Repeat("-", 50), strings.Repeat("-", 50)},
+		{"plain message", "Invalid or missing access token", "Invalid or missing access token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactSensitiveData(tt.input); got != tt.want {
+				t.Errorf("redactSensitiveData(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	// Known secrets are removed even when too short to look like tokens
+	if got := redactSensitiveData("key s3cr3t leaked", "s3cr3t"); got != "key [REDACTED] leaked" {
+		t.Errorf("secret not redacted: %q", got)
+	}
+	if containsToken("HTTP 404 Not Found") {
+		t.Error("containsToken reported a token in a plain message")
+	}
+	if !containsToken("x" + testPAT) {
+		t.Error("containsToken missed an embedded token")
+	}
+}
+
+func TestClient_ErrorsRedactToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A misbehaving server that echoes the request's credentials
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"id":     "400",
+				"name":   "bad_request " + r.Header.Get("Authorization"),
+				"detail": "token " + strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ") + " rejected",
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("my-secret-pat")
+	client.baseURL = server.URL
+
+	_, err := client.request("GET", "/test", nil)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if strings.Contains(err.Error(), "my-secret-pat") {
+		t.Errorf("error echoes the token: %q", err)
+	}
+	var ynabErr *YNABError
+	if !errors.As(err, &ynabErr) || ynabErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected a 400 YNABError, got %v", err)
+	}
+	if strings.Contains(ynabErr.Detail, "my-secret-pat") {
+		t.Errorf("Detail echoes the token: %q", ynabErr.Detail)
+	}
+}
+
+func TestSanitizeError_Wrapped(t *testing.T) {
+	client, _ := NewClient(testPAT)
+	inner := &YNABError{Message: "boom", StatusCode: http.StatusInternalServerError}
+	err := client.sanitizeError(fmt.Errorf("request to Bearer %s failed: %w", testPAT, inner))
+
+	if strings.Contains(err.Error(), testPAT) {
+		t.Errorf("error echoes the token: %q", err)
+	}
+	if !errors.Is(err, inner) {
+		t.Error("sanitized error no longer wraps the YNAB error")
+	}
+}
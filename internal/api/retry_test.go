@@ -1,9 +1,12 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -26,7 +29,7 @@ func TestClient_RetryOnServerError(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
-		token:      "test-token",
+		auth:       newAuthState("test-token"),
 		baseURL:    server.URL,
 		httpClient: &http.Client{Timeout: 30 * time.Second},
 	}
@@ -67,7 +70,7 @@ func TestClient_RetryOnRateLimit(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
-		token:      "test-token",
+		auth:       newAuthState("test-token"),
 		baseURL:    server.URL,
 		httpClient: &http.Client{Timeout: 30 * time.Second},
 	}
@@ -90,6 +93,74 @@ func TestClient_RetryOnRateLimit(t *testing.T) {
 	}
 }
 
+func TestClient_MaxBackoffClampsRetryAfter(t *testing.T) {
+	var attemptCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attemptCount, 1) == 1 {
+			w.Header().Set("Retry-After", "300")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"data": {"budgets": []}}`))
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		sleep:      func(d time.Duration) { waits = append(waits, d) },
+	}
+	client.SetRetryLimits(2*time.Second, 0)
+
+	if _, err := client.GetBudgets(); err != nil {
+		t.Fatalf("Expected success after retry, got error: %v", err)
+	}
+
+	if len(waits) == 0 || waits[0] != 2*time.Second {
+		t.Fatalf("Expected 300s Retry-After clamped to 2s, got waits %v", waits)
+	}
+	for _, d := range waits {
+		if d > 2*time.Second {
+			t.Errorf("Wait %v exceeds max backoff", d)
+		}
+	}
+}
+
+func TestClient_RetryBudgetExhausted(t *testing.T) {
+	var attemptCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attemptCount, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		sleep:      func(d time.Duration) { waits = append(waits, d) },
+	}
+	// Backoff waits 1s then 2s; the second would push the total past 2.5s
+	client.SetRetryLimits(0, 2500*time.Millisecond)
+
+	_, err := client.GetBudgets()
+	if err == nil || !strings.Contains(err.Error(), "retry budget") {
+		t.Fatalf("Expected retry budget error, got %v", err)
+	}
+	if !IsServerError(err) {
+		t.Errorf("Expected last server error to be wrapped, got %v", err)
+	}
+	if atomic.LoadInt32(&attemptCount) != 2 {
+		t.Errorf("Expected 2 attempts before giving up, got %d", attemptCount)
+	}
+	if len(waits) != 1 || waits[0] != time.Second {
+		t.Errorf("Expected a single 1s wait, got %v", waits)
+	}
+}
+
 func TestClient_NoRetryOnAuthError(t *testing.T) {
 	var attemptCount int32
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -100,7 +171,7 @@ func TestClient_NoRetryOnAuthError(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
-		token:      "test-token",
+		auth:       newAuthState("test-token"),
 		baseURL:    server.URL,
 		httpClient: &http.Client{Timeout: 30 * time.Second},
 	}
@@ -131,7 +202,7 @@ func TestClient_NoRetryOnBadRequest(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
-		token:      "test-token",
+		auth:       newAuthState("test-token"),
 		baseURL:    server.URL,
 		httpClient: &http.Client{Timeout: 30 * time.Second},
 	}
@@ -168,7 +239,7 @@ func TestClient_MaxRetriesExhausted(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
-		token:      "test-token",
+		auth:       newAuthState("test-token"),
 		baseURL:    server.URL,
 		httpClient: &http.Client{Timeout: 30 * time.Second},
 	}
@@ -209,7 +280,7 @@ func TestClient_ExponentialBackoffTiming(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
-		token:      "test-token",
+		auth:       newAuthState("test-token"),
 		baseURL:    server.URL,
 		httpClient: &http.Client{Timeout: 30 * time.Second},
 	}
@@ -288,7 +359,7 @@ func TestClient_ParseErrorResponse(t *testing.T) {
 			defer server.Close()
 
 			client := &Client{
-				token:      "test-token",
+				auth:       newAuthState("test-token"),
 				baseURL:    server.URL,
 				httpClient: &http.Client{Timeout: 30 * time.Second},
 			}
@@ -365,7 +436,7 @@ func TestClient_RetryAfterHeaderParsing(t *testing.T) {
 			defer server.Close()
 
 			client := &Client{
-				token:      "test-token",
+				auth:       newAuthState("test-token"),
 				baseURL:    server.URL,
 				httpClient: &http.Client{Timeout: 120 * time.Second},
 			}
@@ -398,7 +469,7 @@ func BenchmarkClient_RetryLogic(b *testing.B) {
 	defer server.Close()
 
 	client := &Client{
-		token:      "test-token",
+		auth:       newAuthState("test-token"),
 		baseURL:    server.URL,
 		httpClient: &http.Client{Timeout: 30 * time.Second},
 	}
@@ -420,8 +491,214 @@ func ExampleYNABError_handling() {
 	serverErr := NewServerError(500)
 	fmt.Println("Server error retryable:", serverErr.IsRetryable())
 
+	// No response at all: tell the user to check their connection
+	netErr := fmt.Errorf("failed to get budgets: %w", &NetworkError{Err: errors.New("connection refused")})
+	fmt.Println("Network error:", IsNetworkError(netErr), IsYNABError(netErr))
+
 	// Output:
 	// Auth error: true
 	// Rate limit retryable: true
 	// Server error retryable: true
+	// Network error: true false
+}
+
+func TestJitterBounds(t *testing.T) {
+	base := 2 * time.Second
+	low, high := 1600*time.Millisecond, 2400*time.Millisecond
+	for _, r := range []float64{0, 0.25, 0.5, 0.75, 0.999999} {
+		got := jitter(base, 0.2, r)
+		if got < low || got > high {
+			t.Errorf("jitter(%v, 0.2, %v) = %v, want within [%v, %v]", base, r, got, low, high)
+		}
+	}
+	if got := jitter(base, 0, 0.9); got != base {
+		t.Errorf("jitter with no fraction = %v, want %v", got, base)
+	}
+}
+
+func TestClient_RetryConfig(t *testing.T) {
+	var attemptCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attemptCount, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		sleep:      func(d time.Duration) { waits = append(waits, d) },
+	}
+	client.SetRetryConfig(RetryConfig{MaxRetries: 2, InitialBackoff: 100 * time.Millisecond, Multiplier: 3})
+
+	if _, err := client.GetBudgets(); err == nil || !strings.Contains(err.Error(), "after 2 retries") {
+		t.Fatalf("Expected failure after 2 retries, got %v", err)
+	}
+	if atomic.LoadInt32(&attemptCount) != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attemptCount)
+	}
+	if fmt.Sprint(waits) != "[100ms 300ms]" {
+		t.Errorf("Expected waits [100ms 300ms], got %v", waits)
+	}
+
+	// With jitter every wait stays within ±20% of the unjittered one
+	waits = nil
+	client.SetRetryConfig(RetryConfig{MaxRetries: 3, InitialBackoff: time.Second, Jitter: 0.2})
+	client.GetBudgets()
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+	if len(waits) != len(want) {
+		t.Fatalf("Expected %d waits, got %v", len(want), waits)
+	}
+	for i, d := range waits {
+		if d < want[i]*8/10 || d > want[i]*12/10 {
+			t.Errorf("Wait %d = %v, want within 20%% of %v", i, d, want[i])
+		}
+	}
+
+	// A negative MaxRetries disables retries
+	atomic.StoreInt32(&attemptCount, 0)
+	client.SetRetryConfig(RetryConfig{MaxRetries: -1})
+	client.GetBudgets()
+	if atomic.LoadInt32(&attemptCount) != 1 {
+		t.Errorf("Expected a single attempt, got %d", attemptCount)
+	}
+}
+
+func TestClient_ContextCancelsRetries(t *testing.T) {
+	var attemptCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attemptCount, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	// Cancel during the first backoff, as Ctrl-C would
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := (&Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		sleep:      func(time.Duration) { cancel() },
+	}).WithContext(ctx)
+
+	_, err := client.GetBudgets()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Service Unavailable") {
+		t.Errorf("Expected the retried failure in the error, got %v", err)
+	}
+	if n := atomic.LoadInt32(&attemptCount); n != 1 {
+		t.Errorf("Expected 1 attempt before cancellation, got %d", n)
+	}
+}
+
+func TestClient_ContextCancelsInFlightRequest(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	client := (&Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		sleep:      func(time.Duration) { t.Error("Canceled request was retried") },
+	}).WithContext(ctx)
+
+	start := time.Now()
+	_, err := client.GetBudgets()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Request took %v to notice the deadline", elapsed)
+	}
+}
+
+func TestClient_NetworkErrorIsClassified(t *testing.T) {
+	// A closed server refuses connections
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	var waits int32
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    url,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		sleep:      func(time.Duration) { atomic.AddInt32(&waits, 1) },
+	}
+
+	_, err := client.GetBudgets()
+	if !IsNetworkError(err) {
+		t.Fatalf("Expected a NetworkError, got %T: %v", err, err)
+	}
+	if IsYNABError(err) {
+		t.Error("A refused connection must not look like a YNAB response")
+	}
+	var netErr *NetworkError
+	if errors.As(err, &netErr) && netErr.Timeout {
+		t.Error("A refused connection is not a timeout")
+	}
+	if n := atomic.LoadInt32(&waits); n != int32(MaxRetries) {
+		t.Errorf("Expected %d retries, got %d", MaxRetries, n)
+	}
+}
+
+func TestClient_TimeoutIsRetryableNetworkError(t *testing.T) {
+	var attemptCount int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/budgets" && atomic.AddInt32(&attemptCount, 1) > 1 {
+			w.Write([]byte(`{"data": {"budgets": []}}`))
+			return
+		}
+		// Hang until the client gives up
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := &Client{
+		auth:       newAuthState("test-token"),
+		baseURL:    server.URL,
+		httpClient: &http.Client{Timeout: 50 * time.Millisecond},
+		sleep:      func(time.Duration) {},
+	}
+
+	// The first attempt times out and the retry succeeds
+	if _, err := client.GetBudgets(); err != nil {
+		t.Fatalf("Expected the retry to succeed, got %v", err)
+	}
+	if n := atomic.LoadInt32(&attemptCount); n != 2 {
+		t.Errorf("Expected 2 attempts, got %d", n)
+	}
+
+	// Without retries the timeout itself comes back
+	client.SetRetryConfig(RetryConfig{MaxRetries: -1})
+	_, err := client.GetBudget("slow", 0)
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) {
+		t.Fatalf("Expected a NetworkError, got %T: %v", err, err)
+	}
+	if !netErr.Timeout || !netErr.IsRetryable() {
+		t.Errorf("Timeout = %v, IsRetryable = %v; want both true", netErr.Timeout, netErr.IsRetryable())
+	}
+	if !strings.Contains(err.Error(), "network timeout") {
+		t.Errorf("Expected a timeout message, got %v", err)
+	}
 }
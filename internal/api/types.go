@@ -29,12 +29,14 @@ type CurrencyFormat struct {
 	DisplaySymbol    bool   `json:"display_symbol"`
 }
 
-// BudgetDetail represents detailed budget information.
+// BudgetDetail represents detailed budget information. Categories are the
+// flat list the budget endpoint returns; CategoryGroups carry no categories.
 type BudgetDetail struct {
 	Budget          *Budget          `json:"budget"`
 	ServerKnowledge int64            `json:"server_knowledge"`
 	Accounts        []*Account       `json:"accounts,omitempty"`
 	CategoryGroups  []*CategoryGroup `json:"category_groups,omitempty"`
+	Categories      []*Category      `json:"categories,omitempty"`
 	Payees          []*Payee         `json:"payees,omitempty"`
 	Transactions    []*Transaction   `json:"transactions,omitempty"`
 }
@@ -150,15 +152,18 @@ type BudgetsResponse struct {
 	} `json:"data"`
 }
 
-// BudgetResponse wraps the budget detail response.
+// BudgetResponse wraps the budget detail response. The entity lists are
+// nested inside the budget object, next to its summary fields.
 type BudgetResponse struct {
 	Data struct {
-		Budget          *Budget          `json:"budget"`
-		ServerKnowledge int64            `json:"server_knowledge"`
-		Accounts        []*Account       `json:"accounts,omitempty"`
-		CategoryGroups  []*CategoryGroup `json:"category_groups,omitempty"`
-		Payees          []*Payee         `json:"payees,omitempty"`
-		Transactions    []*Transaction   `json:"transactions,omitempty"`
+		Budget struct {
+			Budget
+			CategoryGroups []*CategoryGroup `json:"category_groups,omitempty"`
+			Categories     []*Category      `json:"categories,omitempty"`
+			Payees         []*Payee         `json:"payees,omitempty"`
+			Transactions   []*Transaction   `json:"transactions,omitempty"`
+		} `json:"budget"`
+		ServerKnowledge int64 `json:"server_knowledge"`
 	} `json:"data"`
 }
 
@@ -187,7 +192,8 @@ type AccountsResponse struct {
 type TransactionResponse struct {
 	Data struct {
 		Transaction        *Transaction   `json:"transaction"`
-		Transactions       []*Transaction `json:"transactions,omitempty"`       // For bulk creates
+		Transactions       []*Transaction `json:"transactions,omitempty"`    // For bulk creates and updates
+		TransactionIDs     []string       `json:"transaction_ids,omitempty"` // For bulk creates and updates
 		DuplicateImportIDs []string       `json:"duplicate_import_ids,omitempty"`
 		ServerKnowledge    int64          `json:"server_knowledge"`
 	} `json:"data"`
@@ -225,20 +231,21 @@ type MonthResponse struct {
 
 // ScheduledTransaction represents a scheduled/recurring transaction.
 type ScheduledTransaction struct {
-	ID              string `json:"id"`
-	DateFirst       string `json:"date_first"`
-	DateNext        string `json:"date_next"`
-	Frequency       string `json:"frequency"` // never, daily, weekly, everyOtherWeek, twiceAMonth, every4Weeks, monthly, everyOtherMonth, every3Months, every4Months, twiceAYear, yearly, everyOtherYear
-	Amount          int64  `json:"amount"`
-	Memo            string `json:"memo,omitempty"`
-	FlagColor       string `json:"flag_color,omitempty"`
-	AccountID       string `json:"account_id"`
-	AccountName     string `json:"account_name,omitempty"`
-	PayeeID         string `json:"payee_id,omitempty"`
-	PayeeName       string `json:"payee_name,omitempty"`
-	CategoryID      string `json:"category_id,omitempty"`
-	CategoryName    string `json:"category_name,omitempty"`
-	Deleted         bool   `json:"deleted"`
+	ID                string `json:"id"`
+	DateFirst         string `json:"date_first"`
+	DateNext          string `json:"date_next"`
+	Frequency         string `json:"frequency"` // never, daily, weekly, everyOtherWeek, twiceAMonth, every4Weeks, monthly, everyOtherMonth, every3Months, every4Months, twiceAYear, yearly, everyOtherYear
+	Amount            int64  `json:"amount"`
+	Memo              string `json:"memo,omitempty"`
+	FlagColor         string `json:"flag_color,omitempty"`
+	AccountID         string `json:"account_id"`
+	AccountName       string `json:"account_name,omitempty"`
+	PayeeID           string `json:"payee_id,omitempty"`
+	PayeeName         string `json:"payee_name,omitempty"`
+	CategoryID        string `json:"category_id,omitempty"`
+	CategoryName      string `json:"category_name,omitempty"`
+	TransferAccountID string `json:"transfer_account_id,omitempty"`
+	Deleted           bool   `json:"deleted"`
 }
 
 // ScheduledTransactionsResponse wraps the scheduled transactions list response.
@@ -249,6 +256,13 @@ type ScheduledTransactionsResponse struct {
 	} `json:"data"`
 }
 
+// ScheduledTransactionResponse wraps a single scheduled transaction response.
+type ScheduledTransactionResponse struct {
+	Data struct {
+		ScheduledTransaction *ScheduledTransaction `json:"scheduled_transaction"`
+	} `json:"data"`
+}
+
 // PayeesResponse wraps the payees list response.
 type PayeesResponse struct {
 	Data struct {
@@ -263,3 +277,15 @@ type PayeeResponse struct {
 		Payee *Payee `json:"payee"`
 	} `json:"data"`
 }
+
+// User represents the YNAB user a token belongs to.
+type User struct {
+	ID string `json:"id"`
+}
+
+// UserResponse wraps the user response.
+type UserResponse struct {
+	Data struct {
+		User *User `json:"user"`
+	} `json:"data"`
+}
@@ -4,43 +4,77 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/storage"
 	"github.com/joeyhipolito/ynab-cli/internal/transform"
 )
 
 // AccountOutput represents the JSON output for account creation.
 type AccountOutput struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Type    string `json:"type"`
-	Balance int64  `json:"balance"`
+	BudgetID       string `json:"budget_id"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	Balance        int64  `json:"balance"`
 	BalanceDisplay string `json:"balance_display"`
 }
 
-// AddAccountCmd creates a new account in the budget.
+// accountTypes are the account types add-account accepts, as YNAB names them.
+var accountTypes = []string{
+	"checking", "savings", "creditCard", "cash", "lineOfCredit", "otherAsset", "otherLiability",
+}
+
+// accountTypeAliases are friendly names for account types, lowercase.
+var accountTypeAliases = map[string]string{
+	"credit":    "creditCard",
+	"cc":        "creditCard",
+	"card":      "creditCard",
+	"loc":       "lineOfCredit",
+	"asset":     "otherAsset",
+	"liability": "otherLiability",
+}
+
+// resolveAccountType maps a type or alias, in any case, to the YNAB account
+// type, so a typo fails here rather than as a 400 from the API.
+func resolveAccountType(accountType string) (string, error) {
+	lower := strings.ToLower(strings.TrimSpace(accountType))
+	for _, t := range accountTypes {
+		if strings.ToLower(t) == lower {
+			return t, nil
+		}
+	}
+	if t, ok := accountTypeAliases[lower]; ok {
+		return t, nil
+	}
+	return "", fmt.Errorf("invalid account type '%s'\n\nValid types: %s\nAliases: credit, cc, card (creditCard), loc (lineOfCredit), asset (otherAsset), liability (otherLiability)",
+		accountType, strings.Join(accountTypes, ", "))
+}
+
+// AddAccountCmd creates a new account in the budget. accountType may be a
+// YNAB account type or one of its aliases.
 func AddAccountCmd(client *api.Client, name, accountType string, balanceMilliunits int64, jsonOutput bool) error {
-	budgetID, err := client.GetDefaultBudgetID()
+	accountType, err := resolveAccountType(accountType)
 	if err != nil {
 		return err
 	}
 
-	// Validate account type
-	validTypes := map[string]bool{
-		"checking": true, "savings": true, "creditCard": true,
-		"cash": true, "lineOfCredit": true, "otherAsset": true, "otherLiability": true,
-	}
-	if !validTypes[accountType] {
-		return fmt.Errorf("invalid account type '%s'\n\nValid types: checking, savings, creditCard, cash, lineOfCredit, otherAsset, otherLiability", accountType)
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
 	}
 
 	account, err := client.CreateAccount(budgetID, name, accountType, balanceMilliunits)
 	if err != nil {
 		return fmt.Errorf("failed to create account: %w", err)
 	}
+	// The new account must be findable by name straight away
+	storage.InvalidateLookup(budgetID)
 
 	if jsonOutput {
 		output := AccountOutput{
+			BudgetID:       budgetID,
 			ID:             account.ID,
 			Name:           account.Name,
 			Type:           account.Type,
@@ -61,3 +95,114 @@ func AddAccountCmd(client *api.Client, name, accountType string, balanceMilliuni
 
 	return nil
 }
+
+// AccountEditOutput represents the JSON output for account edit.
+type AccountEditOutput struct {
+	BudgetID       string `json:"budget_id"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Note           string `json:"note"`
+	Closed         bool   `json:"closed"`
+	Balance        int64  `json:"balance"`
+	BalanceDisplay string `json:"balance_display"`
+}
+
+// AccountEditOptions holds the changes for account edit. A nil Note leaves
+// the note alone; an empty one clears it.
+type AccountEditOptions struct {
+	Note   *string
+	Close  bool
+	Reopen bool
+	Force  bool // close even with a non-zero balance
+}
+
+// AccountEditCmd changes an account's note or closes or reopens it. Closed
+// accounts can be named too, so they can be reopened. Closing an account
+// with money in it is refused without Force: YNAB would keep the balance
+// off every list, where it is easy to forget.
+func AccountEditCmd(client *api.Client, accountName string, opts AccountEditOptions, jsonOutput bool) error {
+	if opts.Close && opts.Reopen {
+		return fmt.Errorf("choose one of --close and --reopen")
+	}
+	if opts.Note == nil && !opts.Close && !opts.Reopen {
+		return fmt.Errorf("nothing to change\n\nUsage: ynab account edit <name> [--note <text>] [--close [--force]] [--reopen]")
+	}
+
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	// Balances come from the API; the lookup cache doesn't carry them
+	accounts, err := client.GetAccounts(budgetID)
+	if err != nil {
+		return fmt.Errorf("failed to get accounts: %w", err)
+	}
+	var candidates []*api.Account
+	for _, a := range accounts {
+		if !a.Deleted {
+			candidates = append(candidates, a)
+		}
+	}
+	account, err := matchAccount(candidates, accountName)
+	if err != nil {
+		return err
+	}
+
+	fields := make(map[string]interface{})
+	if opts.Note != nil {
+		fields["note"] = *opts.Note
+	}
+	switch {
+	case opts.Close && account.Closed:
+		return fmt.Errorf("%s is already closed", account.Name)
+	case opts.Reopen && !account.Closed:
+		return fmt.Errorf("%s is already open", account.Name)
+	case opts.Close:
+		if account.Balance != 0 && !opts.Force {
+			return fmt.Errorf("%s has a balance of %s; refusing to close it without --force\n\nMove the money out first, or re-run with --force to close it anyway",
+				account.Name, transform.FormatCurrency(account.Balance))
+		}
+		fields["closed"] = true
+	case opts.Reopen:
+		fields["closed"] = false
+	}
+
+	updated, err := client.UpdateAccount(budgetID, account.ID, fields)
+	if err != nil {
+		return fmt.Errorf("failed to update account: %w", err)
+	}
+	// Name resolution skips closed accounts, so the cached flags must go
+	storage.InvalidateLookup(budgetID)
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(AccountEditOutput{
+			BudgetID:       budgetID,
+			ID:             updated.ID,
+			Name:           updated.Name,
+			Note:           updated.Note,
+			Closed:         updated.Closed,
+			Balance:        updated.Balance,
+			BalanceDisplay: transform.FormatCurrency(updated.Balance),
+		})
+	}
+
+	fmt.Printf("Account updated: %s\n", updated.Name)
+	if _, ok := fields["closed"]; ok {
+		status := "open"
+		if updated.Closed {
+			status = "closed"
+		}
+		fmt.Printf("Status: %s\n", status)
+	}
+	if opts.Note != nil {
+		if updated.Note == "" {
+			fmt.Println("Note:   (cleared)")
+		} else {
+			fmt.Printf("Note:   %s\n", updated.Note)
+		}
+	}
+	return nil
+}
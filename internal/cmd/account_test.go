@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResolveAccountType(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"checking", "checking"},
+		{"creditCard", "creditCard"},
+		{"CREDITCARD", "creditCard"},
+		{"credit", "creditCard"},
+		{"loc", "lineOfCredit"},
+		{" Savings ", "savings"},
+		{"liability", "otherLiability"},
+	}
+	for _, tt := range tests {
+		got, err := resolveAccountType(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("resolveAccountType(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	_, err := resolveAccountType("chequing")
+	if err == nil || !strings.Contains(err.Error(), "otherLiability") {
+		t.Errorf("expected an error listing the valid types, got %v", err)
+	}
+}
+
+func TestAccountEditCmd(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var patches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"data":{"accounts":[
+				{"id":"a1","name":"Checking","balance":250000,"closed":false,"deleted":false},
+				{"id":"a2","name":"Car Loan","balance":0,"closed":false,"deleted":false},
+				{"id":"a3","name":"Old Savings","balance":0,"closed":true,"deleted":false}
+			]}}`))
+		case http.MethodPatch:
+			patches = append(patches, r.URL.Path)
+			w.Write([]byte(`{"data":{"account":{"id":"a2","name":"Car Loan","balance":0,"closed":true}}}`))
+		}
+	}))
+	defer server.Close()
+	client := createTestClient(t, server)
+	client.SetDefaultBudgetID("budget-1")
+
+	_, err := captureStdout(t, func() error {
+		return AccountEditCmd(client, "checking", AccountEditOptions{Close: true}, false)
+	})
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("closing an account with a balance: err = %v, want a --force hint", err)
+	}
+	if _, err := captureStdout(t, func() error {
+		return AccountEditCmd(client, "old savings", AccountEditOptions{Close: true}, false)
+	}); err == nil {
+		t.Error("closing a closed account succeeded")
+	}
+	if len(patches) != 0 {
+		t.Fatalf("refused edits sent %v", patches)
+	}
+
+	out, err := captureStdout(t, func() error {
+		return AccountEditCmd(client, "car", AccountEditOptions{Close: true}, false)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 1 || patches[0] != "/budgets/budget-1/accounts/a2" {
+		t.Errorf("patches = %v, want one to a2", patches)
+	}
+	if !strings.Contains(out, "Status: closed") {
+		t.Errorf("output = %q", out)
+	}
+
+	// --force closes despite the balance
+	if _, err := captureStdout(t, func() error {
+		return AccountEditCmd(client, "checking", AccountEditOptions{Close: true, Force: true}, false)
+	}); err != nil || len(patches) != 2 {
+		t.Errorf("forced close: err = %v, patches = %v", err, patches)
+	}
+}
@@ -4,16 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/config"
+	"github.com/joeyhipolito/ynab-cli/internal/storage"
 	"github.com/joeyhipolito/ynab-cli/internal/transform"
 )
 
 // AddOutput represents the JSON output format for the add command.
 type AddOutput struct {
+	BudgetID      string `json:"budget_id"`
 	TransactionID string `json:"transaction_id"`
 	Date          string `json:"date"`
 	Amount        int64  `json:"amount"`
@@ -21,46 +23,158 @@ type AddOutput struct {
 	Payee         string `json:"payee"`
 	Category      string `json:"category,omitempty"`
 	Account       string `json:"account"`
+	AccountID     string `json:"account_id"`
 	Memo          string `json:"memo,omitempty"`
+	FlagColor     string `json:"flag_color,omitempty"`
+	Approved      bool   `json:"approved"`
+	ImportID      string `json:"import_id,omitempty"`
+	Duplicate     bool   `json:"duplicate,omitempty"`
+
+	// Set when YNAB matched the new transaction to an already-imported one
+	Matched              bool   `json:"matched,omitempty"`
+	MatchedTransactionID string `json:"matched_transaction_id,omitempty"`
+
+	// Set only with --verify
+	Verified      *bool  `json:"verified,omitempty"`
+	ObservedDelta *int64 `json:"observed_delta,omitempty"`
+
+	// Set only for split transactions
+	Splits []SplitItem `json:"splits,omitempty"`
+}
+
+// SplitItem represents one line of a split transaction.
+type SplitItem struct {
+	Category      string `json:"category"`
+	CategoryID    string `json:"category_id"`
+	Amount        int64  `json:"amount"`
+	AmountDisplay string `json:"amount_display"`
+}
+
+// AddOptions holds the parameters for the add command.
+type AddOptions struct {
+	Amount   string   // Dollar amount as string (e.g., "50.00", "25", "-100.50")
+	Payee    string   // Payee name (required)
+	Category string   // Category name (optional - can be empty for uncategorized)
+	Account  string   // Account name (optional - uses DefaultAccount, then the first on-budget account, if empty)
+	Date     string   // ISO date YYYY-MM-DD (optional - uses today if empty)
+	Memo     string   // Transaction memo (optional)
+	ImportID string   // Caller-supplied idempotency key (optional, max 36 chars)
+	Approved bool     // false creates the transaction unapproved, for review in YNAB
+	Verify   bool     // re-fetch the account afterwards and check the balance moved by Amount
+	Splits   []string // "category:amount" lines of a split transaction (optional)
+	Flag     string   // Flag color (optional); "none" or empty for no flag
+	DryRun   bool     // Print the request instead of sending it
+	Force    bool     // Skip the likely-duplicate check
+
+	// IDs used as is, skipping the lookup of Account or Category
+	AccountID  string
+	CategoryID string
+
+	DefaultAccount      string // default_account from config
+	DuplicateWindowDays int    // duplicate_window_days from config
+}
+
+// checkDuplicate looks for a likely duplicate of txnReq in its account and,
+// if there is one, asks whether to add it anyway. Under --json there is no
+// prompt and the match is an error.
+func checkDuplicate(client *api.Client, budgetID, accountID, accountName string, txnReq *api.TransactionRequest, windowDays int, jsonOutput bool) error {
+	date := transform.ParseDate(txnReq.Date)
+	since := transform.FormatDate(date.AddDate(0, 0, -windowDays))
+	txns, err := client.GetTransactionsByAccount(budgetID, accountID, since)
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicates: %w", err)
+	}
+
+	dup := findDuplicate(txns, txnReq.Date, txnReq.Amount, txnReq.PayeeName, windowDays)
+	if dup == nil {
+		return nil
+	}
+	desc := fmt.Sprintf("%s %s %s", dup.Date, dup.PayeeName, transform.FormatCurrency(dup.Amount))
+	if jsonOutput {
+		return fmt.Errorf("%s already has %s (%s), which looks like the same transaction; use --force to add it anyway", accountName, desc, dup.ID)
+	}
+	fmt.Fprintf(os.Stderr, "%s already has %s, which looks like the same transaction.\n", accountName, desc)
+	if !confirm("Add it anyway?") {
+		return fmt.Errorf("add cancelled; nothing was created (use --force to skip this check)")
+	}
+	return nil
+}
+
+// findDuplicate returns the first transaction in txns with the given amount
+// and payee (case-insensitive) dated at most windowDays from date, or nil.
+// Deleted transactions are ignored.
+func findDuplicate(txns []*api.Transaction, date string, amount int64, payee string, windowDays int) *api.Transaction {
+	want := transform.ParseDate(date)
+	for _, t := range txns {
+		if t.Deleted || t.Amount != amount || !strings.EqualFold(t.PayeeName, payee) {
+			continue
+		}
+		days := transform.ParseDate(t.Date).Sub(want).Hours() / 24
+		if days < 0 {
+			days = -days
+		}
+		if days <= float64(windowDays) {
+			return t
+		}
+	}
+	return nil
+}
+
+// splitLine is a parsed --split before its category is resolved.
+type splitLine struct {
+	Category string
+	Amount   int64 // milliunits, signed like the parent amount
 }
 
 // AddCmd creates a new transaction.
 //
-// Parameters:
-//   - amount: Dollar amount as string (e.g., "50.00", "25", "-100.50")
-//   - payee: Payee name (required)
-//   - category: Category name (optional - can be empty for uncategorized)
-//   - account: Account name (optional - uses first on-budget account if empty)
-//   - date: ISO date YYYY-MM-DD (optional - uses today if empty)
-//   - memo: Transaction memo (optional)
-//   - jsonOutput: If true, outputs JSON instead of human-readable format
+// If jsonOutput is true, outputs JSON instead of human-readable format.
 //
 // Amount handling:
 //   - Positive amounts are inflows (income)
 //   - Negative amounts are outflows (expenses)
 //   - For expenses, you can use either "-50" or "50" (defaults to expense)
-func AddCmd(client *api.Client, amount, payee, category, account, date, memo string, jsonOutput bool) error {
+//
+// When an import ID is given and YNAB already has a transaction with it,
+// nothing is created and the command reports that the transaction exists.
+// Without one, an existing transaction in the account with the same amount
+// and payee, dated within DuplicateWindowDays of the new one, is taken as a
+// likely duplicate: the user is asked before adding, and under --json the
+// command fails unless Force is set.
+func AddCmd(client *api.Client, opts AddOptions, jsonOutput bool) error {
 	// Validate required parameters
-	if amount == "" {
+	if opts.Amount == "" {
 		return fmt.Errorf("amount is required")
 	}
-	if payee == "" {
+	if opts.Payee == "" {
 		return fmt.Errorf("payee is required")
 	}
-
-	// Parse amount from dollars to milliunits
-	amountFloat, err := strconv.ParseFloat(amount, 64)
+	if len(opts.ImportID) > api.MaxImportIDLength {
+		return fmt.Errorf("--import-id must be at most %d characters (got %d)", api.MaxImportIDLength, len(opts.ImportID))
+	}
+	flag, err := parseFlagColor(opts.Flag)
 	if err != nil {
-		return fmt.Errorf("invalid amount: %s (expected decimal number like 50.00)", amount)
+		return err
 	}
 
-	// Convert to milliunits
-	amountMilliunits := transform.DollarsToMilliunits(amountFloat)
+	amountMilliunits, err := parseAddAmount(opts.Amount)
+	if err != nil {
+		return err
+	}
 
-	// Default to expense (negative) if positive amount is given
-	// Users typically think "I spent $50" not "I spent -$50"
-	if amountMilliunits > 0 && !strings.HasPrefix(amount, "+") {
-		amountMilliunits = -amountMilliunits
+	// Check splits before any API calls so a bad --split fails fast
+	splits, err := parseSplits(opts.Splits, amountMilliunits)
+	if err != nil {
+		return err
+	}
+	if len(splits) > 0 && (opts.Category != "" || opts.CategoryID != "") {
+		return fmt.Errorf("a split transaction takes its categories from --split; drop the category argument")
+	}
+	if opts.Account != "" && opts.AccountID != "" {
+		return fmt.Errorf("choose one of --account and --account-id")
+	}
+	if opts.Category != "" && opts.CategoryID != "" {
+		return fmt.Errorf("choose one of the category argument and --category-id")
 	}
 
 	// Get default budget ID
@@ -70,64 +184,166 @@ func AddCmd(client *api.Client, amount, payee, category, account, date, memo str
 	}
 
 	// If no date provided, use today
-	if date == "" {
-		date = transform.FormatDate(time.Now())
+	if opts.Date == "" {
+		opts.Date = transform.FormatDate(time.Now())
 	}
 
 	// Validate date format
-	parsedDate := transform.ParseDate(date)
+	parsedDate := transform.ParseDate(opts.Date)
 	if parsedDate.IsZero() {
-		return fmt.Errorf("invalid date format: %s (expected YYYY-MM-DD)", date)
+		return fmt.Errorf("invalid date format: %s (expected YYYY-MM-DD)", opts.Date)
 	}
 
-	// Find account by name or use default
-	accountID, accountName, err := findAccount(client, budgetID, account)
-	if err != nil {
-		return err
+	// Find account by name or use default. A raw ID skips the lookup, so
+	// the name shown is the ID until YNAB returns the transaction.
+	accountID, accountName := opts.AccountID, opts.AccountID
+	if accountID == "" {
+		accountID, accountName, err = findAccount(client, budgetID, opts.Account, opts.DefaultAccount)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Find category by name (if provided)
-	var categoryID string
+	categoryID := opts.CategoryID
 	var categoryName string
-	if category != "" {
-		categoryID, categoryName, err = findCategory(client, budgetID, category)
+	if opts.Category != "" {
+		categoryID, categoryName, err = findCategory(client, budgetID, opts.Category)
 		if err != nil {
 			return err
 		}
 	}
 
+	// Resolve split categories with a single categories fetch
+	var subtransactions []api.SubTransactionRequest
+	var splitItems []SplitItem
+	if len(splits) > 0 {
+		groups, err := lookupCategories(client, budgetID)
+		if err != nil {
+			return fmt.Errorf("failed to get categories: %w", err)
+		}
+		for _, split := range splits {
+			id, name, err := matchCategory(groups, split.Category)
+			if err != nil {
+				return err
+			}
+			subtransactions = append(subtransactions, api.SubTransactionRequest{Amount: split.Amount, CategoryID: id})
+			splitItems = append(splitItems, SplitItem{
+				Category:      name,
+				CategoryID:    id,
+				Amount:        split.Amount,
+				AmountDisplay: transform.FormatCurrency(split.Amount),
+			})
+		}
+	}
+
 	// Create transaction request
 	txnReq := &api.TransactionRequest{
 		BudgetID:  budgetID,
 		AccountID: accountID,
-		Date:      date,
+		Date:      opts.Date,
 		Amount:    amountMilliunits,
-		PayeeName: payee,
-		Memo:      memo,
+		PayeeName: expandAlias(opts.Payee),
+		Memo:      opts.Memo,
 		Cleared:   "uncleared",
-		Approved:  true,
+		Approved:  opts.Approved,
+		ImportID:  opts.ImportID,
+		FlagColor: flag,
+
+		Subtransactions: subtransactions,
 	}
 
 	if categoryID != "" {
 		txnReq.CategoryID = categoryID
 	}
 
+	if opts.DryRun {
+		prepared, err := client.BuildCreateTransaction(txnReq)
+		if err != nil {
+			return err
+		}
+		return printDryRun(budgetID, []*api.PreparedRequest{prepared}, jsonOutput)
+	}
+
+	// Catch the same add run twice. An import ID already guards against
+	// that, and YNAB reports its duplicates itself.
+	if opts.ImportID == "" && !opts.Force {
+		if err := checkDuplicate(client, budgetID, accountID, accountName, txnReq, opts.DuplicateWindowDays, jsonOutput); err != nil {
+			return err
+		}
+	}
+
+	// Snapshot the balance so --verify can compare after creating
+	var balanceBefore int64
+	if opts.Verify {
+		account, err := client.GetAccount(budgetID, accountID)
+		if err != nil {
+			return fmt.Errorf("failed to get account balance: %w", err)
+		}
+		balanceBefore = account.Balance
+	}
+
 	// Create the transaction
 	txn, err := client.CreateTransaction(txnReq)
+	if api.IsDuplicateImportError(err) {
+		return reportDuplicateImport(budgetID, accountID, accountName, opts.ImportID, jsonOutput)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create transaction: %w", err)
 	}
+	if opts.AccountID != "" && txn.AccountName != "" {
+		accountName = txn.AccountName
+	}
+	if opts.CategoryID != "" {
+		categoryName = txn.CategoryName
+	}
+	recordAction(storage.Action{
+		BudgetID: budgetID,
+		Kind:     storage.KindAdd,
+		Summary:  fmt.Sprintf("add %s %s to %s", transform.FormatCurrency(txn.Amount), txn.PayeeName, accountName),
+		Created:  txn.ID,
+	})
+
+	// The transaction exists at this point, so a failed or mismatched check
+	// is reported as a warning rather than an error
+	var verified *bool
+	var observedDelta *int64
+	if opts.Verify {
+		account, err := client.GetAccount(budgetID, accountID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not verify balance: %v\n", err)
+		} else {
+			delta := account.Balance - balanceBefore
+			ok := balanceChangeExpected(txn, delta, amountMilliunits)
+			verified, observedDelta = &ok, &delta
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Warning: %s balance changed by %s, expected %s. YNAB may have matched or merged this transaction.\n",
+					accountName, transform.FormatCurrency(delta), transform.FormatCurrency(amountMilliunits))
+			}
+		}
+	}
 
 	// If JSON output requested, marshal and print
 	if jsonOutput {
 		output := AddOutput{
+			BudgetID:      budgetID,
 			TransactionID: txn.ID,
 			Date:          txn.Date,
 			Amount:        txn.Amount,
 			AmountDisplay: transform.FormatCurrency(txn.Amount),
 			Payee:         txn.PayeeName,
 			Account:       accountName,
+			AccountID:     accountID,
 			Memo:          txn.Memo,
+			FlagColor:     txn.FlagColor,
+			Approved:      txn.Approved,
+			ImportID:      opts.ImportID,
+			Verified:      verified,
+			ObservedDelta: observedDelta,
+			Splits:        splitItems,
+
+			Matched:              txn.MatchedTransactionID != "",
+			MatchedTransactionID: txn.MatchedTransactionID,
 		}
 
 		if categoryName != "" {
@@ -148,7 +364,18 @@ func AddCmd(client *api.Client, amount, payee, category, account, date, memo str
 	fmt.Printf("Amount:   %s\n", transform.FormatCurrency(txn.Amount))
 	fmt.Printf("Payee:    %s\n", txn.PayeeName)
 
-	if categoryName != "" {
+	if len(splitItems) > 0 {
+		fmt.Printf("Category: Split\n")
+		maxName := 0
+		for _, item := range splitItems {
+			if w := displayWidth(item.Category); w > maxName {
+				maxName = w
+			}
+		}
+		for _, item := range splitItems {
+			fmt.Printf("  %s  %12s\n", padCell(item.Category, maxName, false), item.AmountDisplay)
+		}
+	} else if categoryName != "" {
 		fmt.Printf("Category: %s\n", categoryName)
 	} else {
 		fmt.Printf("Category: Uncategorized\n")
@@ -160,15 +387,136 @@ func AddCmd(client *api.Client, amount, payee, category, account, date, memo str
 		fmt.Printf("Memo:     %s\n", txn.Memo)
 	}
 
+	if txn.FlagColor != "" {
+		fmt.Printf("Flag:     %s\n", txn.FlagColor)
+	}
+
+	if !txn.Approved {
+		fmt.Printf("Approved: no (review it in YNAB)\n")
+	}
+
+	if txn.MatchedTransactionID != "" {
+		fmt.Printf("Matched:  imported transaction %s\n", txn.MatchedTransactionID)
+	}
+
+	if verified != nil && *verified {
+		fmt.Printf("Verified: balance changed by %s\n", transform.FormatCurrency(*observedDelta))
+	}
+
 	fmt.Printf("\nTransaction ID: %s\n", txn.ID)
 
 	return nil
 }
 
+// parseAddAmount converts a dollar amount argument to milliunits. A plain
+// amount is an outflow, since users typically think "I spent $50" not "I
+// spent -$50"; a leading + makes it an inflow.
+func parseAddAmount(amount string) (int64, error) {
+	milliunits, err := transform.ParseDollarsToMilliunits(amount)
+	if err != nil {
+		return 0, fmt.Errorf("%w (expected decimal number like 50.00)", err)
+	}
+	if milliunits > 0 && !strings.HasPrefix(amount, "+") {
+		milliunits = -milliunits
+	}
+	return milliunits, nil
+}
+
+// parseFlagColor validates a --flag value, case-insensitively. "none" and
+// the empty string mean no flag and return "".
+func parseFlagColor(flag string) (string, error) {
+	color := strings.ToLower(strings.TrimSpace(flag))
+	if color == "" || color == "none" {
+		return "", nil
+	}
+	if !api.ValidFlagColor(color) {
+		return "", fmt.Errorf("invalid flag color: %s (expected %s, or none)", flag, strings.Join(api.FlagColors, ", "))
+	}
+	return color, nil
+}
+
+// parseSplits parses --split values of the form "category:amount". An amount
+// without a sign goes the same direction as the parent, so "Groceries:60"
+// splits an expense; an explicit + or - is kept. The lines must add up to
+// the parent amount.
+func parseSplits(specs []string, total int64) ([]splitLine, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	if len(specs) < 2 {
+		return nil, fmt.Errorf("a split transaction needs at least two --split values")
+	}
+
+	lines := make([]splitLine, 0, len(specs))
+	var sum int64
+	for _, spec := range specs {
+		// Split on the last colon: category names like "Inflow: Ready to
+		// Assign" contain one
+		idx := strings.LastIndex(spec, ":")
+		if idx <= 0 || idx == len(spec)-1 {
+			return nil, fmt.Errorf("invalid --split '%s' (expected category:amount)", spec)
+		}
+		category := strings.TrimSpace(spec[:idx])
+		amountStr := strings.TrimSpace(spec[idx+1:])
+
+		amount, err := transform.ParseDollarsToMilliunits(amountStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount in --split '%s' (expected decimal number like 40.00)", spec)
+		}
+		if !strings.HasPrefix(amountStr, "+") && !strings.HasPrefix(amountStr, "-") && total < 0 {
+			amount = -amount
+		}
+
+		lines = append(lines, splitLine{Category: category, Amount: amount})
+		sum += amount
+	}
+
+	if sum != total {
+		return nil, fmt.Errorf("splits add up to %s but the transaction amount is %s",
+			transform.FormatCurrency(sum), transform.FormatCurrency(total))
+	}
+	return lines, nil
+}
+
+// balanceChangeExpected reports whether the account balance moved as it should
+// after creating txn. When YNAB matches the new transaction to one already
+// imported from the bank, the imported one was counted in the balance before,
+// so no change is the expected outcome rather than a sign of a lost entry.
+func balanceChangeExpected(txn *api.Transaction, delta, amount int64) bool {
+	if txn.MatchedTransactionID != "" {
+		return delta == 0 || delta == amount
+	}
+	return delta == amount
+}
+
+// reportDuplicateImport reports that a transaction with importID already
+// exists, so re-running a sync job is a no-op rather than an error.
+func reportDuplicateImport(budgetID, accountID, accountName, importID string, jsonOutput bool) error {
+	if jsonOutput {
+		output := AddOutput{
+			BudgetID:  budgetID,
+			Account:   accountName,
+			AccountID: accountID,
+			ImportID:  importID,
+			Duplicate: true,
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	}
+
+	fmt.Printf("Transaction already exists (import ID %s in %s); nothing created.\n", importID, accountName)
+	return nil
+}
+
 // findAccount finds an account by name (case-insensitive partial match).
-// If accountName is empty, returns the first on-budget account.
-func findAccount(client *api.Client, budgetID, accountName string) (string, string, error) {
-	accounts, err := client.GetAccounts(budgetID)
+// If accountName is empty, it uses defaultAccount (the default_account
+// config key), and without one the first on-budget account.
+func findAccount(client *api.Client, budgetID, accountName, defaultAccount string) (string, string, error) {
+	accounts, err := lookupAccounts(client, budgetID)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to get accounts: %w", err)
 	}
@@ -176,7 +524,7 @@ func findAccount(client *api.Client, budgetID, accountName string) (string, stri
 	// Filter to on-budget, open accounts
 	var validAccounts []*api.Account
 	for _, acc := range accounts {
-		if acc.OnBudget && !acc.Closed && !acc.Deleted {
+		if isOpenAccount(acc) {
 			validAccounts = append(validAccounts, acc)
 		}
 	}
@@ -185,60 +533,94 @@ func findAccount(client *api.Client, budgetID, accountName string) (string, stri
 		return "", "", fmt.Errorf("no on-budget accounts found")
 	}
 
+	if accountName == "" && defaultAccount != "" {
+		acc, err := matchAccount(validAccounts, defaultAccount)
+		if err != nil {
+			return "", "", fmt.Errorf("default_account: %w\n\nPass --account, or update default_account in %s ('ynab doctor' checks it)", err, config.Path())
+		}
+		return acc.ID, acc.Name, nil
+	}
+
 	// If no account name specified, use first on-budget account
 	if accountName == "" {
 		return validAccounts[0].ID, validAccounts[0].Name, nil
 	}
 
-	// Try to find account by name (case-insensitive partial match)
+	acc, err := matchAccount(validAccounts, accountName)
+	if err != nil {
+		return "", "", err
+	}
+	return acc.ID, acc.Name, nil
+}
+
+// matchAccount finds an account among candidates by alias, ID or name: an
+// exact name first, then a case-insensitive partial match, then a word-suffix
+// or acronym match. More than one partial match is an error, and no match
+// suggests the closest names.
+func matchAccount(candidates []*api.Account, accountName string) (*api.Account, error) {
+	accountName = expandAlias(accountName)
 	accountNameLower := strings.ToLower(accountName)
 	var matches []*api.Account
 
 	// First pass: exact match
-	for _, acc := range validAccounts {
-		if strings.ToLower(acc.Name) == accountNameLower {
-			return acc.ID, acc.Name, nil
+	for _, acc := range candidates {
+		if acc.ID == accountName || strings.ToLower(acc.Name) == accountNameLower {
+			return acc, nil
 		}
 	}
 
 	// Second pass: partial match
-	for _, acc := range validAccounts {
+	for _, acc := range candidates {
 		if strings.Contains(strings.ToLower(acc.Name), accountNameLower) {
 			matches = append(matches, acc)
 		}
 	}
 
+	// Last resort: word-suffix or acronym match for verbose names
+	if len(matches) == 0 {
+		matches = looseAccountMatches(candidates, accountName)
+	}
+
 	if len(matches) == 0 {
-		// List available accounts
 		var accountNames []string
-		for _, acc := range validAccounts {
+		for _, acc := range candidates {
 			accountNames = append(accountNames, acc.Name)
 		}
-		return "", "", fmt.Errorf("account not found: %s\nAvailable accounts: %s",
+		// Suggest close names for a typo, otherwise list what there is
+		if suggestions := suggestNames(accountName, accountNames); len(suggestions) > 0 {
+			return nil, fmt.Errorf("account not found: %s\n%s", accountName, didYouMean(suggestions))
+		}
+		return nil, fmt.Errorf("account not found: %s\nAvailable accounts: %s",
 			accountName, strings.Join(accountNames, ", "))
 	}
 
 	if len(matches) > 1 {
-		// List matching accounts
-		var matchNames []string
-		for _, acc := range matches {
-			matchNames = append(matchNames, acc.Name)
-		}
-		return "", "", fmt.Errorf("multiple accounts match '%s': %s\nPlease be more specific",
-			accountName, strings.Join(matchNames, ", "))
+		return nil, ambiguousAccountError(accountName, matches)
 	}
 
 	// Single match found
-	return matches[0].ID, matches[0].Name, nil
+	return matches[0], nil
+}
+
+// isOpenAccount reports whether an account is on-budget, open, and not deleted.
+func isOpenAccount(acc *api.Account) bool {
+	return acc.OnBudget && !acc.Closed && !acc.Deleted
 }
 
 // findCategory finds a category by name (case-insensitive partial match).
 func findCategory(client *api.Client, budgetID, categoryName string) (string, string, error) {
-	categoryGroups, err := client.GetCategories(budgetID)
+	categoryGroups, err := lookupCategories(client, budgetID)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to get categories: %w", err)
 	}
+	return matchCategory(categoryGroups, categoryName)
+}
 
+// matchCategory finds a visible category in categoryGroups by alias, ID or
+// name: an exact name first, then a case-insensitive partial match. When
+// nothing matches, the error suggests the closest names.
+func matchCategory(categoryGroups []*api.CategoryGroup, categoryName string) (string, string, error) {
+	categoryName = expandAlias(categoryName)
 	categoryNameLower := strings.ToLower(categoryName)
 	var matches []*api.Category
 
@@ -257,7 +639,7 @@ func findCategory(client *api.Client, budgetID, categoryName string) (string, st
 
 	// First pass: exact match
 	for _, cat := range validCategories {
-		if strings.ToLower(cat.Name) == categoryNameLower {
+		if cat.ID == categoryName || strings.ToLower(cat.Name) == categoryNameLower {
 			return cat.ID, cat.Name, nil
 		}
 	}
@@ -270,6 +652,14 @@ func findCategory(client *api.Client, budgetID, categoryName string) (string, st
 	}
 
 	if len(matches) == 0 {
+		var allNames []string
+		for _, cat := range validCategories {
+			allNames = append(allNames, cat.Name)
+		}
+		if suggestions := suggestNames(categoryName, allNames); len(suggestions) > 0 {
+			return "", "", fmt.Errorf("category not found: %s\n%s", categoryName, didYouMean(suggestions))
+		}
+
 		// List some available categories (limit to 10 for readability)
 		var categoryNames []string
 		for i, cat := range validCategories {
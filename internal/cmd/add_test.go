@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/joeyhipolito/ynab-cli/internal/api"
 	"github.com/joeyhipolito/ynab-cli/internal/transform"
 )
 
@@ -159,3 +164,278 @@ func parseAmountString(s string) (float64, error) {
 	}
 	return strconv.ParseFloat(s, 64)
 }
+
+func TestFindAccount_DefaultAccount(t *testing.T) {
+	server := createTestServer()
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	tests := []struct {
+		name           string
+		account        string
+		defaultAccount string
+		want           string
+	}{
+		{"no default takes the first on-budget account", "", "", "Checking Account"},
+		{"default account is used", "", "savings", "Savings Account"},
+		{"--account beats the default", "credit", "savings", "Credit Card"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, name, err := findAccount(client, "test-budget-1", tt.account, tt.defaultAccount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if name != tt.want {
+				t.Errorf("findAccount() = %s, want %s", name, tt.want)
+			}
+		})
+	}
+
+	// A closed or missing default is an error, not a silent fallback
+	_, _, err := findAccount(client, "test-budget-1", "", "old checking")
+	if err == nil || !strings.Contains(err.Error(), "default_account") {
+		t.Errorf("expected an error naming default_account, got %v", err)
+	}
+}
+
+func TestBalanceChangeExpected(t *testing.T) {
+	tests := []struct {
+		name    string
+		matched string
+		delta   int64
+		want    bool
+	}{
+		{"new transaction moved the balance", "", -50000, true},
+		{"new transaction did not move the balance", "", 0, false},
+		{"new transaction moved by the wrong amount", "", -5000, false},
+		{"matched an import already in the balance", "imported-1", 0, true},
+		{"matched and still moved by the amount", "imported-1", -50000, true},
+		{"matched but moved by the wrong amount", "imported-1", -5000, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := &api.Transaction{Amount: -50000, MatchedTransactionID: tt.matched}
+			if got := balanceChangeExpected(txn, tt.delta, -50000); got != tt.want {
+				t.Errorf("balanceChangeExpected(delta=%d) = %v, want %v", tt.delta, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSplits(t *testing.T) {
+	tests := []struct {
+		name    string
+		specs   []string
+		total   int64
+		want    []splitLine
+		wantErr bool
+	}{
+		{
+			name:  "expense split",
+			specs: []string{"Groceries:60", "Household:40"},
+			total: -100000,
+			want:  []splitLine{{"Groceries", -60000}, {"Household", -40000}},
+		},
+		{
+			name:  "income split",
+			specs: []string{"Salary:900", "Bonus:100.50"},
+			total: 1000500,
+			want:  []splitLine{{"Salary", 900000}, {"Bonus", 100500}},
+		},
+		{
+			name:  "explicit sign for a return inside a purchase",
+			specs: []string{"Groceries:80", "Refunds:+20"},
+			total: -60000,
+			want:  []splitLine{{"Groceries", -80000}, {"Refunds", 20000}},
+		},
+		{
+			name:  "category containing a colon",
+			specs: []string{"Inflow: Ready to Assign:50", "Gifts:50"},
+			total: 100000,
+			want:  []splitLine{{"Inflow: Ready to Assign", 50000}, {"Gifts", 50000}},
+		},
+		{name: "does not add up", specs: []string{"Groceries:60", "Household:30"}, total: -100000, wantErr: true},
+		{name: "single split", specs: []string{"Groceries:100"}, total: -100000, wantErr: true},
+		{name: "missing amount", specs: []string{"Groceries:", "Household:100"}, total: -100000, wantErr: true},
+		{name: "missing category", specs: []string{":60", "Household:40"}, total: -100000, wantErr: true},
+		{name: "bad amount", specs: []string{"Groceries:sixty", "Household:40"}, total: -100000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSplits(tt.specs, tt.total)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSplits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseSplits() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("split %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseFlagColor(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"red", "red", false},
+		{"Purple", "purple", false},
+		{"none", "", false},
+		{"", "", false},
+		{"pink", "", true},
+	}
+	for _, tt := range tests {
+		got, err := parseFlagColor(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseFlagColor(%q) = %q, %v; want %q, error %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFindDuplicate(t *testing.T) {
+	txns := []*api.Transaction{
+		{ID: "t1", Date: "2026-03-10", Amount: -4500, PayeeName: "Coffee Shop"},
+		{ID: "t2", Date: "2026-03-12", Amount: -12000, PayeeName: "Grocery Store", Deleted: true},
+		{ID: "t3", Date: "2026-03-01", Amount: -12000, PayeeName: "Grocery Store"},
+	}
+
+	tests := []struct {
+		name   string
+		date   string
+		amount int64
+		payee  string
+		window int
+		want   string
+	}{
+		{"same day", "2026-03-10", -4500, "Coffee Shop", 3, "t1"},
+		{"payee case differs", "2026-03-10", -4500, "coffee shop", 3, "t1"},
+		{"within window", "2026-03-13", -4500, "Coffee Shop", 3, "t1"},
+		{"before the existing one", "2026-03-07", -4500, "Coffee Shop", 3, "t1"},
+		{"outside window", "2026-03-14", -4500, "Coffee Shop", 3, ""},
+		{"zero window is same day only", "2026-03-11", -4500, "Coffee Shop", 0, ""},
+		{"different amount", "2026-03-10", -4000, "Coffee Shop", 3, ""},
+		{"different payee", "2026-03-10", -4500, "Bakery", 3, ""},
+		{"deleted ignored", "2026-03-12", -12000, "Grocery Store", 3, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findDuplicate(txns, tt.date, tt.amount, tt.payee, tt.window)
+			var id string
+			if got != nil {
+				id = got.ID
+			}
+			if id != tt.want {
+				t.Errorf("findDuplicate() = %q, want %q", id, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddCmd_Duplicate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var posts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost:
+			posts++
+			w.Write([]byte(`{"data":{"transaction":{"id":"new","date":"2026-03-10","amount":-4500,"payee_name":"Coffee Shop","account_id":"a1"}}}`))
+		case strings.HasSuffix(r.URL.Path, "/transactions"):
+			w.Write([]byte(`{"data":{"transactions":[
+				{"id":"t1","date":"2026-03-09","amount":-4500,"payee_name":"Coffee Shop","account_id":"a1"}
+			]}}`))
+		default:
+			w.Write([]byte(`{"data":{"accounts":[
+				{"id":"a1","name":"Checking","type":"checking","on_budget":true,"closed":false,"deleted":false}
+			]}}`))
+		}
+	}))
+	defer server.Close()
+	client := createTestClient(t, server)
+	client.SetDefaultBudgetID("budget-1")
+	opts := AddOptions{Amount: "4.50", Payee: "Coffee Shop", Date: "2026-03-10", Approved: true, DuplicateWindowDays: 3}
+
+	_, err := captureStdout(t, func() error { return AddCmd(client, opts, true) })
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("duplicate under --json: err = %v, want a --force hint", err)
+	}
+
+	confirmInput = strings.NewReader("n\n")
+	defer func() { confirmInput = os.Stdin }()
+	if _, err := captureStdout(t, func() error { return AddCmd(client, opts, false) }); err == nil {
+		t.Error("declined duplicate was added")
+	}
+	if posts != 0 {
+		t.Fatalf("refused adds sent %d request(s)", posts)
+	}
+
+	confirmInput = strings.NewReader("y\n")
+	if _, err := captureStdout(t, func() error { return AddCmd(client, opts, false) }); err != nil || posts != 1 {
+		t.Errorf("confirmed duplicate: err = %v, posts = %d", err, posts)
+	}
+
+	opts.Force = true
+	if _, err := captureStdout(t, func() error { return AddCmd(client, opts, true) }); err != nil || posts != 2 {
+		t.Errorf("forced duplicate: err = %v, posts = %d", err, posts)
+	}
+}
+
+func TestAddCmd_RawIDs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var requests []string
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+			w.Write([]byte(`{"data":{"transaction":{"id":"new","date":"2026-03-10","amount":-4500,"payee_name":"Coffee Shop",
+				"account_id":"acc-9","account_name":"Joint Checking","category_id":"cat-7","category_name":"Dining Out"}}}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"transactions":[]}}`))
+	}))
+	defer server.Close()
+	client := createTestClient(t, server)
+	client.SetDefaultBudgetID("budget-1")
+
+	opts := AddOptions{Amount: "4.50", Payee: "Coffee Shop", Date: "2026-03-10", Approved: true, AccountID: "acc-9", CategoryID: "cat-7"}
+	out, err := captureStdout(t, func() error { return AddCmd(client, opts, false) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the duplicate check and the create; no account or category lookups
+	for _, req := range requests {
+		if strings.HasSuffix(req, "/accounts") || strings.HasSuffix(req, "/categories") {
+			t.Errorf("looked up names with raw IDs: %v", requests)
+		}
+	}
+	if !strings.Contains(body, `"account_id":"acc-9"`) || !strings.Contains(body, `"category_id":"cat-7"`) {
+		t.Errorf("request body = %s", body)
+	}
+	if !strings.Contains(out, "Account:  Joint Checking") || !strings.Contains(out, "Category: Dining Out") {
+		t.Errorf("output = %q", out)
+	}
+
+	for _, conflict := range []AddOptions{
+		{Amount: "1", Payee: "A", Account: "checking", AccountID: "acc-9"},
+		{Amount: "1", Payee: "A", Category: "dining", CategoryID: "cat-7"},
+	} {
+		if err := AddCmd(client, conflict, true); err == nil || !strings.Contains(err.Error(), "choose one") {
+			t.Errorf("AddCmd(%+v) = %v, want a choose-one error", conflict, err)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/joeyhipolito/ynab-cli/internal/config"
+)
+
+// AliasesOutput represents the JSON output for the alias commands.
+type AliasesOutput struct {
+	Aliases []AliasItem `json:"aliases"`
+}
+
+// AliasItem represents a single alias.
+type AliasItem struct {
+	Name   string `json:"name"`
+	Target string `json:"target"`
+}
+
+// aliases maps lowercase short names to the account, category or payee name
+// (or ID) they stand for. Set once at startup from config.
+var aliases map[string]string
+
+// SetAliases installs the aliases consulted by name resolution.
+func SetAliases(m map[string]string) {
+	aliases = m
+}
+
+// expandAlias returns the target of name if it is an alias, otherwise name.
+// Aliases are checked before any name matching, so an alias shadows an
+// account or category with the same name. Expansion is a single step.
+func expandAlias(name string) string {
+	if target, ok := aliases[strings.ToLower(name)]; ok {
+		return target
+	}
+	return name
+}
+
+// validateAlias checks an alias before it is saved. Names must be a single
+// word, and a target may not be another alias since expansion doesn't chain.
+func validateAlias(existing map[string]string, name, target string) error {
+	if name == "" || strings.ContainsAny(name, " \t=") {
+		return fmt.Errorf("invalid alias name '%s': use a single word without spaces or '='", name)
+	}
+	if strings.TrimSpace(target) == "" {
+		return fmt.Errorf("alias '%s' needs a target name or ID", name)
+	}
+	if strings.EqualFold(name, target) {
+		return fmt.Errorf("alias '%s' can't point to itself", name)
+	}
+	if _, ok := existing[strings.ToLower(target)]; ok {
+		return fmt.Errorf("'%s' is already an alias; point '%s' at its target instead", target, name)
+	}
+	for other, otherTarget := range existing {
+		if other != strings.ToLower(name) && strings.EqualFold(otherTarget, name) {
+			return fmt.Errorf("alias '%s' points to '%s'; an alias with that name would shadow it", other, name)
+		}
+	}
+	return nil
+}
+
+// AliasSetCmd adds or replaces an alias.
+func AliasSetCmd(name, target string, jsonOutput bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := validateAlias(cfg.Aliases, name, target); err != nil {
+		return err
+	}
+
+	key := strings.ToLower(name)
+	previous, replaced := cfg.Aliases[key]
+	if cfg.Aliases == nil {
+		cfg.Aliases = make(map[string]string)
+	}
+	cfg.Aliases[key] = target
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if jsonOutput {
+		return printAliases(map[string]string{key: target}, true)
+	}
+	if replaced {
+		fmt.Printf("Alias '%s' now points to '%s' (was '%s').\n", key, target, previous)
+	} else {
+		fmt.Printf("Alias '%s' -> '%s' saved.\n", key, target)
+	}
+	return nil
+}
+
+// AliasRemoveCmd deletes an alias.
+func AliasRemoveCmd(name string, jsonOutput bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	key := strings.ToLower(name)
+	target, ok := cfg.Aliases[key]
+	if !ok {
+		return fmt.Errorf("no alias named '%s'", name)
+	}
+	delete(cfg.Aliases, key)
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if jsonOutput {
+		return printAliases(map[string]string{key: target}, true)
+	}
+	fmt.Printf("Alias '%s' removed.\n", key)
+	return nil
+}
+
+// AliasListCmd lists all aliases.
+func AliasListCmd(jsonOutput bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	return printAliases(cfg.Aliases, jsonOutput)
+}
+
+// printAliases prints aliases sorted by name.
+func printAliases(m map[string]string, jsonOutput bool) error {
+	items := make([]AliasItem, 0, len(m))
+	for name, target := range m {
+		items = append(items, AliasItem{Name: name, Target: target})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(AliasesOutput{Aliases: items})
+	}
+
+	if len(items) == 0 {
+		fmt.Println("No aliases defined.")
+		fmt.Println("Add one with: ynab alias set <name> <target>")
+		return nil
+	}
+
+	maxName := 10
+	for _, item := range items {
+		if len(item.Name) > maxName {
+			maxName = len(item.Name)
+		}
+	}
+	for _, item := range items {
+		fmt.Printf("%-*s  %s\n", maxName, item.Name, item.Target)
+	}
+	return nil
+}
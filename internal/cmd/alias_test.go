@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestAliasResolution(t *testing.T) {
+	SetAliases(map[string]string{
+		"cc":       "Chase Sapphire Credit Card",
+		"checking": "Joint Checking",
+		"groc":     "cat-groceries",
+	})
+	defer SetAliases(nil)
+
+	accounts := []*api.Account{
+		{ID: "chase", Name: "Chase Sapphire Credit Card", OnBudget: true},
+		{ID: "solo", Name: "Checking", OnBudget: true},
+		{ID: "joint", Name: "Joint Checking", OnBudget: true},
+	}
+	groups := []*api.CategoryGroup{{
+		Name: "Everyday",
+		Categories: []*api.Category{
+			{ID: "cat-groceries", Name: "Groceries"},
+			{ID: "cat-gas", Name: "Gas"},
+		},
+	}}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   string
+	}{
+		{"alias to name", "cc", "chase"},
+		{"alias is case-insensitive", "CC", "chase"},
+		{"alias shadows an exact account name", "checking", "joint"},
+		{"non-alias falls through to matching", "chase", "chase"},
+		{"account ID matches directly", "solo", "solo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findAccountID(accounts, tt.filter)
+			if err != nil {
+				t.Fatalf("findAccountID(%q): %v", tt.filter, err)
+			}
+			if got != tt.want {
+				t.Errorf("findAccountID(%q) = %q, want %q", tt.filter, got, tt.want)
+			}
+		})
+	}
+
+	if got := findCategoryID(groups, "groc"); got != "cat-groceries" {
+		t.Errorf("category alias to ID = %q, want cat-groceries", got)
+	}
+	if got := findCategoryID(groups, "gas"); got != "cat-gas" {
+		t.Errorf("category without alias = %q, want cat-gas", got)
+	}
+	if !matchesPayee("Chase Sapphire Credit Card Payment", "cc") {
+		t.Error("payee filter should expand aliases")
+	}
+}
+
+func TestValidateAlias(t *testing.T) {
+	existing := map[string]string{
+		"cc":   "Chase Sapphire Credit Card",
+		"groc": "Groceries",
+	}
+
+	tests := []struct {
+		name    string
+		alias   string
+		target  string
+		wantErr bool
+	}{
+		{"new alias", "gas", "Gas & Fuel", false},
+		{"replacing an alias", "cc", "Citi Card", false},
+		{"target is an alias", "card", "cc", true},
+		{"target is an alias, other case", "card", "CC", true},
+		{"name is another alias's target", "Groceries", "Food", true},
+		{"points to itself", "rent", "Rent", true},
+		{"name with spaces", "my card", "Visa", true},
+		{"name with equals", "a=b", "Visa", true},
+		{"empty name", "", "Visa", true},
+		{"empty target", "visa", " ", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAlias(existing, tt.alias, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateAlias(%q, %q) error = %v, wantErr %v", tt.alias, tt.target, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+// ApproveOutput represents the JSON output for the approve command.
+type ApproveOutput struct {
+	BudgetID      string            `json:"budget_id"`
+	Approved      []TransactionItem `json:"approved"`
+	ApprovedCount int               `json:"approved_count"`
+	Skipped       []ApproveSkip     `json:"skipped"`
+	SkippedCount  int               `json:"skipped_count"`
+}
+
+// ApproveSkip is a transaction approve left alone, and why.
+type ApproveSkip struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+// ApproveOptions holds the parameters for the approve command.
+type ApproveOptions struct {
+	IDs          []string // Transactions to approve
+	All          bool     // Approve every unapproved transaction in the window instead
+	SinceDate    string   // Window for All: YYYY-MM-DD, today, Nd, Nw or Nm (default: DefaultSince, then 30d)
+	DefaultSince string   // default_since from config
+}
+
+// ApproveCmd approves transactions, either the given IDs or every unapproved
+// transaction since a date. All approvals go out in a single bulk update.
+func ApproveCmd(client *api.Client, opts ApproveOptions, jsonOutput bool) error {
+	if opts.All == (len(opts.IDs) > 0) {
+		return fmt.Errorf("give either transaction IDs or --all")
+	}
+
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	var candidates []*api.Transaction
+	if opts.All {
+		sinceDate, err := resolveSinceDate(opts.SinceDate, opts.DefaultSince, time.Now())
+		if err != nil {
+			return err
+		}
+		transactions, err := client.GetTransactions(budgetID, sinceDate)
+		if err != nil {
+			return fmt.Errorf("failed to get transactions: %w", err)
+		}
+		// Approved ones aren't candidates, so only named IDs are ever skipped
+		for _, t := range transactions {
+			if !t.Approved && !t.Deleted {
+				candidates = append(candidates, t)
+			}
+		}
+	} else {
+		// Look every ID up first so a typo fails before anything changes
+		for _, id := range opts.IDs {
+			txn, err := client.GetTransaction(budgetID, id)
+			if err != nil {
+				return fmt.Errorf("failed to get transaction %s: %w", id, err)
+			}
+			candidates = append(candidates, txn)
+		}
+	}
+
+	toApprove, skipped := planApproval(candidates)
+
+	var approved []*api.Transaction
+	if len(toApprove) > 0 {
+		updates := make([]map[string]interface{}, 0, len(toApprove))
+		for _, t := range toApprove {
+			updates = append(updates, map[string]interface{}{"id": t.ID, "approved": true})
+		}
+		approved, err = client.PatchTransactions(budgetID, updates)
+		if err != nil {
+			return fmt.Errorf("failed to approve transactions: %w", err)
+		}
+	}
+
+	if jsonOutput {
+		output := ApproveOutput{
+			BudgetID:      budgetID,
+			Approved:      make([]TransactionItem, 0, len(approved)),
+			ApprovedCount: len(approved),
+			Skipped:       make([]ApproveSkip, 0, len(skipped)),
+			SkippedCount:  len(skipped),
+		}
+		for _, t := range approved {
+			output.Approved = append(output.Approved, newTransactionItem(t))
+		}
+		output.Skipped = append(output.Skipped, skipped...)
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	if len(approved) == 0 && len(skipped) == 0 {
+		fmt.Println("No unapproved transactions found.")
+		return nil
+	}
+
+	if len(approved) > 0 {
+		tbl := table{columns: []tableColumn{
+			{Header: "Date", MinWidth: 12},
+			{Header: "Payee", MinWidth: 15, MaxWidth: 30},
+			{Header: "Amount", Right: true, MinWidth: 12},
+			{Header: "Account", MinWidth: 10, MaxWidth: 15},
+		}}
+		for _, t := range approved {
+			tbl.addRow(t.Date, t.PayeeName, transform.FormatCurrency(t.Amount), t.AccountName)
+		}
+		tbl.render(os.Stdout)
+		fmt.Println()
+	}
+
+	for _, s := range skipped {
+		fmt.Printf("Skipped %s: %s\n", s.ID, s.Reason)
+	}
+	fmt.Printf("Approved %d transaction(s), skipped %d.\n", len(approved), len(skipped))
+	return nil
+}
+
+// planApproval splits transactions into those to approve and those to skip.
+func planApproval(transactions []*api.Transaction) ([]*api.Transaction, []ApproveSkip) {
+	var toApprove []*api.Transaction
+	var skipped []ApproveSkip
+	seen := make(map[string]bool)
+	for _, t := range transactions {
+		switch {
+		case seen[t.ID]:
+			// Named twice; approve it once
+		case t.Deleted:
+			skipped = append(skipped, ApproveSkip{ID: t.ID, Reason: "deleted"})
+		case t.Approved:
+			skipped = append(skipped, ApproveSkip{ID: t.ID, Reason: "already approved"})
+		default:
+			toApprove = append(toApprove, t)
+		}
+		seen[t.ID] = true
+	}
+	return toApprove, skipped
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestPlanApproval(t *testing.T) {
+	transactions := []*api.Transaction{
+		{ID: "imported-1"},
+		{ID: "entered", Approved: true},
+		{ID: "gone", Deleted: true},
+		{ID: "imported-2"},
+		{ID: "imported-1"},
+	}
+
+	toApprove, skipped := planApproval(transactions)
+
+	if len(toApprove) != 2 || toApprove[0].ID != "imported-1" || toApprove[1].ID != "imported-2" {
+		t.Errorf("unexpected transactions to approve: %+v", toApprove)
+	}
+	want := []ApproveSkip{
+		{ID: "entered", Reason: "already approved"},
+		{ID: "gone", Reason: "deleted"},
+	}
+	if len(skipped) != len(want) || skipped[0] != want[0] || skipped[1] != want[1] {
+		t.Errorf("skipped = %+v, want %+v", skipped, want)
+	}
+}
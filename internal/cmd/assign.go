@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/storage"
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+// AssignOutput represents the JSON output for the assign command.
+type AssignOutput struct {
+	BudgetID        string `json:"budget_id"`
+	Month           string `json:"month"`
+	CategoryID      string `json:"category_id"`
+	CategoryName    string `json:"category_name"`
+	BudgetedBefore  int64  `json:"budgeted_before"`
+	Budgeted        int64  `json:"budgeted"`
+	Activity        int64  `json:"activity"`
+	Balance         int64  `json:"balance"`
+	BudgetedDisplay string `json:"budgeted_display"`
+	BalanceDisplay  string `json:"balance_display"`
+}
+
+// AssignCmd sets a category's budgeted amount for a month, replacing what
+// was assigned before. month is YYYY-MM; empty means the current month.
+func AssignCmd(client *api.Client, amountMilliunits int64, categoryName, month string, jsonOutput bool) error {
+	month, err := assignMonth(month, time.Now())
+	if err != nil {
+		return err
+	}
+
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	categoryID, name, err := findCategory(client, budgetID, categoryName)
+	if err != nil {
+		return err
+	}
+
+	// The categories list only has this month's figures, so read the
+	// target month for the amount being replaced
+	monthData, err := client.GetMonth(budgetID, month)
+	if err != nil {
+		return fmt.Errorf("failed to get month data: %w", err)
+	}
+	var before int64
+	for _, c := range monthData.Categories {
+		if c.ID == categoryID {
+			before = c.Budgeted
+		}
+	}
+
+	category, err := client.UpdateCategoryBudget(categoryID, amountMilliunits, month, budgetID)
+	if err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+	recordAction(storage.Action{
+		BudgetID: budgetID,
+		Kind:     storage.KindAssign,
+		Summary:  fmt.Sprintf("assign %s to %s (%s)", transform.FormatCurrency(amountMilliunits), name, month[:7]),
+		Budgeted: []storage.BudgetedBefore{{CategoryID: categoryID, Month: month, Budgeted: before}},
+	})
+
+	if jsonOutput {
+		output := AssignOutput{
+			BudgetID:        budgetID,
+			Month:           month[:7],
+			CategoryID:      categoryID,
+			CategoryName:    name,
+			BudgetedBefore:  before,
+			Budgeted:        category.Budgeted,
+			Activity:        category.Activity,
+			Balance:         category.Balance,
+			BudgetedDisplay: transform.FormatCurrency(category.Budgeted),
+			BalanceDisplay:  transform.FormatCurrency(category.Balance),
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	fmt.Printf("Assigned %s to '%s' (%s)\n\n", transform.FormatCurrency(category.Budgeted), name, month[:7])
+	fmt.Printf("  Budgeted: %s -> %s\n", formatAmount(before), formatAmount(category.Budgeted))
+	fmt.Printf("  Activity: %s\n", formatAmount(category.Activity))
+	fmt.Printf("  Balance:  %s\n", formatAmount(category.Balance))
+	return nil
+}
+
+// assignMonth returns month (YYYY-MM or YYYY-MM-DD) as the first of the
+// month in YNAB's format, or the current month if month is empty.
+func assignMonth(month string, now time.Time) (string, error) {
+	if month == "" {
+		return transform.FormatMonth(now.Year(), int(now.Month())) + "-01", nil
+	}
+	year, m, err := transform.ParseMonth(month)
+	if err != nil {
+		return "", err
+	}
+	return transform.FormatMonth(year, m) + "-01", nil
+}
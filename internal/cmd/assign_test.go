@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAssignMonth(t *testing.T) {
+	now := time.Date(2025, 3, 17, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "2025-03-01"},
+		{"2025-06", "2025-06-01"},
+		{"2024-12-15", "2024-12-01"},
+	}
+	for _, tt := range tests {
+		got, err := assignMonth(tt.input, now)
+		if err != nil || got != tt.want {
+			t.Errorf("assignMonth(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"June", "2025-13"} {
+		if _, err := assignMonth(bad, now); err == nil {
+			t.Errorf("assignMonth(%q) should fail", bad)
+		}
+	}
+}
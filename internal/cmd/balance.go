@@ -1,20 +1,45 @@
 package cmd
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/joeyhipolito/ynab-cli/internal/api"
-	"github.com/joeyhipolito/ynab-cli/internal/transform"
 )
 
 // BalanceOutput represents the JSON output format for the balance command.
 type BalanceOutput struct {
-	Accounts []AccountBalance `json:"accounts"`
+	BudgetID string             `json:"budget_id"`
+	Accounts []AccountBalance   `json:"accounts"`
+	Types    []AccountTypeTotal `json:"types,omitempty"` // with --group-by-type
 }
 
+// AccountTypeTotal sums the listed accounts of one type.
+type AccountTypeTotal struct {
+	Type             string `json:"type"`
+	Count            int    `json:"count"`
+	Balance          int64  `json:"balance"`
+	ClearedBalance   int64  `json:"cleared_balance"`
+	UnclearedBalance int64  `json:"uncleared_balance"`
+}
+
+// BalanceOptions holds the filters for the balance command.
+type BalanceOptions struct {
+	Filter      string // Account name substring (case-insensitive)
+	Type        string // Account type or add-account alias, e.g. "cc"
+	GroupByType bool   // Cluster accounts under type headings with subtotals
+	JSONL       bool   // one JSON object per account, per line
+	Sort        string // "name", "balance" or "type" (default: API order)
+	Desc        bool   // Reverse the Sort order
+}
+
+// balanceSortKeys are the values --sort accepts for balance.
+var balanceSortKeys = []string{"name", "balance", "type"}
+
 // AccountBalance represents a single account's balance information.
 type AccountBalance struct {
 	ID               string `json:"id"`
@@ -27,10 +52,48 @@ type AccountBalance struct {
 	Closed           bool   `json:"closed"`
 }
 
+func newAccountBalance(account *api.Account) AccountBalance {
+	return AccountBalance{
+		ID:               account.ID,
+		Name:             account.Name,
+		Type:             account.Type,
+		Balance:          account.Balance,
+		ClearedBalance:   account.ClearedBalance,
+		UnclearedBalance: account.UnclearedBalance,
+		OnBudget:         account.OnBudget,
+		Closed:           account.Closed,
+	}
+}
+
 // BalanceCmd retrieves and displays account balances.
-// If filter is provided, only accounts matching the filter (case-insensitive) are shown.
+// If a filter is provided, only accounts matching it (case-insensitive) are
+// shown; a type keeps only accounts of that type.
 // If jsonOutput is true, outputs JSON instead of human-readable format.
-func BalanceCmd(client *api.Client, filter string, jsonOutput bool) error {
+func BalanceCmd(client *api.Client, opts BalanceOptions, jsonOutput bool) error {
+	filter := opts.Filter
+	accountType := ""
+	if opts.Type != "" {
+		var err error
+		if accountType, err = resolveBalanceType(opts.Type); err != nil {
+			return err
+		}
+	}
+	if opts.GroupByType && csvOutput {
+		return fmt.Errorf("--csv lists accounts; it can't be combined with --group-by-type")
+	}
+	if err := checkJSONLines(opts.JSONL); err != nil {
+		return err
+	}
+	if opts.GroupByType && opts.JSONL {
+		return fmt.Errorf("--jsonl lists accounts; it can't be combined with --group-by-type")
+	}
+	if opts.Sort != "" && !slices.Contains(balanceSortKeys, opts.Sort) {
+		return fmt.Errorf("invalid --sort value: %s (expected name, balance or type)", opts.Sort)
+	}
+	if opts.Desc && opts.Sort == "" {
+		return fmt.Errorf("--desc needs --sort")
+	}
+
 	// Get default budget ID
 	budgetID, err := client.GetDefaultBudgetID()
 	if err != nil {
@@ -58,34 +121,51 @@ func BalanceCmd(client *api.Client, filter string, jsonOutput bool) error {
 				continue
 			}
 		}
+		if accountType != "" && account.Type != accountType {
+			continue
+		}
 
 		filtered = append(filtered, account)
 	}
 
 	if len(filtered) == 0 {
-		if filter != "" {
+		switch {
+		case filter != "" && accountType != "":
+			return fmt.Errorf("no %s accounts found matching '%s'", formatAccountType(accountType), filter)
+		case filter != "":
 			return fmt.Errorf("no accounts found matching '%s'", filter)
+		case accountType != "":
+			return fmt.Errorf("no %s accounts found", formatAccountType(accountType))
 		}
 		return fmt.Errorf("no accounts found")
 	}
 
+	if opts.Sort != "" {
+		slices.SortStableFunc(filtered, accountOrder(opts.Sort, opts.Desc))
+	}
+
+	if opts.JSONL {
+		lines := newJSONLines(os.Stdout)
+		for _, account := range filtered {
+			if err := lines.write(newAccountBalance(account)); err != nil {
+				return err
+			}
+		}
+		return lines.flush()
+	}
+
 	// If JSON output requested, marshal and print
 	if jsonOutput {
 		output := BalanceOutput{
+			BudgetID: budgetID,
 			Accounts: make([]AccountBalance, 0, len(filtered)),
 		}
 
 		for _, account := range filtered {
-			output.Accounts = append(output.Accounts, AccountBalance{
-				ID:               account.ID,
-				Name:             account.Name,
-				Type:             account.Type,
-				Balance:          account.Balance,
-				ClearedBalance:   account.ClearedBalance,
-				UnclearedBalance: account.UnclearedBalance,
-				OnBudget:         account.OnBudget,
-				Closed:           account.Closed,
-			})
+			output.Accounts = append(output.Accounts, newAccountBalance(account))
+		}
+		if opts.GroupByType {
+			output.Types = totalsByType(filtered)
 		}
 
 		encoder := json.NewEncoder(os.Stdout)
@@ -96,54 +176,38 @@ func BalanceCmd(client *api.Client, filter string, jsonOutput bool) error {
 		return nil
 	}
 
+	if csvOutput {
+		return writeBalanceCSV(os.Stdout, filtered)
+	}
+
 	// Human-readable output
 	fmt.Printf("Account Balances:\n\n")
 
-	// Calculate column widths
-	maxNameLen := 15 // Minimum width
-	for _, account := range filtered {
-		if len(account.Name) > maxNameLen {
-			maxNameLen = len(account.Name)
-		}
+	if opts.GroupByType {
+		printBalancesByType(filtered)
+		return nil
 	}
 
-	// Print header
-	nameHeader := "Account"
-	typeHeader := "Type"
-	balanceHeader := "Balance"
-	clearedHeader := "Cleared"
-	unclearedHeader := "Uncleared"
-
-	fmt.Printf("%-*s  %-12s  %15s  %15s  %15s\n",
-		maxNameLen, nameHeader, typeHeader, balanceHeader, clearedHeader, unclearedHeader)
-	fmt.Printf("%s\n", strings.Repeat("-", maxNameLen+12+15+15+15+8))
+	tbl := table{columns: []tableColumn{
+		{Header: "Account", MinWidth: 15},
+		{Header: "Type", MinWidth: 12},
+		{Header: "Balance", Right: true, MinWidth: 15},
+		{Header: "Cleared", Right: true, MinWidth: 15},
+		{Header: "Uncleared", Right: true, MinWidth: 15},
+	}}
 
-	// Print accounts
+	// Track totals for on-budget accounts only
 	var totalBalance int64
 	var totalCleared int64
 	var totalUncleared int64
 	onBudgetCount := 0
 
 	for _, account := range filtered {
-		// Format type nicely
-		displayType := formatAccountType(account.Type)
-
-		// Format account name with status indicators
-		displayName := account.Name
-		if account.Closed {
-			displayName += " [CLOSED]"
-		}
-		if !account.OnBudget {
-			displayName += " (off-budget)"
-		}
+		tbl.addRow(balanceDisplayName(account), formatAccountType(account.Type),
+			formatAmount(account.Balance),
+			formatAmount(account.ClearedBalance),
+			formatAmount(account.UnclearedBalance))
 
-		fmt.Printf("%-*s  %-12s  %15s  %15s  %15s\n",
-			maxNameLen, displayName, displayType,
-			transform.FormatCurrency(account.Balance),
-			transform.FormatCurrency(account.ClearedBalance),
-			transform.FormatCurrency(account.UnclearedBalance))
-
-		// Track totals for on-budget accounts only
 		if account.OnBudget && !account.Closed {
 			totalBalance += account.Balance
 			totalCleared += account.ClearedBalance
@@ -154,17 +218,120 @@ func BalanceCmd(client *api.Client, filter string, jsonOutput bool) error {
 
 	// Print totals if we have multiple on-budget accounts
 	if onBudgetCount > 1 {
-		fmt.Printf("%s\n", strings.Repeat("-", maxNameLen+12+15+15+15+8))
-		fmt.Printf("%-*s  %-12s  %15s  %15s  %15s\n",
-			maxNameLen, "Total (on-budget)", "",
-			transform.FormatCurrency(totalBalance),
-			transform.FormatCurrency(totalCleared),
-			transform.FormatCurrency(totalUncleared))
+		tbl.addFooter("Total (on-budget)", "",
+			formatAmount(totalBalance),
+			formatAmount(totalCleared),
+			formatAmount(totalUncleared))
 	}
 
+	tbl.render(os.Stdout)
 	return nil
 }
 
+// accountOrder returns the comparison for a balance --sort key, reversed
+// when desc is set. Names and types compare case-insensitively, types by
+// their display name.
+func accountOrder(key string, desc bool) func(a, b *api.Account) int {
+	var order func(a, b *api.Account) int
+	switch key {
+	case "balance":
+		order = func(a, b *api.Account) int { return cmp.Compare(a.Balance, b.Balance) }
+	case "type":
+		order = func(a, b *api.Account) int {
+			return strings.Compare(strings.ToLower(formatAccountType(a.Type)), strings.ToLower(formatAccountType(b.Type)))
+		}
+	default:
+		order = func(a, b *api.Account) int {
+			return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		}
+	}
+	if desc {
+		return func(a, b *api.Account) int { return order(b, a) }
+	}
+	return order
+}
+
+// printBalancesByType prints the accounts under a heading per type, in the
+// order the types first appear, each with a subtotal of its accounts.
+func printBalancesByType(accounts []*api.Account) {
+	for _, total := range totalsByType(accounts) {
+		heading := formatAccountType(total.Type)
+		fmt.Printf("%s\n", heading)
+		fmt.Printf("%s\n", strings.Repeat("-", displayWidth(heading)))
+
+		tbl := table{
+			columns: []tableColumn{
+				{Header: "Account", MinWidth: 20},
+				{Header: "Balance", Right: true, MinWidth: 15},
+				{Header: "Cleared", Right: true, MinWidth: 15},
+				{Header: "Uncleared", Right: true, MinWidth: 15},
+			},
+			indent:     "  ",
+			hideHeader: true,
+		}
+		for _, account := range accounts {
+			if account.Type != total.Type {
+				continue
+			}
+			tbl.addRow(balanceDisplayName(account),
+				formatAmount(account.Balance),
+				formatAmount(account.ClearedBalance),
+				formatAmount(account.UnclearedBalance))
+		}
+		if total.Count > 1 {
+			tbl.addFooter("Subtotal",
+				formatAmount(total.Balance),
+				formatAmount(total.ClearedBalance),
+				formatAmount(total.UnclearedBalance))
+		}
+		tbl.render(os.Stdout)
+		fmt.Println()
+	}
+}
+
+// totalsByType sums accounts per type, in the order the types first appear.
+func totalsByType(accounts []*api.Account) []AccountTypeTotal {
+	var totals []AccountTypeTotal
+	index := make(map[string]int)
+	for _, account := range accounts {
+		i, ok := index[account.Type]
+		if !ok {
+			i = len(totals)
+			index[account.Type] = i
+			totals = append(totals, AccountTypeTotal{Type: account.Type})
+		}
+		totals[i].Count++
+		totals[i].Balance += account.Balance
+		totals[i].ClearedBalance += account.ClearedBalance
+		totals[i].UnclearedBalance += account.UnclearedBalance
+	}
+	return totals
+}
+
+// balanceDisplayName marks closed and off-budget accounts.
+func balanceDisplayName(account *api.Account) string {
+	name := account.Name
+	if account.Closed {
+		name += " [CLOSED]"
+	}
+	if !account.OnBudget {
+		name += " (off-budget)"
+	}
+	return name
+}
+
+// resolveBalanceType maps a --type value to an account type. On top of the
+// types and aliases add-account accepts, it takes the loan and debt types
+// YNAB reports for existing accounts.
+func resolveBalanceType(accountType string) (string, error) {
+	for t := range liabilityAccountTypes {
+		if strings.EqualFold(t, strings.TrimSpace(accountType)) {
+			return t, nil
+		}
+	}
+	return resolveAccountType(accountType)
+}
+
 // formatAccountType formats the account type for display.
 func formatAccountType(accountType string) string {
 	switch accountType {
@@ -182,6 +349,18 @@ func formatAccountType(accountType string) string {
 		return "Other Asset"
 	case "otherLiability":
 		return "Other Liability"
+	case "mortgage":
+		return "Mortgage"
+	case "autoLoan":
+		return "Auto Loan"
+	case "studentLoan":
+		return "Student Loan"
+	case "personalLoan":
+		return "Personal Loan"
+	case "medicalDebt":
+		return "Medical Debt"
+	case "otherDebt":
+		return "Other Debt"
 	default:
 		return accountType
 	}
@@ -118,14 +118,149 @@ func createTestClient(t *testing.T, server *httptest.Server) *api.Client {
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
-
-	// Use reflection or a helper to override the base URL
-	// Since we can't easily do this without modifying the api package,
-	// we'll need to add a SetBaseURL method or similar
-	// For now, we'll skip the full integration test and focus on unit tests
+	client.SetBaseURL(server.URL)
 	return client
 }
 
+// captureStdout runs fn and returns what it wrote to stdout.
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := fn()
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String(), err
+}
+
+func TestBalanceCmd(t *testing.T) {
+	server := createTestServer()
+	defer server.Close()
+	client := createTestClient(t, server)
+
+	t.Run("human readable output", func(t *testing.T) {
+		output, err := captureStdout(t, func() error { return BalanceCmd(client, BalanceOptions{}, false) })
+		if err != nil {
+			t.Fatalf("BalanceCmd failed: %v", err)
+		}
+		if !strings.Contains(output, "Checking Account") || !strings.Contains(output, "$150,000.00") {
+			t.Errorf("output missing checking balance:\n%s", output)
+		}
+		if strings.Contains(output, "Deleted Account") {
+			t.Errorf("output lists a deleted account:\n%s", output)
+		}
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		output, err := captureStdout(t, func() error { return BalanceCmd(client, BalanceOptions{Filter: "savings"}, true) })
+		if err != nil {
+			t.Fatalf("BalanceCmd failed: %v", err)
+		}
+		var result BalanceOutput
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Invalid JSON output: %v", err)
+		}
+		if result.BudgetID != "test-budget-1" {
+			t.Errorf("budget_id = %q, want test-budget-1", result.BudgetID)
+		}
+		if len(result.Accounts) != 1 || result.Accounts[0].ID != "acc-2" {
+			t.Errorf("filter savings returned %+v, want acc-2 only", result.Accounts)
+		}
+	})
+
+	t.Run("type filter", func(t *testing.T) {
+		output, err := captureStdout(t, func() error {
+			return BalanceCmd(client, BalanceOptions{Type: "cc"}, true)
+		})
+		if err != nil {
+			t.Fatalf("BalanceCmd failed: %v", err)
+		}
+		var result BalanceOutput
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Invalid JSON output: %v", err)
+		}
+		if len(result.Accounts) != 1 || result.Accounts[0].ID != "acc-3" {
+			t.Errorf("type cc returned %+v, want acc-3 only", result.Accounts)
+		}
+
+		if err := BalanceCmd(client, BalanceOptions{Type: "mortgage"}, true); err == nil {
+			t.Error("Expected an error when no account has the type")
+		}
+		if err := BalanceCmd(client, BalanceOptions{Type: "bogus"}, true); err == nil {
+			t.Error("Expected an error for an unknown type")
+		}
+	})
+
+	t.Run("jsonl output", func(t *testing.T) {
+		output, err := captureStdout(t, func() error {
+			return BalanceCmd(client, BalanceOptions{Type: "checking", JSONL: true}, true)
+		})
+		if err != nil {
+			t.Fatalf("BalanceCmd failed: %v", err)
+		}
+		lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("got %d lines, want one per checking account:\n%s", len(lines), output)
+		}
+		for _, line := range lines {
+			var account AccountBalance
+			if err := json.Unmarshal([]byte(line), &account); err != nil || account.Type != "checking" {
+				t.Errorf("line %q: %+v, %v", line, account, err)
+			}
+		}
+
+		if err := BalanceCmd(client, BalanceOptions{GroupByType: true, JSONL: true}, false); err == nil {
+			t.Error("Expected an error for --jsonl with --group-by-type")
+		}
+	})
+
+	t.Run("sort", func(t *testing.T) {
+		output, err := captureStdout(t, func() error {
+			return BalanceCmd(client, BalanceOptions{Sort: "balance", Desc: true}, true)
+		})
+		if err != nil {
+			t.Fatalf("BalanceCmd failed: %v", err)
+		}
+		var result BalanceOutput
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Invalid JSON output: %v", err)
+		}
+		for i := 1; i < len(result.Accounts); i++ {
+			if result.Accounts[i-1].Balance < result.Accounts[i].Balance {
+				t.Errorf("accounts not in descending balance order: %+v", result.Accounts)
+				break
+			}
+		}
+
+		if err := BalanceCmd(client, BalanceOptions{Sort: "bogus"}, true); err == nil {
+			t.Error("Expected an error for an unknown sort key")
+		}
+		if err := BalanceCmd(client, BalanceOptions{Desc: true}, true); err == nil {
+			t.Error("Expected an error for --desc without --sort")
+		}
+	})
+
+	t.Run("group by type", func(t *testing.T) {
+		output, err := captureStdout(t, func() error {
+			return BalanceCmd(client, BalanceOptions{Type: "checking", GroupByType: true}, false)
+		})
+		if err != nil {
+			t.Fatalf("BalanceCmd failed: %v", err)
+		}
+		for _, want := range []string{"Checking\n--------\n", "Old Checking [CLOSED]", "Subtotal", "$150,000.00"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("output missing %q:\n%s", want, output)
+			}
+		}
+	})
+}
+
 func TestBalanceCmd_Integration(t *testing.T) {
 	if os.Getenv("YNAB_ACCESS_TOKEN") == "" {
 		t.Skip("Skipping integration test: YNAB_ACCESS_TOKEN not set")
@@ -143,7 +278,7 @@ func TestBalanceCmd_Integration(t *testing.T) {
 		r, w, _ := os.Pipe()
 		os.Stdout = w
 
-		err := BalanceCmd(client, "", false)
+		err := BalanceCmd(client, BalanceOptions{}, false)
 
 		w.Close()
 		os.Stdout = oldStdout
@@ -169,7 +304,7 @@ func TestBalanceCmd_Integration(t *testing.T) {
 		r, w, _ := os.Pipe()
 		os.Stdout = w
 
-		err := BalanceCmd(client, "", true)
+		err := BalanceCmd(client, BalanceOptions{}, true)
 
 		w.Close()
 		os.Stdout = oldStdout
@@ -13,33 +13,53 @@ import (
 
 // BudgetOutput represents the JSON output format for the budget command.
 type BudgetOutput struct {
+	BudgetID       string          `json:"budget_id"`
 	Month          string          `json:"month"`
 	CategoryGroups []CategoryGroup `json:"category_groups"`
+
+	OverspentCategories []OverspentCategory `json:"overspent_categories"`
+}
+
+// OverspentCategory is a category whose balance has gone below zero.
+// Overspent is the shortfall as a positive amount.
+type OverspentCategory struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Group     string `json:"group"`
+	Balance   int64  `json:"balance"`
+	Overspent int64  `json:"overspent"`
 }
 
 // CategoryGroup represents a category group with its categories.
 type CategoryGroup struct {
-	ID         string           `json:"id"`
-	Name       string           `json:"name"`
-	Categories []CategoryBudget `json:"categories"`
-	TotalBudgeted int64         `json:"total_budgeted"`
-	TotalActivity int64         `json:"total_activity"`
-	TotalBalance  int64         `json:"total_balance"`
+	ID            string           `json:"id"`
+	Name          string           `json:"name"`
+	Categories    []CategoryBudget `json:"categories"`
+	TotalBudgeted int64            `json:"total_budgeted"`
+	TotalActivity int64            `json:"total_activity"`
+	TotalBalance  int64            `json:"total_balance"`
 }
 
 // CategoryBudget represents a single category's budget information.
 type CategoryBudget struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Budgeted int64  `json:"budgeted"`
-	Activity int64  `json:"activity"`
-	Balance  int64  `json:"balance"`
+	ID                     string `json:"id"`
+	Name                   string `json:"name"`
+	Budgeted               int64  `json:"budgeted"`
+	Activity               int64  `json:"activity"`
+	Balance                int64  `json:"balance"`
+	Note                   string `json:"note,omitempty"`
+	GoalType               string `json:"goal_type,omitempty"`
+	GoalTarget             int64  `json:"goal_target,omitempty"`
+	GoalTargetMonth        string `json:"goal_target_month,omitempty"`
+	GoalPercentageComplete int    `json:"goal_percentage_complete,omitempty"`
 }
 
 // BudgetCmd retrieves and displays category budgets for the current month.
-// Categories are grouped by their category groups.
+// Categories are grouped by their category groups, and any with a negative
+// balance are listed again as overspent. With failOnOverspend, the command
+// returns an error after printing if there are any, so a cron job can alert.
 // If jsonOutput is true, outputs JSON instead of human-readable format.
-func BudgetCmd(client *api.Client, jsonOutput bool) error {
+func BudgetCmd(client *api.Client, failOnOverspend, jsonOutput bool) error {
 	// Get default budget ID
 	budgetID, err := client.GetDefaultBudgetID()
 	if err != nil {
@@ -56,11 +76,15 @@ func BudgetCmd(client *api.Client, jsonOutput bool) error {
 	now := time.Now()
 	currentMonth := transform.FormatMonth(now.Year(), int(now.Month())) + "-01"
 
+	overspent := overspentCategories(categoryGroups)
+
 	// If JSON output requested, marshal and print
 	if jsonOutput {
 		output := BudgetOutput{
-			Month:          currentMonth,
-			CategoryGroups: make([]CategoryGroup, 0),
+			BudgetID:            budgetID,
+			Month:               currentMonth,
+			CategoryGroups:      make([]CategoryGroup, 0),
+			OverspentCategories: overspent,
 		}
 
 		for _, group := range categoryGroups {
@@ -87,11 +111,16 @@ func BudgetCmd(client *api.Client, jsonOutput bool) error {
 				}
 
 				categoryGroup.Categories = append(categoryGroup.Categories, CategoryBudget{
-					ID:       category.ID,
-					Name:     category.Name,
-					Budgeted: category.Budgeted,
-					Activity: category.Activity,
-					Balance:  category.Balance,
+					ID:                     category.ID,
+					Name:                   category.Name,
+					Budgeted:               category.Budgeted,
+					Activity:               category.Activity,
+					Balance:                category.Balance,
+					Note:                   category.Note,
+					GoalType:               category.GoalType,
+					GoalTarget:             category.GoalTarget,
+					GoalTargetMonth:        category.GoalTargetMonth,
+					GoalPercentageComplete: category.GoalPercentageComplete,
 				})
 
 				// Add to group totals
@@ -111,7 +140,14 @@ func BudgetCmd(client *api.Client, jsonOutput bool) error {
 		if err := encoder.Encode(output); err != nil {
 			return fmt.Errorf("failed to encode JSON: %w", err)
 		}
-		return nil
+		return overspendError(overspent, failOnOverspend)
+	}
+
+	if csvOutput {
+		if err := writeCategoriesCSV(os.Stdout, categoryGroups, "", 0); err != nil {
+			return err
+		}
+		return overspendError(overspent, failOnOverspend)
 	}
 
 	// Human-readable output
@@ -150,14 +186,17 @@ func BudgetCmd(client *api.Client, jsonOutput bool) error {
 
 		// Print group header
 		fmt.Printf("%s\n", group.Name)
-		fmt.Printf("%s\n", strings.Repeat("-", len(group.Name)))
-
-		// Calculate column width for category names
-		maxNameLen := 20
-		for _, category := range visibleCategories {
-			if len(category.Name) > maxNameLen {
-				maxNameLen = len(category.Name)
-			}
+		fmt.Printf("%s\n", strings.Repeat("-", displayWidth(group.Name)))
+
+		tbl := table{
+			columns: []tableColumn{
+				{Header: "Category", MinWidth: 20},
+				{Header: "Budgeted", Right: true, MinWidth: 15},
+				{Header: "Activity", Right: true, MinWidth: 15},
+				{Header: "Balance", Right: true, MinWidth: 15},
+			},
+			indent:     "  ",
+			hideHeader: true,
 		}
 
 		// Print categories
@@ -166,11 +205,10 @@ func BudgetCmd(client *api.Client, jsonOutput bool) error {
 		var groupTotalBalance int64
 
 		for _, category := range visibleCategories {
-			fmt.Printf("  %-*s  %15s  %15s  %15s\n",
-				maxNameLen, category.Name,
-				transform.FormatCurrency(category.Budgeted),
-				transform.FormatCurrency(category.Activity),
-				transform.FormatCurrency(category.Balance))
+			tbl.addRow(category.Name,
+				formatAmount(category.Budgeted),
+				formatAmount(category.Activity),
+				formatAmount(category.Balance))
 
 			groupTotalBudgeted += category.Budgeted
 			groupTotalActivity += category.Activity
@@ -179,14 +217,13 @@ func BudgetCmd(client *api.Client, jsonOutput bool) error {
 
 		// Print group totals if there's more than one category
 		if len(visibleCategories) > 1 {
-			fmt.Printf("  %s\n", strings.Repeat("-", maxNameLen+15+15+15+6))
-			fmt.Printf("  %-*s  %15s  %15s  %15s\n",
-				maxNameLen, "Total",
-				transform.FormatCurrency(groupTotalBudgeted),
-				transform.FormatCurrency(groupTotalActivity),
-				transform.FormatCurrency(groupTotalBalance))
+			tbl.addFooter("Total",
+				formatAmount(groupTotalBudgeted),
+				formatAmount(groupTotalActivity),
+				formatAmount(groupTotalBalance))
 		}
 
+		tbl.render(os.Stdout)
 		fmt.Println()
 
 		// Add to grand totals
@@ -198,9 +235,62 @@ func BudgetCmd(client *api.Client, jsonOutput bool) error {
 	// Print grand totals
 	fmt.Printf("Overall Totals\n")
 	fmt.Printf("==============\n")
-	fmt.Printf("Budgeted:  %s\n", transform.FormatCurrency(grandTotalBudgeted))
-	fmt.Printf("Activity:  %s\n", transform.FormatCurrency(grandTotalActivity))
-	fmt.Printf("Balance:   %s\n", transform.FormatCurrency(grandTotalBalance))
+	fmt.Printf("Budgeted:  %s\n", formatAmount(grandTotalBudgeted))
+	fmt.Printf("Activity:  %s\n", formatAmount(grandTotalActivity))
+	fmt.Printf("Balance:   %s\n", formatAmount(grandTotalBalance))
+
+	if len(overspent) > 0 {
+		fmt.Printf("\nOverspent\n")
+		fmt.Printf("=========\n")
+		tbl := table{columns: []tableColumn{
+			{Header: "Category", MinWidth: 20},
+			{Header: "Group", MinWidth: 15},
+			{Header: "Overspent", Right: true, MinWidth: 15},
+		}, hideHeader: true}
+		for _, c := range overspent {
+			tbl.addRow(c.Name, c.Group, formatAmount(c.Balance))
+		}
+		tbl.render(os.Stdout)
+	}
 
-	return nil
+	return overspendError(overspent, failOnOverspend)
+}
+
+// overspentCategories lists the visible categories with a negative balance,
+// in budget order.
+func overspentCategories(groups []*api.CategoryGroup) []OverspentCategory {
+	overspent := make([]OverspentCategory, 0)
+	for _, group := range groups {
+		if group.Hidden || group.Deleted || group.Name == "Internal Master Category" {
+			continue
+		}
+		for _, category := range group.Categories {
+			if category.Hidden || category.Deleted || category.Balance >= 0 {
+				continue
+			}
+			overspent = append(overspent, OverspentCategory{
+				ID:        category.ID,
+				Name:      category.Name,
+				Group:     group.Name,
+				Balance:   category.Balance,
+				Overspent: -category.Balance,
+			})
+		}
+	}
+	return overspent
+}
+
+// overspendError is the error --fail-on-overspend returns once the budget
+// has been printed, or nil.
+func overspendError(overspent []OverspentCategory, failOnOverspend bool) error {
+	if !failOnOverspend || len(overspent) == 0 {
+		return nil
+	}
+	var total int64
+	names := make([]string, 0, len(overspent))
+	for _, c := range overspent {
+		total += c.Overspent
+		names = append(names, c.Name)
+	}
+	return fmt.Errorf("overspent by %s in total: %s", transform.FormatCurrency(total), strings.Join(names, ", "))
 }
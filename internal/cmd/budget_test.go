@@ -29,7 +29,7 @@ func TestBudgetCmd_Integration(t *testing.T) {
 		r, w, _ := os.Pipe()
 		os.Stdout = w
 
-		err := BudgetCmd(client, false)
+		err := BudgetCmd(client, false, false)
 
 		w.Close()
 		os.Stdout = oldStdout
@@ -59,7 +59,7 @@ func TestBudgetCmd_Integration(t *testing.T) {
 		r, w, _ := os.Pipe()
 		os.Stdout = w
 
-		err := BudgetCmd(client, true)
+		err := BudgetCmd(client, false, true)
 
 		w.Close()
 		os.Stdout = oldStdout
@@ -143,3 +143,91 @@ func TestBudgetOutput_JSON(t *testing.T) {
 		t.Errorf("Expected 2 categories, got %d", len(unmarshaled.CategoryGroups[0].Categories))
 	}
 }
+
+// TestCategoryGoalFields_JSON tests that goal and note fields serialize when
+// set and are omitted when zero.
+func TestCategoryGoalFields_JSON(t *testing.T) {
+	goalFields := []string{"note", "goal_type", "goal_target", "goal_target_month", "goal_percentage_complete"}
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  bool
+	}{
+		{
+			name: "budget category with goal",
+			value: CategoryBudget{
+				ID: "cat-1", Name: "Vacation", Note: "Summer trip",
+				GoalType: "TBD", GoalTarget: 1200000, GoalTargetMonth: "2024-07-01", GoalPercentageComplete: 40,
+			},
+			want: true,
+		},
+		{
+			name:  "budget category without goal",
+			value: CategoryBudget{ID: "cat-2", Name: "Groceries"},
+			want:  false,
+		},
+		{
+			name: "category info with goal",
+			value: CategoryInfo{
+				ID: "cat-1", Name: "Vacation", Note: "Summer trip",
+				GoalType: "TBD", GoalTarget: 1200000, GoalTargetMonth: "2024-07-01", GoalPercentageComplete: 40,
+			},
+			want: true,
+		},
+		{
+			name:  "category info without goal",
+			value: CategoryInfo{ID: "cat-2", Name: "Groceries"},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("Failed to marshal JSON: %v", err)
+			}
+			for _, field := range goalFields {
+				if got := strings.Contains(string(data), `"`+field+`"`); got != tt.want {
+					t.Errorf("field %q present = %v, want %v (json: %s)", field, got, tt.want, data)
+				}
+			}
+		})
+	}
+}
+
+func TestOverspentCategories(t *testing.T) {
+	groups := []*api.CategoryGroup{
+		{Name: "Everyday", Categories: []*api.Category{
+			{ID: "groceries", Name: "Groceries", Balance: -25000},
+			{ID: "dining", Name: "Dining Out", Balance: 10000},
+			{ID: "old", Name: "Old Hobby", Balance: -5000, Hidden: true},
+		}},
+		{Name: "Bills", Categories: []*api.Category{
+			{ID: "phone", Name: "Phone", Balance: -12500},
+		}},
+		{Name: "Retired", Hidden: true, Categories: []*api.Category{
+			{ID: "gym", Name: "Gym", Balance: -30000},
+		}},
+	}
+
+	overspent := overspentCategories(groups)
+	if len(overspent) != 2 || overspent[0].ID != "groceries" || overspent[1].ID != "phone" {
+		t.Fatalf("overspentCategories() = %+v, want groceries and phone", overspent)
+	}
+	if overspent[1].Group != "Bills" || overspent[1].Overspent != 12500 {
+		t.Errorf("phone = %+v, want group Bills and 12500 overspent", overspent[1])
+	}
+
+	if err := overspendError(overspent, false); err != nil {
+		t.Errorf("without --fail-on-overspend: %v", err)
+	}
+	err := overspendError(overspent, true)
+	if err == nil || !strings.Contains(err.Error(), "$37.50") || !strings.Contains(err.Error(), "Groceries, Phone") {
+		t.Errorf("overspendError() = %v", err)
+	}
+	if err := overspendError(overspentCategories(nil), true); err != nil {
+		t.Errorf("nothing overspent: %v", err)
+	}
+}
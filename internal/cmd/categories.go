@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/joeyhipolito/ynab-cli/internal/api"
@@ -11,6 +14,7 @@ import (
 
 // CategoriesOutput represents the JSON output format for the categories command.
 type CategoriesOutput struct {
+	BudgetID       string              `json:"budget_id"`
 	CategoryGroups []CategoryGroupInfo `json:"category_groups"`
 }
 
@@ -23,14 +27,48 @@ type CategoryGroupInfo struct {
 
 // CategoryInfo represents a single category's information.
 type CategoryInfo struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID                     string `json:"id"`
+	Name                   string `json:"name"`
+	Note                   string `json:"note,omitempty"`
+	GoalType               string `json:"goal_type,omitempty"`
+	GoalTarget             int64  `json:"goal_target,omitempty"`
+	GoalTargetMonth        string `json:"goal_target_month,omitempty"`
+	GoalPercentageComplete int    `json:"goal_percentage_complete,omitempty"`
+
+	// Current month's figures, only with --with-amounts
+	Budgeted *int64 `json:"budgeted,omitempty"`
+	Activity *int64 `json:"activity,omitempty"`
+	Balance  *int64 `json:"balance,omitempty"`
+}
+
+// CategoryLine represents a single category in JSON Lines output.
+// Group fields are flattened onto each line so every line stands alone.
+type CategoryLine struct {
+	GroupID   string `json:"group_id"`
+	GroupName string `json:"group_name"`
+	CategoryInfo
+}
+
+// CategoriesOptions holds the filters for the categories command.
+type CategoriesOptions struct {
+	Filter  string // case-insensitive match on category name
+	Limit   int    // 0 means no limit
+	JSONL   bool   // one JSON object per line, streamed
+	Sort    string // "name", "id", or "" / "none" for API order
+	Reverse bool
+	Group   string // case-insensitive partial match on category group name
+	// WithAmounts adds the current month's budgeted, activity and balance
+	WithAmounts bool
 }
 
 // CategoriesCmd retrieves and displays all categories with their IDs.
 // Categories are grouped by their category groups.
 // If jsonOutput is true, outputs JSON instead of human-readable format.
-func CategoriesCmd(client *api.Client, jsonOutput bool) error {
+func CategoriesCmd(client *api.Client, opts CategoriesOptions, jsonOutput bool) error {
+	if err := checkJSONLines(opts.JSONL); err != nil {
+		return err
+	}
+
 	// Get default budget ID
 	budgetID, err := client.GetDefaultBudgetID()
 	if err != nil {
@@ -43,110 +81,281 @@ func CategoriesCmd(client *api.Client, jsonOutput bool) error {
 		return fmt.Errorf("failed to get categories: %w", err)
 	}
 
+	return writeCategories(os.Stdout, budgetID, categoryGroups, opts, jsonOutput)
+}
+
+// newCategoryInfo converts an API category to its output form, with the
+// month's amounts if withAmounts is set.
+func newCategoryInfo(category *api.Category, withAmounts bool) CategoryInfo {
+	info := CategoryInfo{
+		ID:                     category.ID,
+		Name:                   category.Name,
+		Note:                   category.Note,
+		GoalType:               category.GoalType,
+		GoalTarget:             category.GoalTarget,
+		GoalTargetMonth:        category.GoalTargetMonth,
+		GoalPercentageComplete: category.GoalPercentageComplete,
+	}
+	if withAmounts {
+		budgeted, activity, balance := category.Budgeted, category.Activity, category.Balance
+		info.Budgeted, info.Activity, info.Balance = &budgeted, &activity, &balance
+	}
+	return info
+}
+
+// filterCategoryGroups returns the groups whose name contains name,
+// case-insensitively. An empty name keeps every group.
+func filterCategoryGroups(groups []*api.CategoryGroup, name string) ([]*api.CategoryGroup, error) {
+	if name == "" {
+		return groups, nil
+	}
+	nameLower := strings.ToLower(name)
+	var matched []*api.CategoryGroup
+	for _, group := range groups {
+		if group.Deleted || group.Name == "Internal Master Category" {
+			continue
+		}
+		if strings.Contains(strings.ToLower(group.Name), nameLower) {
+			matched = append(matched, group)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no category group matching '%s'", name)
+	}
+	return matched, nil
+}
+
+// visibleCategories returns the categories of group that should be listed,
+// skipping hidden, deleted and non-matching ones. At most limit categories
+// are returned when limit is positive.
+func visibleCategories(group *api.CategoryGroup, filter string, limit int) []*api.Category {
+	// Skip hidden and deleted groups
+	if group.Hidden || group.Deleted {
+		return nil
+	}
+
+	// Skip internal master category
+	if group.Name == "Internal Master Category" {
+		return nil
+	}
+
+	filterLower := strings.ToLower(filter)
+	var visible []*api.Category
+	for _, category := range group.Categories {
+		if limit > 0 && len(visible) >= limit {
+			break
+		}
+		if category.Hidden || category.Deleted {
+			continue
+		}
+		if filter != "" && !strings.Contains(strings.ToLower(category.Name), filterLower) {
+			continue
+		}
+		visible = append(visible, category)
+	}
+	return visible
+}
+
+// writeCategories renders category groups to w. Output is written group by
+// group as it is processed; only the single-object --json path holds the
+// whole result before encoding.
+func writeCategories(w io.Writer, budgetID string, categoryGroups []*api.CategoryGroup, opts CategoriesOptions, jsonOutput bool) error {
+	categoryGroups, err := filterCategoryGroups(categoryGroups, opts.Group)
+	if err != nil {
+		return err
+	}
+	categoryGroups = sortCategoryGroups(categoryGroups, opts.Sort, opts.Reverse)
+
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	remaining := opts.Limit
+	if opts.Limit <= 0 {
+		remaining = -1
+	}
+
+	if csvOutput {
+		if err := writeCategoriesCSV(bw, categoryGroups, opts.Filter, opts.Limit); err != nil {
+			return err
+		}
+		return bw.Flush()
+	}
+
+	if opts.JSONL {
+		lines := newJSONLines(w)
+		for _, group := range categoryGroups {
+			if remaining == 0 {
+				break
+			}
+			for _, category := range visibleCategories(group, opts.Filter, remaining) {
+				line := CategoryLine{
+					GroupID:      group.ID,
+					GroupName:    group.Name,
+					CategoryInfo: newCategoryInfo(category, opts.WithAmounts),
+				}
+				if err := lines.write(line); err != nil {
+					return err
+				}
+				remaining--
+			}
+		}
+		return lines.flush()
+	}
+
 	// If JSON output requested, marshal and print
 	if jsonOutput {
 		output := CategoriesOutput{
+			BudgetID:       budgetID,
 			CategoryGroups: make([]CategoryGroupInfo, 0),
 		}
 
 		for _, group := range categoryGroups {
-			// Skip hidden and deleted groups
-			if group.Hidden || group.Deleted {
-				continue
+			if remaining == 0 {
+				break
 			}
 
-			// Skip internal master category
-			if group.Name == "Internal Master Category" {
+			visible := visibleCategories(group, opts.Filter, remaining)
+
+			// Only include groups that have categories
+			if len(visible) == 0 {
 				continue
 			}
 
 			categoryGroup := CategoryGroupInfo{
 				ID:         group.ID,
 				Name:       group.Name,
-				Categories: make([]CategoryInfo, 0),
+				Categories: make([]CategoryInfo, 0, len(visible)),
 			}
-
-			for _, category := range group.Categories {
-				// Skip hidden and deleted categories
-				if category.Hidden || category.Deleted {
-					continue
-				}
-
-				categoryGroup.Categories = append(categoryGroup.Categories, CategoryInfo{
-					ID:   category.ID,
-					Name: category.Name,
-				})
+			for _, category := range visible {
+				categoryGroup.Categories = append(categoryGroup.Categories, newCategoryInfo(category, opts.WithAmounts))
 			}
+			remaining -= len(visible)
 
-			// Only include groups that have categories
-			if len(categoryGroup.Categories) > 0 {
-				output.CategoryGroups = append(output.CategoryGroups, categoryGroup)
-			}
+			output.CategoryGroups = append(output.CategoryGroups, categoryGroup)
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := json.NewEncoder(bw)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
 			return fmt.Errorf("failed to encode JSON: %w", err)
 		}
-		return nil
+		return bw.Flush()
 	}
 
 	// Human-readable output
-	fmt.Printf("Categories:\n\n")
+	fmt.Fprintf(bw, "Categories:\n\n")
 
 	// Track total categories
 	totalCategories := 0
 
 	// Process each category group
 	for _, group := range categoryGroups {
-		// Skip hidden and deleted groups
-		if group.Hidden || group.Deleted {
-			continue
+		if remaining == 0 {
+			break
 		}
 
-		// Skip internal master category
-		if group.Name == "Internal Master Category" {
-			continue
-		}
-
-		// Filter out hidden/deleted categories
-		var visibleCategories []*api.Category
-		for _, category := range group.Categories {
-			if !category.Hidden && !category.Deleted {
-				visibleCategories = append(visibleCategories, category)
-			}
-		}
+		visible := visibleCategories(group, opts.Filter, remaining)
 
 		// Skip groups with no visible categories
-		if len(visibleCategories) == 0 {
+		if len(visible) == 0 {
 			continue
 		}
 
 		// Print group header
-		fmt.Printf("%s\n", group.Name)
-		fmt.Printf("%s\n", strings.Repeat("-", len(group.Name)))
+		fmt.Fprintf(bw, "%s\n", group.Name)
+		fmt.Fprintf(bw, "%s\n", strings.Repeat("-", len(group.Name)))
+
+		if opts.WithAmounts {
+			tbl := table{
+				columns: []tableColumn{
+					{Header: "Category", MinWidth: 20},
+					{Header: "Budgeted", Right: true, MinWidth: 15},
+					{Header: "Activity", Right: true, MinWidth: 15},
+					{Header: "Balance", Right: true, MinWidth: 15},
+					{Header: "ID"},
+				},
+				indent:     "  ",
+				hideHeader: true,
+			}
+			for _, category := range visible {
+				tbl.addRow(category.Name,
+					formatAmount(category.Budgeted),
+					formatAmount(category.Activity),
+					formatAmount(category.Balance),
+					category.ID)
+			}
+			tbl.render(bw)
+			totalCategories += len(visible)
+			remaining -= len(visible)
+			fmt.Fprintln(bw)
+			continue
+		}
 
 		// Calculate column width for category names
 		maxNameLen := 20
-		for _, category := range visibleCategories {
+		for _, category := range visible {
 			if len(category.Name) > maxNameLen {
 				maxNameLen = len(category.Name)
 			}
 		}
 
 		// Print categories with IDs
-		for _, category := range visibleCategories {
-			fmt.Printf("  %-*s  %s\n",
+		for _, category := range visible {
+			fmt.Fprintf(bw, "  %-*s  %s\n",
 				maxNameLen, category.Name, category.ID)
 			totalCategories++
 		}
+		remaining -= len(visible)
 
-		fmt.Println()
+		fmt.Fprintln(bw)
 	}
 
 	// Print summary
-	fmt.Printf("Total: %d categories\n", totalCategories)
+	fmt.Fprintf(bw, "Total: %d categories\n", totalCategories)
 
-	return nil
+	return bw.Flush()
+}
+
+// sortCategoryGroups returns groups, and the categories within each group,
+// ordered by name (case-insensitive) or ID. Any other key keeps API order.
+// The input is not modified.
+func sortCategoryGroups(groups []*api.CategoryGroup, by string, reverse bool) []*api.CategoryGroup {
+	if (by != "name" && by != "id") && !reverse {
+		return groups
+	}
+
+	less := func(nameA, nameB, idA, idB string) bool {
+		if by == "id" {
+			return idA < idB
+		}
+		return lessByName(nameA, nameB, idA, idB)
+	}
+	sorting := by == "name" || by == "id"
+
+	sorted := make([]*api.CategoryGroup, 0, len(groups))
+	for _, g := range groups {
+		group := *g
+		group.Categories = make([]*api.Category, len(g.Categories))
+		copy(group.Categories, g.Categories)
+		if sorting {
+			sort.SliceStable(group.Categories, func(i, j int) bool {
+				a, b := group.Categories[i], group.Categories[j]
+				return less(a.Name, b.Name, a.ID, b.ID)
+			})
+		}
+		if reverse {
+			reverseSlice(group.Categories)
+		}
+		sorted = append(sorted, &group)
+	}
+
+	if sorting {
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return less(sorted[i].Name, sorted[j].Name, sorted[i].ID, sorted[j].ID)
+		})
+	}
+	if reverse {
+		reverseSlice(sorted)
+	}
+	return sorted
 }
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+// ANSI escape sequences used to highlight amounts.
+const (
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiReset = "\x1b[0m"
+)
+
+// colorOutput makes human-readable output highlight amounts. Set once at
+// startup from --color, --no-color or the terminal; JSON and CSV never use it.
+var colorOutput bool
+
+// SetColorOutput turns amount highlighting on or off.
+func SetColorOutput(enabled bool) {
+	colorOutput = enabled
+}
+
+// ColorAuto reports whether color should be used when neither --color nor
+// --no-color is given: stdout must be a terminal and NO_COLOR unset.
+func ColorAuto() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	fi, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// formatAmount formats milliunits as currency for human-readable output,
+// red for outflows and green for inflows when color is on. Zero stays plain.
+func formatAmount(milliunits int64) string {
+	return formatAmountIn(milliunits, nil)
+}
+
+// formatAmountIn is formatAmount in a budget's currency format; a nil
+// format formats as dollars.
+func formatAmountIn(milliunits int64, cf *api.CurrencyFormat) string {
+	s := transform.FormatCurrencyWithFormat(milliunits, currencyFormat(cf))
+	if !colorOutput {
+		return s
+	}
+	switch {
+	case milliunits < 0:
+		return ansiRed + s + ansiReset
+	case milliunits > 0:
+		return ansiGreen + s + ansiReset
+	}
+	return s
+}
+
+// currencyFormat converts a budget's currency format for transform. A nil
+// format stays nil, which formats as dollars.
+func currencyFormat(cf *api.CurrencyFormat) *transform.CurrencyFormat {
+	if cf == nil {
+		return nil
+	}
+	return &transform.CurrencyFormat{
+		Symbol:           cf.CurrencySymbol,
+		SymbolFirst:      cf.SymbolFirst,
+		DisplaySymbol:    cf.DisplaySymbol,
+		DecimalDigits:    cf.DecimalDigits,
+		DecimalSeparator: cf.DecimalSeparator,
+		GroupSeparator:   cf.GroupSeparator,
+	}
+}
+
+// stripANSI removes escape sequences from s, for output such as Markdown
+// that is meant to be pasted elsewhere.
+func stripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if n := ansiLen(s[i:]); n > 0 {
+			i += n - 1
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// ansiLen returns the length of the escape sequence s starts with, or 0.
+// Only the CSI sequences formatAmount writes ("\x1b[...m") are recognized.
+func ansiLen(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFormatAmount(t *testing.T) {
+	if got := formatAmount(-12340); got != "-$12.34" {
+		t.Errorf("color off: got %q", got)
+	}
+
+	SetColorOutput(true)
+	defer SetColorOutput(false)
+	if got := formatAmount(-12340); got != ansiRed+"-$12.34"+ansiReset {
+		t.Errorf("negative: got %q", got)
+	}
+	if got := formatAmount(5000); got != ansiGreen+"$5.00"+ansiReset {
+		t.Errorf("positive: got %q", got)
+	}
+	if got := formatAmount(0); got != "$0.00" {
+		t.Errorf("zero: got %q", got)
+	}
+}
+
+func TestColoredTableAlignment(t *testing.T) {
+	SetColorOutput(true)
+	defer SetColorOutput(false)
+
+	tbl := table{columns: []tableColumn{{Header: "Name"}, {Header: "Amount", Right: true}}}
+	tbl.addRow("Rent", formatAmount(-1500000))
+	tbl.addRow("Pay", formatAmount(200000))
+	var buf bytes.Buffer
+	tbl.render(&buf)
+
+	lines := strings.Split(strings.TrimRight(stripANSI(buf.String()), "\n"), "\n")
+	want := []string{
+		"Name      Amount",
+		"----------------",
+		"Rent  -$1,500.00",
+		"Pay      $200.00",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	md := renderTable(t, TableStyleMarkdown, tbl)
+	if strings.Contains(md, "\x1b") {
+		t.Errorf("markdown output contains escapes: %q", md)
+	}
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+// completionCommands are the subcommands the completion scripts offer.
+var completionCommands = []string{
+	"status", "balance", "budget", "net-worth", "stats", "report", "categories",
+	"transactions", "search", "payees", "months", "scheduled", "schedule", "add", "edit",
+	"delete", "restore", "undo", "approve", "import", "move", "assign",
+	"transfer", "reconcile", "sweep", "add-account", "account", "export", "sync",
+	"alias", "configure", "doctor", "whoami", "completion",
+}
+
+// CompleteCmd prints the names a completion script offers for kind
+// ("payees", "categories" or "accounts"), one per line. Deleted, hidden and
+// closed entries are left out. Accounts and categories come from the lookup
+// cache when it is enabled, so repeated tab presses stay fast.
+func CompleteCmd(client *api.Client, kind string) error {
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	names, err := completionNames(client, budgetID, kind)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		fmt.Println(name)
+	}
+	return nil
+}
+
+// completionNames returns the sorted, de-duplicated names for kind.
+func completionNames(client *api.Client, budgetID, kind string) ([]string, error) {
+	var names []string
+	switch kind {
+	case "payees":
+		payees, err := client.GetPayees(budgetID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get payees: %w", err)
+		}
+		for _, p := range payees {
+			// Transfer payees ("Transfer : Savings") are picked by account
+			if !p.Deleted && p.TransferAccountID == "" {
+				names = append(names, p.Name)
+			}
+		}
+	case "categories":
+		groups, err := lookupCategories(client, budgetID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get categories: %w", err)
+		}
+		for _, g := range groups {
+			if g.Hidden || g.Deleted || g.Name == "Internal Master Category" {
+				continue
+			}
+			for _, c := range g.Categories {
+				if !c.Hidden && !c.Deleted {
+					names = append(names, c.Name)
+				}
+			}
+		}
+	case "accounts":
+		accounts, err := lookupAccounts(client, budgetID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get accounts: %w", err)
+		}
+		for _, a := range accounts {
+			if !a.Closed && !a.Deleted {
+				names = append(names, a.Name)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown completion kind %q (use payees, categories or accounts)", kind)
+	}
+
+	sort.Strings(names)
+	return compactNames(names), nil
+}
+
+// compactNames drops empty names and repeats from a sorted list.
+func compactNames(names []string) []string {
+	out := names[:0]
+	for i, name := range names {
+		if name == "" || (i > 0 && name == names[i-1]) {
+			continue
+		}
+		out = append(out, name)
+	}
+	return out
+}
+
+// CompletionCmd prints the completion script for shell: bash, zsh or fish.
+func CompletionCmd(shell string) error {
+	script, err := completionScript(shell)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.WriteString(script)
+	return err
+}
+
+func completionScript(shell string) (string, error) {
+	commands := strings.Join(completionCommands, " ")
+	switch shell {
+	case "bash":
+		return strings.ReplaceAll(bashCompletion, "@COMMANDS@", commands), nil
+	case "zsh":
+		return strings.ReplaceAll(zshCompletion, "@COMMANDS@", commands), nil
+	case "fish":
+		return strings.ReplaceAll(fishCompletion, "@COMMANDS@", commands), nil
+	default:
+		return "", fmt.Errorf("unsupported shell %q (use bash, zsh or fish)", shell)
+	}
+}
+
+// The scripts complete subcommands, then names after --account, --category,
+// --payee and --from/--to (accounts for transfer, categories for move and
+// sweep), and add's payee and category arguments, by calling
+// `ynab __complete`. Errors from it, say without a token, are discarded so
+// a tab press never prints anything.
+
+const bashCompletion = `# bash completion for ynab
+# Install: ynab completion bash > /etc/bash_completion.d/ynab
+#      or: source <(ynab completion bash)
+
+_ynab_names() {
+    local IFS=$'\n'
+    COMPREPLY=($(compgen -W "$(ynab __complete "$1" 2>/dev/null)" -- "$cur"))
+    COMPREPLY=("${COMPREPLY[@]// /\\ }")
+}
+
+_ynab() {
+    local cur prev
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+    if [[ $COMP_CWORD -eq 1 ]]; then
+        COMPREPLY=($(compgen -W "@COMMANDS@" -- "$cur"))
+        return
+    fi
+
+    case "$prev" in
+        --from|--to)
+            if [[ ${COMP_WORDS[1]} == transfer ]]; then
+                _ynab_names accounts
+            else
+                _ynab_names categories
+            fi
+            return
+            ;;
+        --account) _ynab_names accounts; return ;;
+        --category) _ynab_names categories; return ;;
+        --payee) _ynab_names payees; return ;;
+    esac
+
+    case "${COMP_WORDS[1]}" in
+        add)
+            case $COMP_CWORD in
+                3) _ynab_names payees ;;
+                4) _ynab_names categories ;;
+            esac
+            ;;
+        balance) [[ $COMP_CWORD -eq 2 ]] && _ynab_names accounts ;;
+        completion) [[ $COMP_CWORD -eq 2 ]] && COMPREPLY=($(compgen -W "bash zsh fish" -- "$cur")) ;;
+    esac
+}
+
+complete -F _ynab ynab
+`
+
+const zshCompletion = `# zsh completion for ynab
+# Install: add to ~/.zshrc, after compinit:
+#     source <(ynab completion zsh)
+
+_ynab_names() {
+    local -a names
+    names=("${(@f)$(ynab __complete "$1" 2>/dev/null)}")
+    compadd -a names
+}
+
+_ynab() {
+    if (( CURRENT == 2 )); then
+        local -a commands
+        commands=(@COMMANDS@)
+        compadd -a commands
+        return
+    fi
+
+    case "${words[CURRENT-1]}" in
+        --from|--to)
+            if [[ ${words[2]} == transfer ]]; then
+                _ynab_names accounts
+            else
+                _ynab_names categories
+            fi
+            return
+            ;;
+        --account) _ynab_names accounts; return ;;
+        --category) _ynab_names categories; return ;;
+        --payee) _ynab_names payees; return ;;
+    esac
+
+    case "${words[2]}" in
+        add)
+            case $CURRENT in
+                4) _ynab_names payees ;;
+                5) _ynab_names categories ;;
+            esac
+            ;;
+        balance) (( CURRENT == 3 )) && _ynab_names accounts ;;
+        completion) (( CURRENT == 3 )) && compadd bash zsh fish ;;
+    esac
+}
+
+compdef _ynab ynab
+`
+
+const fishCompletion = `# fish completion for ynab
+# Install: ynab completion fish > ~/.config/fish/completions/ynab.fish
+
+complete -c ynab -f
+complete -c ynab -n __fish_use_subcommand -a "@COMMANDS@"
+complete -c ynab -l account -x -a "(ynab __complete accounts 2>/dev/null)"
+complete -c ynab -n "__fish_seen_subcommand_from transfer" -l from -x -a "(ynab __complete accounts 2>/dev/null)"
+complete -c ynab -n "__fish_seen_subcommand_from transfer" -l to -x -a "(ynab __complete accounts 2>/dev/null)"
+complete -c ynab -n "__fish_seen_subcommand_from move sweep" -l from -x -a "(ynab __complete categories 2>/dev/null)"
+complete -c ynab -n "__fish_seen_subcommand_from move sweep" -l to -x -a "(ynab __complete categories 2>/dev/null)"
+complete -c ynab -l category -x -a "(ynab __complete categories 2>/dev/null)"
+complete -c ynab -l payee -x -a "(ynab __complete payees 2>/dev/null)"
+complete -c ynab -n "__fish_seen_subcommand_from completion" -a "bash zsh fish"
+complete -c ynab -n "__fish_seen_subcommand_from balance" -a "(ynab __complete accounts 2>/dev/null)"
+`
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCompleteCmd(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/payees"):
+			w.Write([]byte(`{"data":{"payees":[
+				{"id":"p1","name":"Whole Foods","deleted":false},
+				{"id":"p2","name":"Old Diner","deleted":true},
+				{"id":"p3","name":"Transfer : Savings","transfer_account_id":"a2","deleted":false},
+				{"id":"p4","name":"Amazon","deleted":false},
+				{"id":"p5","name":"Amazon","deleted":false}
+			]}}`))
+		case strings.HasSuffix(r.URL.Path, "/categories"):
+			w.Write([]byte(`{"data":{"category_groups":[
+				{"id":"g0","name":"Internal Master Category","hidden":false,"deleted":false,"categories":[
+					{"id":"c0","name":"Inflow: Ready to Assign","hidden":false,"deleted":false}]},
+				{"id":"g1","name":"Bills","hidden":false,"deleted":false,"categories":[
+					{"id":"c1","name":"Rent","hidden":false,"deleted":false},
+					{"id":"c2","name":"Old Gym","hidden":true,"deleted":false},
+					{"id":"c3","name":"Electric","hidden":false,"deleted":false}]},
+				{"id":"g2","name":"Archive","hidden":true,"deleted":false,"categories":[
+					{"id":"c4","name":"Vacation 2019","hidden":false,"deleted":false}]}
+			]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := createTestClient(t, server)
+	client.SetDefaultBudgetID("budget-1")
+
+	tests := []struct {
+		kind string
+		want []string
+	}{
+		{"payees", []string{"Amazon", "Whole Foods"}},
+		{"categories", []string{"Electric", "Rent"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			out, err := captureStdout(t, func() error { return CompleteCmd(client, tt.kind) })
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Split(strings.TrimSpace(out), "\n"); !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if err := CompleteCmd(client, "budgets"); err == nil {
+		t.Error("unknown kind accepted")
+	}
+}
+
+func TestCompletionScript(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		script, err := completionScript(shell)
+		if err != nil {
+			t.Fatalf("%s: %v", shell, err)
+		}
+		if strings.Contains(script, "@COMMANDS@") || !strings.Contains(script, "add-account") {
+			t.Errorf("%s: command list not filled in", shell)
+		}
+		if !strings.Contains(script, "ynab __complete payees") && !strings.Contains(script, `ynab __complete "$1"`) {
+			t.Errorf("%s: script doesn't call ynab __complete", shell)
+		}
+	}
+	if _, err := completionScript("powershell"); err == nil {
+		t.Error("unsupported shell accepted")
+	}
+}
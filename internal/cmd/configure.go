@@ -98,10 +98,13 @@ func ConfigureCmd() error {
 		fmt.Printf("Selected: %s\n", budgets[idx].Name)
 	}
 
+	defaultAccount := promptDefaultAccount(reader, token, budgetID)
+
 	// Save configuration
 	cfg := &config.Config{
 		AccessToken:     token,
 		DefaultBudgetID: budgetID,
+		DefaultAccount:  defaultAccount,
 		APIBaseURL:      "https://api.youneedabudget.com/v1",
 	}
 
@@ -122,8 +125,61 @@ func ConfigureCmd() error {
 	return nil
 }
 
-// ConfigureShowCmd prints the current configuration (with token masked).
-func ConfigureShowCmd(jsonOutput bool) error {
+// promptDefaultAccount offers the budget's open on-budget accounts as the
+// default for add and returns the one picked, or "" to keep using the first
+// account. It is optional, so a failure to list accounts only skips it.
+func promptDefaultAccount(reader *bufio.Reader, token, budgetID string) string {
+	client, err := api.NewClient(token)
+	if err != nil {
+		return ""
+	}
+	accounts, err := client.GetAccounts(budgetID)
+	if err != nil {
+		fmt.Printf("\nCould not list accounts (%v); skipping the default account.\n", err)
+		return ""
+	}
+	var open []*api.Account
+	for _, a := range accounts {
+		if isOpenAccount(a) {
+			open = append(open, a)
+		}
+	}
+	if len(open) == 0 {
+		return ""
+	}
+
+	fmt.Println()
+	fmt.Println("Default account for 'ynab add' (optional):")
+	for i, a := range open {
+		fmt.Printf("  %d. %s\n", i+1, a.Name)
+	}
+	fmt.Println()
+	fmt.Print("Select account number, or Enter to skip: ")
+	selection, _ := reader.ReadString('\n')
+	selection = strings.TrimSpace(selection)
+	if selection == "" {
+		return ""
+	}
+	n, err := strconv.Atoi(selection)
+	if err != nil || n < 1 || n > len(open) {
+		fmt.Printf("Invalid selection %q; skipping the default account.\n", selection)
+		return ""
+	}
+	fmt.Printf("Selected: %s\n", open[n-1].Name)
+	return open[n-1].Name
+}
+
+// ProfileInfo describes a configured profile in configure show output.
+type ProfileInfo struct {
+	Name            string `json:"name"`
+	AccessToken     string `json:"access_token,omitempty"` // masked
+	DefaultBudgetID string `json:"default_budget_id,omitempty"`
+	Active          bool   `json:"active"`
+}
+
+// ConfigureShowCmd prints the current configuration and all profiles (with
+// tokens masked). activeProfile marks the profile selected by --profile.
+func ConfigureShowCmd(activeProfile string, jsonOutput bool) error {
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
@@ -135,22 +191,28 @@ func ConfigureShowCmd(jsonOutput bool) error {
 		return nil
 	}
 
-	// Mask token for display
-	maskedToken := ""
-	if cfg.AccessToken != "" {
-		if len(cfg.AccessToken) > 8 {
-			maskedToken = cfg.AccessToken[:4] + "..." + cfg.AccessToken[len(cfg.AccessToken)-4:]
-		} else {
-			maskedToken = "****"
-		}
+	maskedToken := maskToken(cfg.AccessToken)
+
+	profiles := make([]ProfileInfo, 0, len(cfg.Profiles))
+	for _, name := range cfg.ProfileNames() {
+		p := cfg.Profiles[name]
+		profiles = append(profiles, ProfileInfo{
+			Name:            name,
+			AccessToken:     maskToken(p.AccessToken),
+			DefaultBudgetID: p.DefaultBudgetID,
+			Active:          name == activeProfile,
+		})
 	}
 
 	if jsonOutput {
-		output := map[string]string{
+		output := map[string]interface{}{
 			"config_path":       config.Path(),
 			"access_token":      maskedToken,
 			"default_budget_id": cfg.DefaultBudgetID,
+			"default_account":   cfg.DefaultAccount,
+			"default_since":     cfg.DefaultSince,
 			"api_base_url":      cfg.APIBaseURL,
+			"profiles":          profiles,
 		}
 		encoder := json.NewEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
@@ -160,6 +222,44 @@ func ConfigureShowCmd(jsonOutput bool) error {
 	fmt.Printf("Config file: %s\n", config.Path())
 	fmt.Printf("Access token: %s\n", maskedToken)
 	fmt.Printf("Default budget: %s\n", cfg.DefaultBudgetID)
+	if cfg.DefaultAccount != "" {
+		fmt.Printf("Default account: %s\n", cfg.DefaultAccount)
+	}
+	if cfg.DefaultSince != "" {
+		fmt.Printf("Default since: %s\n", cfg.DefaultSince)
+	}
 	fmt.Printf("API base URL: %s\n", cfg.APIBaseURL)
+
+	if len(profiles) > 0 {
+		fmt.Println()
+		fmt.Println("Profiles:")
+		for _, p := range profiles {
+			marker := " "
+			if p.Active {
+				marker = "*"
+			}
+			token, budget := p.AccessToken, p.DefaultBudgetID
+			if token == "" {
+				token = "(default)"
+			}
+			if budget == "" {
+				budget = "(default)"
+			}
+			fmt.Printf(" %s %s: token %s, budget %s\n", marker, p.Name, token, budget)
+		}
+	}
 	return nil
 }
+
+// maskToken shortens a token for display, keeping the first and last four
+// characters of long tokens.
+func maskToken(token string) string {
+	switch {
+	case token == "":
+		return ""
+	case len(token) > 8:
+		return token[:4] + "..." + token[len(token)-4:]
+	default:
+		return "****"
+	}
+}
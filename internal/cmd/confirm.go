@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// confirmInput is where confirm reads the reply. Tests replace it.
+var confirmInput io.Reader = os.Stdin
+
+// confirm asks a yes/no question before a destructive operation and reports
+// whether the user answered yes. The prompt goes to stderr so JSON on stdout
+// stays clean. Anything but "y" or "yes", including an empty line or closed
+// stdin, is no.
+func confirm(question string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	reply, _ := bufio.NewReader(confirmInput).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(reply)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
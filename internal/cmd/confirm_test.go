@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	oldInput, oldStderr := confirmInput, os.Stderr
+	defer func() { confirmInput, os.Stderr = oldInput, oldStderr }()
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	os.Stderr = devNull
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"  y  \n", true},
+		{"n\n", false},
+		{"\n", false}, // empty defaults to no
+		{"", false},   // closed stdin
+		{"yep\n", false},
+	}
+	for _, tt := range tests {
+		confirmInput = strings.NewReader(tt.input)
+		if got := confirm("Delete?"); got != tt.want {
+			t.Errorf("confirm with input %q = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
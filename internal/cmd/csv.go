@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+// csvOutput makes the commands that support it write CSV instead of a table.
+// Set once at startup from --csv or --format csv.
+var csvOutput bool
+
+// SetCSVOutput turns CSV output on or off.
+func SetCSVOutput(enabled bool) {
+	csvOutput = enabled
+}
+
+// csvAmount formats milliunits as a plain decimal for CSV: no currency
+// symbol or thousands separators, and a third decimal only when the amount
+// has sub-cent milliunits.
+func csvAmount(milliunits int64) string {
+	digits := 2
+	if milliunits%10 != 0 {
+		digits = 3
+	}
+	return strconv.FormatFloat(transform.MilliunitsToDollars(milliunits), 'f', digits, 64)
+}
+
+// writeCSV writes a header and records, flushing once at the end. The
+// header is written even when there are no records.
+func writeCSV(w io.Writer, header []string, records [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	if err := cw.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// writeTransactionsCSV writes transaction rows as CSV. Projected scheduled
+// rows have no cleared state and are marked "scheduled" instead.
+func writeTransactionsCSV(w io.Writer, rows []transactionRow) error {
+	records := make([][]string, 0, len(rows))
+	for _, t := range rows {
+		cleared := t.Cleared
+		if t.Scheduled {
+			cleared = "scheduled"
+		}
+		records = append(records, []string{
+			t.Date, t.PayeeName, t.CategoryName, t.AccountName,
+			csvAmount(t.Amount), cleared, t.Memo,
+		})
+	}
+	return writeCSV(w, []string{"date", "payee", "category", "account", "amount", "cleared", "memo"}, records)
+}
+
+// writeBalanceCSV writes account balances as CSV.
+func writeBalanceCSV(w io.Writer, accounts []*api.Account) error {
+	records := make([][]string, 0, len(accounts))
+	for _, a := range accounts {
+		records = append(records, []string{
+			a.Name, a.Type,
+			strconv.FormatBool(a.OnBudget), strconv.FormatBool(a.Closed),
+			csvAmount(a.Balance), csvAmount(a.ClearedBalance), csvAmount(a.UnclearedBalance),
+		})
+	}
+	return writeCSV(w, []string{"account", "type", "on_budget", "closed", "balance", "cleared", "uncleared"}, records)
+}
+
+// writeCategoriesCSV writes one row per visible category with its group and
+// current-month amounts, each as it is visited. Used by both budget and
+// categories.
+func writeCategoriesCSV(w io.Writer, categoryGroups []*api.CategoryGroup, filter string, limit int) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"group", "category", "category_id", "budgeted", "activity", "balance"}); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	remaining := limit
+	if limit <= 0 {
+		remaining = -1
+	}
+	for _, group := range categoryGroups {
+		if remaining == 0 {
+			break
+		}
+		visible := visibleCategories(group, filter, remaining)
+		for _, c := range visible {
+			err := cw.Write([]string{
+				group.Name, c.Name, c.ID,
+				csvAmount(c.Budgeted), csvAmount(c.Activity), csvAmount(c.Balance),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to write CSV: %w", err)
+			}
+		}
+		remaining -= len(visible)
+	}
+	return flushCSV(cw)
+}
+
+// writePayeesCSV writes payee rows as CSV, each as it is visited.
+func writePayeesCSV(w io.Writer, payees []*api.Payee, opts PayeesOptions) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"name", "id"}); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	_, err := eachPayee(payees, opts, func(p *api.Payee) error {
+		if err := cw.Write([]string{p.Name, p.ID}); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flushCSV(cw)
+}
+
+// flushCSV flushes cw and reports any error from writing.
+func flushCSV(cw *csv.Writer) error {
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestWriteTransactionsCSV(t *testing.T) {
+	rows := []transactionRow{
+		{Transaction: &api.Transaction{
+			Date: "2025-01-02", PayeeName: "Grocer, Inc.", CategoryName: "Groceries",
+			AccountName: "Checking", Amount: -1234560, Cleared: "cleared", Memo: `weekly "big" shop`,
+		}},
+		{Transaction: &api.Transaction{
+			Date: "2025-01-05", PayeeName: "Gym", CategoryName: "Fitness",
+			AccountName: "Visa", Amount: -40000,
+		}, Scheduled: true},
+	}
+
+	var buf bytes.Buffer
+	if err := writeTransactionsCSV(&buf, rows); err != nil {
+		t.Fatalf("writeTransactionsCSV: %v", err)
+	}
+
+	want := "date,payee,category,account,amount,cleared,memo\n" +
+		`2025-01-02,"Grocer, Inc.",Groceries,Checking,-1234.56,cleared,"weekly ""big"" shop"` + "\n" +
+		"2025-01-05,Gym,Fitness,Visa,-40.00,scheduled,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteTransactionsCSV_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeTransactionsCSV(&buf, nil); err != nil {
+		t.Fatalf("writeTransactionsCSV: %v", err)
+	}
+	if got := buf.String(); got != "date,payee,category,account,amount,cleared,memo\n" {
+		t.Errorf("empty output should still have a header, got %q", got)
+	}
+}
+
+func TestCSVAmount(t *testing.T) {
+	tests := []struct {
+		milliunits int64
+		want       string
+	}{
+		{12500, "12.50"},
+		{-50000, "-50.00"},
+		{123456780, "123456.78"},
+		{1505, "1.505"},
+		{0, "0.00"},
+	}
+	for _, tt := range tests {
+		if got := csvAmount(tt.milliunits); got != tt.want {
+			t.Errorf("csvAmount(%d) = %s, want %s", tt.milliunits, got, tt.want)
+		}
+	}
+}
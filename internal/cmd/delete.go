@@ -4,13 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/storage"
 	"github.com/joeyhipolito/ynab-cli/internal/transform"
 )
 
-// DeleteCmd deletes a transaction by ID.
-func DeleteCmd(client *api.Client, transactionID string, jsonOutput bool) error {
+// DeleteCmd deletes a transaction by ID after asking for confirmation,
+// unless yes or force is set. Reconciled transactions are refused unless
+// force is set. With dryRun, the request is printed instead of sent, without
+// asking.
+func DeleteCmd(client *api.Client, transactionID string, yes, force, dryRun, jsonOutput bool) error {
 	budgetID, err := client.GetDefaultBudgetID()
 	if err != nil {
 		return err
@@ -22,13 +27,43 @@ func DeleteCmd(client *api.Client, transactionID string, jsonOutput bool) error
 		return fmt.Errorf("failed to get transaction: %w", err)
 	}
 
+	if err := guardReconciled(existing, "delete", force, jsonOutput); err != nil {
+		return err
+	}
+
+	if dryRun {
+		prepared, err := client.BuildDeleteTransaction(budgetID, transactionID)
+		if err != nil {
+			return err
+		}
+		return printDryRun(budgetID, []*api.PreparedRequest{prepared}, jsonOutput)
+	}
+
+	if !yes && !force {
+		question := fmt.Sprintf("Delete %s %s %s from %s?", existing.Date, existing.PayeeName,
+			transform.FormatCurrency(existing.Amount), existing.AccountName)
+		if !confirm(question) {
+			return fmt.Errorf("delete cancelled; nothing was changed")
+		}
+	}
+
 	deleted, err := client.DeleteTransaction(budgetID, transactionID)
 	if err != nil {
 		return fmt.Errorf("failed to delete transaction: %w", err)
 	}
 
+	// Keep the pre-delete copy so `ynab restore` can undo this
+	if err := storage.AddToTrash(storage.TrashEntry{
+		BudgetID:    budgetID,
+		DeletedAt:   time.Now(),
+		Transaction: existing,
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: deleted, but could not save it for restore: %v\n", err)
+	}
+
 	if jsonOutput {
 		output := TransactionItem{
+			BudgetID:      budgetID,
 			ID:            deleted.ID,
 			Date:          existing.Date,
 			Amount:        existing.Amount,
@@ -36,6 +71,7 @@ func DeleteCmd(client *api.Client, transactionID string, jsonOutput bool) error
 			PayeeName:     existing.PayeeName,
 			CategoryName:  existing.CategoryName,
 			AccountName:   existing.AccountName,
+			AccountID:     existing.AccountID,
 			Memo:          existing.Memo,
 		}
 		encoder := json.NewEncoder(os.Stdout)
@@ -50,6 +86,8 @@ func DeleteCmd(client *api.Client, transactionID string, jsonOutput bool) error
 	fmt.Printf("Payee:    %s\n", existing.PayeeName)
 	fmt.Printf("Category: %s\n", existing.CategoryName)
 	fmt.Printf("Account:  %s\n", existing.AccountName)
+	fmt.Println()
+	fmt.Printf("Undo with: ynab restore %s\n", existing.ID)
 
 	return nil
 }
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
+	"time"
 
 	"github.com/joeyhipolito/ynab-cli/internal/api"
 	"github.com/joeyhipolito/ynab-cli/internal/config"
+	"github.com/joeyhipolito/ynab-cli/internal/storage"
 )
 
 // DoctorCheck represents a single doctor check result.
@@ -20,13 +23,21 @@ type DoctorCheck struct {
 // DoctorOutput represents the JSON output of the doctor command.
 type DoctorOutput struct {
 	Checks  []DoctorCheck `json:"checks"`
+	Fixes   []string      `json:"fixes,omitempty"` // what --fix changed
 	Summary string        `json:"summary"`
 	AllOK   bool          `json:"all_ok"`
 }
 
-// DoctorCmd validates the YNAB CLI installation and configuration.
-func DoctorCmd(jsonOutput bool) error {
+// DoctorCmd validates the YNAB CLI installation and configuration. With a
+// profile, the token and budget checks use that profile's settings.
+//
+// With fix, problems that only need a local change are repaired: config
+// permissions are set to 600, and a missing default budget is set when the
+// token can see exactly one. Token and API problems are only reported.
+// Fixing is idempotent; a second run finds nothing to change.
+func DoctorCmd(profile string, fix, jsonOutput bool) error {
 	var checks []DoctorCheck
+	var fixes []string
 	allOK := true
 
 	// 1. Check binary location
@@ -70,11 +81,27 @@ func DoctorCmd(jsonOutput bool) error {
 				Message: fmt.Sprintf("Cannot read permissions: %v", err),
 			})
 			allOK = false
+		} else if perms != 0600 && fix {
+			if err := config.FixPermissions(); err != nil {
+				checks = append(checks, DoctorCheck{
+					Name:    "Config permissions",
+					Status:  "fail",
+					Message: fmt.Sprintf("%o; chmod 600 failed: %v", perms, err),
+				})
+				allOK = false
+			} else {
+				fixes = append(fixes, fmt.Sprintf("Set %s permissions to 600 (was %o)", configPath, perms))
+				checks = append(checks, DoctorCheck{
+					Name:    "Config permissions",
+					Status:  "ok",
+					Message: fmt.Sprintf("600 (fixed, was %o)", perms),
+				})
+			}
 		} else if perms != 0600 {
 			checks = append(checks, DoctorCheck{
 				Name:    "Config permissions",
 				Status:  "warn",
-				Message: fmt.Sprintf("%o (should be 600). Fix: chmod 600 %s", perms, configPath),
+				Message: fmt.Sprintf("%o (should be 600). Fix: chmod 600 %s, or run 'ynab doctor --fix'", perms, configPath),
 			})
 		} else {
 			checks = append(checks, DoctorCheck{
@@ -94,11 +121,30 @@ func DoctorCmd(jsonOutput bool) error {
 			Message: fmt.Sprintf("Failed to parse config: %v", err),
 		})
 		allOK = false
+	} else if active, err := cfg.Profile(profile); err != nil {
+		checks = append(checks, DoctorCheck{
+			Name:    "Profile",
+			Status:  "fail",
+			Message: err.Error(),
+		})
+		allOK = false
 	} else {
-		token := cfg.AccessToken
+		if profile != "" {
+			checks = append(checks, DoctorCheck{
+				Name:    "Profile",
+				Status:  "ok",
+				Message: profile,
+			})
+		}
+
+		token := active.AccessToken
 		if token == "" {
 			token = os.Getenv("YNAB_ACCESS_TOKEN")
 		}
+		source := ""
+		if path := config.EnvFilePath(); path != "" && token == config.EnvFileValue("YNAB_ACCESS_TOKEN") {
+			source = " from " + path
+		}
 
 		if token == "" {
 			checks = append(checks, DoctorCheck{
@@ -108,19 +154,18 @@ func DoctorCmd(jsonOutput bool) error {
 			})
 			allOK = false
 		} else {
-			// Mask token
-			masked := token[:4] + "..." + token[len(token)-4:]
 			checks = append(checks, DoctorCheck{
 				Name:    "Access token",
 				Status:  "ok",
-				Message: fmt.Sprintf("Present (%s)", masked),
+				Message: fmt.Sprintf("Present (%s)%s", maskToken(token), source),
 			})
 
 			// 5. Check default budget ID
-			budgetID := cfg.DefaultBudgetID
+			budgetID := active.DefaultBudgetID
 			if budgetID == "" {
 				budgetID = os.Getenv("YNAB_DEFAULT_BUDGET_ID")
 			}
+			budgetCheck := len(checks)
 			if budgetID != "" {
 				checks = append(checks, DoctorCheck{
 					Name:    "Default budget",
@@ -145,12 +190,17 @@ func DoctorCmd(jsonOutput bool) error {
 				})
 				allOK = false
 			} else {
+				defer PersistBreaker(client)()
 				budgets, err := client.GetBudgets()
 				if err != nil {
+					message := fmt.Sprintf("Failed: %v", err)
+					if api.IsNetworkError(err) {
+						message = fmt.Sprintf("Cannot reach YNAB; check your connection (%v)", err)
+					}
 					checks = append(checks, DoctorCheck{
 						Name:    "API connection",
 						Status:  "fail",
-						Message: fmt.Sprintf("Failed: %v", err),
+						Message: message,
 					})
 					allOK = false
 				} else {
@@ -160,6 +210,42 @@ func DoctorCmd(jsonOutput bool) error {
 						Message: fmt.Sprintf("Success (%d budget(s) found)", len(budgets)),
 					})
 
+					// The only budget there is can safely become the default
+					if budgetID == "" && fix && len(budgets) == 1 && config.Exists() {
+						if err := setDefaultBudget(cfg, profile, budgets[0].ID); err != nil {
+							checks[budgetCheck] = DoctorCheck{
+								Name:    "Default budget",
+								Status:  "fail",
+								Message: fmt.Sprintf("Cannot save %s: %v", budgets[0].ID, err),
+							}
+							allOK = false
+						} else {
+							budgetID = budgets[0].ID
+							fixes = append(fixes, fmt.Sprintf("Set default budget to %s (%s)", budgets[0].Name, budgetID))
+							checks[budgetCheck] = DoctorCheck{
+								Name:    "Default budget",
+								Status:  "ok",
+								Message: fmt.Sprintf("%s (fixed)", budgetID),
+							}
+						}
+					}
+
+					// Confirm who the token authenticates as
+					if user, err := client.GetUser(); err != nil {
+						checks = append(checks, DoctorCheck{
+							Name:    "User",
+							Status:  "fail",
+							Message: fmt.Sprintf("Cannot read user: %v", err),
+						})
+						allOK = false
+					} else {
+						checks = append(checks, DoctorCheck{
+							Name:    "User",
+							Status:  "ok",
+							Message: user.ID,
+						})
+					}
+
 					// 7. Verify budget access if ID is set
 					if budgetID != "" {
 						found := false
@@ -183,6 +269,39 @@ func DoctorCmd(jsonOutput bool) error {
 							allOK = false
 						}
 					}
+
+					// 8. Verify the default account still resolves
+					if cfg.DefaultAccount != "" {
+						if budgetID != "" {
+							client.SetDefaultBudgetID(budgetID)
+						}
+						check := DoctorCheck{Name: "Default account"}
+						accountBudgetID, err := client.GetDefaultBudgetID()
+						var accounts []*api.Account
+						if err == nil {
+							accounts, err = client.GetAccounts(accountBudgetID)
+						}
+						if err != nil {
+							check.Status = "fail"
+							check.Message = fmt.Sprintf("Cannot list accounts: %v", err)
+						} else {
+							check = checkDefaultAccount(accounts, cfg.DefaultAccount)
+						}
+						if check.Status == "fail" {
+							allOK = false
+						}
+						checks = append(checks, check)
+					}
+				}
+
+				// 9. Report the circuit breaker, as left by earlier commands
+				// and the requests above
+				if b := client.CircuitBreaker(); b != nil {
+					check := breakerCheck(b.State())
+					if check.Status == "fail" {
+						allOK = false
+					}
+					checks = append(checks, check)
 				}
 			}
 		}
@@ -204,6 +323,7 @@ func DoctorCmd(jsonOutput bool) error {
 	if jsonOutput {
 		output := DoctorOutput{
 			Checks:  checks,
+			Fixes:   fixes,
 			Summary: summary,
 			AllOK:   allOK,
 		}
@@ -230,6 +350,17 @@ func DoctorCmd(jsonOutput bool) error {
 		fmt.Printf("  [%4s] %-20s %s\n", icon, c.Name+":", c.Message)
 	}
 
+	if len(fixes) > 0 {
+		fmt.Println()
+		fmt.Println("Fixed:")
+		for _, f := range fixes {
+			fmt.Printf("  - %s\n", f)
+		}
+	} else if fix {
+		fmt.Println()
+		fmt.Println("Nothing to fix.")
+	}
+
 	fmt.Println()
 	if allOK {
 		fmt.Println(summary)
@@ -240,3 +371,97 @@ func DoctorCmd(jsonOutput bool) error {
 
 	return nil
 }
+
+// setDefaultBudget saves budgetID as the default budget of profile (the
+// top-level settings if empty).
+func setDefaultBudget(cfg *config.Config, profile, budgetID string) error {
+	if profile != "" {
+		cfg.Profiles[profile].DefaultBudgetID = budgetID
+	} else {
+		cfg.DefaultBudgetID = budgetID
+	}
+	return config.Save(cfg)
+}
+
+// PersistBreaker restores client's circuit breaker from the state earlier
+// commands saved and returns a func that saves it back, so an outage one
+// command runs into makes the next fail fast and shows up in doctor.
+func PersistBreaker(client *api.Client) (save func()) {
+	b := client.CircuitBreaker()
+	if b == nil {
+		return func() {}
+	}
+	loaded := storage.LoadBreaker()
+	b.Restore(loaded)
+	return func() {
+		if r := b.Record(); !r.IsZero() || !loaded.IsZero() {
+			storage.SaveBreaker(r)
+		}
+	}
+}
+
+// breakerCheck reports the API client's circuit breaker.
+func breakerCheck(state api.BreakerState) DoctorCheck {
+	check := DoctorCheck{Name: "Circuit breaker", Status: "ok", Message: "Closed"}
+	switch {
+	case state.Open:
+		check.Status = "fail"
+		check.Message = fmt.Sprintf("Open after %d failed requests in a row; requests resume in %s",
+			state.Failures, state.RetryIn.Round(time.Second))
+	case state.Failures > 0:
+		check.Status = "warn"
+		check.Message = fmt.Sprintf("Closed, but the last %d request attempt(s) failed", state.Failures)
+	}
+	return check
+}
+
+// checkDefaultAccount verifies that the configured default account still
+// names an open, on-budget account. Accounts get renamed and closed, and a
+// stale default would otherwise surface as a confusing error on add.
+func checkDefaultAccount(accounts []*api.Account, name string) DoctorCheck {
+	check := DoctorCheck{Name: "Default account"}
+
+	var open []string
+	for _, a := range accounts {
+		if isOpenAccount(a) {
+			open = append(open, a.Name)
+		}
+	}
+	hint := fmt.Sprintf("Open accounts: %s. Update default_account in %s", strings.Join(open, ", "), config.Path())
+	if len(open) == 0 {
+		hint = "No open on-budget accounts found"
+	}
+
+	id, err := findAccountID(accounts, name)
+	if err != nil {
+		check.Status = "fail"
+		check.Message = fmt.Sprintf("'%s' is ambiguous. %s", name, hint)
+		return check
+	}
+	if id == "" {
+		check.Status = "fail"
+		check.Message = fmt.Sprintf("'%s' not found. %s", name, hint)
+		return check
+	}
+
+	var account *api.Account
+	for _, a := range accounts {
+		if a.ID == id {
+			account = a
+			break
+		}
+	}
+
+	switch {
+	case !isOpenAccount(account):
+		check.Status = "fail"
+		check.Message = fmt.Sprintf("'%s' is closed or off-budget. %s", account.Name, hint)
+	case !strings.EqualFold(account.Name, name):
+		check.Status = "warn"
+		check.Message = fmt.Sprintf("'%s' only partially matches '%s'. %s", name, account.Name, hint)
+	default:
+		check.Status = "ok"
+		check.Message = account.Name
+	}
+	return check
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/config"
+)
+
+// TestCheckDefaultAccount tests validation of the default_account setting.
+func TestCheckDefaultAccount(t *testing.T) {
+	accounts := []*api.Account{
+		{ID: "a1", Name: "Checking", OnBudget: true},
+		{ID: "a2", Name: "Old Savings", OnBudget: true, Closed: true},
+		{ID: "a3", Name: "Brokerage", OnBudget: false},
+		{ID: "a4", Name: "Joint Checking", OnBudget: true},
+	}
+
+	tests := []struct {
+		name     string
+		setting  string
+		status   string
+		contains string
+	}{
+		{"exact match", "checking", "ok", "Checking"},
+		{"partial match", "joint", "warn", "Joint Checking"},
+		{"closed account", "Old Savings", "fail", "closed"},
+		{"off-budget account", "Brokerage", "fail", "off-budget"},
+		{"renamed account", "Everyday", "fail", "Open accounts: Checking, Joint Checking"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := checkDefaultAccount(accounts, tt.setting)
+			if check.Status != tt.status {
+				t.Errorf("status = %s, want %s (%s)", check.Status, tt.status, check.Message)
+			}
+			if !strings.Contains(check.Message, tt.contains) {
+				t.Errorf("message %q does not contain %q", check.Message, tt.contains)
+			}
+		})
+	}
+}
+
+// TestBreakerCheck tests how doctor reports the circuit breaker.
+func TestBreakerCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    api.BreakerState
+		status   string
+		contains string
+	}{
+		{"closed", api.BreakerState{}, "ok", "Closed"},
+		{"recent failures", api.BreakerState{Failures: 2}, "warn", "last 2 request attempt(s) failed"},
+		{"open", api.BreakerState{Open: true, Failures: 5, RetryIn: 12400 * time.Millisecond}, "fail", "resume in 12s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := breakerCheck(tt.state)
+			if check.Status != tt.status {
+				t.Errorf("status = %s, want %s (%s)", check.Status, tt.status, check.Message)
+			}
+			if !strings.Contains(check.Message, tt.contains) {
+				t.Errorf("message %q does not contain %q", check.Message, tt.contains)
+			}
+		})
+	}
+}
+
+func TestDoctorFixPermissions(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("YNAB_ACCESS_TOKEN", "")
+	t.Setenv("YNAB_DEFAULT_BUDGET_ID", "")
+
+	// No token, so the API checks are skipped and nothing needs the network
+	if err := config.Save(&config.Config{DefaultBudgetID: "budget-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(config.Path(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func() DoctorOutput {
+		t.Helper()
+		out, err := captureStdout(t, func() error { return DoctorCmd("", true, true) })
+		if err != nil {
+			t.Fatal(err)
+		}
+		var output DoctorOutput
+		if err := json.Unmarshal([]byte(out), &output); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, out)
+		}
+		return output
+	}
+
+	first := run()
+	if len(first.Fixes) != 1 || !strings.Contains(first.Fixes[0], "was 644") {
+		t.Errorf("fixes = %v, want the permissions fix", first.Fixes)
+	}
+	if perms, err := config.Permissions(); err != nil || perms != 0600 {
+		t.Errorf("permissions after fix = %o, %v", perms, err)
+	}
+
+	// Idempotent: nothing left to change
+	if second := run(); len(second.Fixes) != 0 {
+		t.Errorf("second run fixed %v", second.Fixes)
+	}
+}
+
+func TestSetDefaultBudget(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := &config.Config{
+		AccessToken: "token",
+		Profiles:    map[string]*config.Profile{"business": {AccessToken: "biz-token"}},
+	}
+	if err := setDefaultBudget(cfg, "business", "budget-biz"); err != nil {
+		t.Fatal(err)
+	}
+	if err := setDefaultBudget(cfg, "", "budget-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.DefaultBudgetID != "budget-1" || loaded.Profiles["business"].DefaultBudgetID != "budget-biz" {
+		t.Errorf("saved budgets: top-level %q, business %q",
+			loaded.DefaultBudgetID, loaded.Profiles["business"].DefaultBudgetID)
+	}
+	if loaded.AccessToken != "token" {
+		t.Errorf("access token changed to %q", loaded.AccessToken)
+	}
+}
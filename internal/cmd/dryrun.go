@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+// DryRunOutput represents the JSON output of add, edit, move and delete
+// under --dry-run.
+type DryRunOutput struct {
+	BudgetID string                 `json:"budget_id"`
+	DryRun   bool                   `json:"dry_run"`
+	Requests []*api.PreparedRequest `json:"requests"`
+}
+
+// printDryRun shows the requests a command would send, in order, instead
+// of sending them.
+func printDryRun(budgetID string, requests []*api.PreparedRequest, jsonOutput bool) error {
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(DryRunOutput{BudgetID: budgetID, DryRun: true, Requests: requests})
+	}
+
+	fmt.Println("Dry run: nothing was sent. Would send:")
+	for _, r := range requests {
+		fmt.Printf("\n%s %s\n", r.Method, r.Endpoint)
+		if len(r.Body) == 0 {
+			continue
+		}
+		var body bytes.Buffer
+		if err := json.Indent(&body, r.Body, "", "  "); err != nil {
+			return fmt.Errorf("failed to format request body: %w", err)
+		}
+		fmt.Println(body.String())
+	}
+	return nil
+}
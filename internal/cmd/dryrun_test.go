@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeleteDryRunSendsNothing(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("dry run sent %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"transaction":{"id":"txn-1","date":"2025-03-01","amount":-4500,"payee_name":"Cafe","cleared":"cleared"}}}`))
+	}))
+	defer server.Close()
+	client := createTestClient(t, server)
+	client.SetDefaultBudgetID("budget-1")
+
+	// No --yes: a dry run doesn't ask, so stdin is never read
+	out, err := captureStdout(t, func() error { return DeleteCmd(client, "txn-1", false, false, true, true) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output DryRunOutput
+	if err := json.Unmarshal([]byte(out), &output); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if !output.DryRun || len(output.Requests) != 1 {
+		t.Fatalf("output = %+v", output)
+	}
+	if r := output.Requests[0]; r.Method != "DELETE" || r.Endpoint != "/budgets/budget-1/transactions/txn-1" {
+		t.Errorf("request = %s %s", r.Method, r.Endpoint)
+	}
+}
+
+func TestEditDryRunRawIDs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/budgets/budget-1/transactions/txn-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"transaction":{"id":"txn-1","date":"2025-03-01","amount":-4500,"payee_name":"Cafe","account_id":"acc-1","cleared":"cleared"}}}`))
+	}))
+	defer server.Close()
+	client := createTestClient(t, server)
+	client.SetDefaultBudgetID("budget-1")
+
+	out, err := captureStdout(t, func() error {
+		return EditCmd(client, "txn-1", nil, "", "", "acc-2", "cat-9", "", "", "", "", "", false, true, true)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output DryRunOutput
+	if err := json.Unmarshal([]byte(out), &output); err != nil || len(output.Requests) != 1 {
+		t.Fatalf("output %q: %v", out, err)
+	}
+	var body struct {
+		Transaction map[string]any `json:"transaction"`
+	}
+	if err := json.Unmarshal(output.Requests[0].Body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Transaction["account_id"] != "acc-2" || body.Transaction["category_id"] != "cat-9" {
+		t.Errorf("body = %v", body.Transaction)
+	}
+
+	if err := EditCmd(client, "txn-1", nil, "", "Dining", "", "cat-9", "", "", "", "", "", false, true, true); err == nil {
+		t.Error("expected an error for --category with --category-id")
+	}
+}
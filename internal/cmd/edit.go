@@ -4,13 +4,44 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/storage"
 	"github.com/joeyhipolito/ynab-cli/internal/transform"
 )
 
-// EditCmd updates an existing transaction.
-func EditCmd(client *api.Client, transactionID string, amount *int64, payee, category, memo, date string, cleared bool, jsonOutput bool) error {
+// memoSeparator joins an appended note to an existing memo.
+const memoSeparator = "; "
+
+// EditCmd updates an existing transaction. memo replaces the memo, while
+// memoAppend adds to the end of the current one; set at most one. accountID
+// moves the transaction to that account and categoryID sets its category,
+// both sent as given without looking them up. cleared sets the cleared
+// status to one of api.ClearedStatuses, or leaves it alone when empty.
+// Reconciled transactions are refused unless force is set. With dryRun, the
+// update is printed instead of sent.
+func EditCmd(client *api.Client, transactionID string, amount *int64, payee, category, accountID, categoryID, memo, memoAppend, date, flag, cleared string, force, dryRun, jsonOutput bool) error {
+	if cleared != "" && !slices.Contains(api.ClearedStatuses, cleared) {
+		return fmt.Errorf("invalid cleared status: %s (expected %s)", cleared, strings.Join(api.ClearedStatuses, ", "))
+	}
+	if memo != "" && memoAppend != "" {
+		return fmt.Errorf("choose one of --memo and --memo-append")
+	}
+	if category != "" && categoryID != "" {
+		return fmt.Errorf("choose one of --category and --category-id")
+	}
+
+	// flag is empty to leave the flag alone, or "none" to clear it
+	var flagColor string
+	if flag != "" {
+		var err error
+		if flagColor, err = parseFlagColor(flag); err != nil {
+			return err
+		}
+	}
+
 	budgetID, err := client.GetDefaultBudgetID()
 	if err != nil {
 		return err
@@ -22,6 +53,10 @@ func EditCmd(client *api.Client, transactionID string, amount *int64, payee, cat
 		return fmt.Errorf("failed to get transaction: %w", err)
 	}
 
+	if err := guardReconciled(existing, "edit", force, jsonOutput); err != nil {
+		return err
+	}
+
 	// Build update map with only changed fields
 	updates := map[string]interface{}{
 		"account_id": existing.AccountID,
@@ -30,11 +65,17 @@ func EditCmd(client *api.Client, transactionID string, amount *int64, payee, cat
 		"approved":   true,
 	}
 
+	if accountID != "" {
+		updates["account_id"] = accountID
+	}
+	if categoryID != "" {
+		updates["category_id"] = categoryID
+	}
 	if amount != nil {
 		updates["amount"] = *amount
 	}
 	if payee != "" {
-		updates["payee_name"] = payee
+		updates["payee_name"] = expandAlias(payee)
 	}
 	if date != "" {
 		updates["date"] = date
@@ -42,8 +83,18 @@ func EditCmd(client *api.Client, transactionID string, amount *int64, payee, cat
 	if memo != "" {
 		updates["memo"] = memo
 	}
-	if cleared {
-		updates["cleared"] = "cleared"
+	if memoAppend != "" {
+		updates["memo"] = appendMemo(existing.Memo, memoAppend)
+	}
+	if cleared != "" {
+		updates["cleared"] = cleared
+	}
+	if flag != "" {
+		if flagColor == "" {
+			updates["flag_color"] = nil // sent as null, which clears the flag
+		} else {
+			updates["flag_color"] = flagColor
+		}
 	}
 
 	// Resolve category if provided
@@ -59,25 +110,29 @@ func EditCmd(client *api.Client, transactionID string, amount *int64, payee, cat
 		updates["category_id"] = catID
 	}
 
+	if dryRun {
+		prepared, err := client.BuildUpdateTransaction(budgetID, transactionID, updates)
+		if err != nil {
+			return err
+		}
+		return printDryRun(budgetID, []*api.PreparedRequest{prepared}, jsonOutput)
+	}
+
 	// Perform update
 	updated, err := client.UpdateTransaction(budgetID, transactionID, updates)
 	if err != nil {
 		return fmt.Errorf("failed to update transaction: %w", err)
 	}
+	recordAction(storage.Action{
+		BudgetID: budgetID,
+		Kind:     storage.KindEdit,
+		Summary:  fmt.Sprintf("edit %s %s %s", existing.Date, existing.PayeeName, transform.FormatCurrency(existing.Amount)),
+		Before:   existing,
+	})
 
 	if jsonOutput {
-		output := TransactionItem{
-			ID:            updated.ID,
-			Date:          updated.Date,
-			Amount:        updated.Amount,
-			AmountDisplay: transform.FormatCurrency(updated.Amount),
-			PayeeName:     updated.PayeeName,
-			CategoryName:  updated.CategoryName,
-			AccountName:   updated.AccountName,
-			Memo:          updated.Memo,
-			Cleared:       updated.Cleared,
-			Approved:      updated.Approved,
-		}
+		output := newTransactionItem(updated)
+		output.BudgetID = budgetID
 		encoder := json.NewEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		return encoder.Encode(output)
@@ -93,7 +148,27 @@ func EditCmd(client *api.Client, transactionID string, amount *int64, payee, cat
 	if updated.Memo != "" {
 		fmt.Printf("Memo:     %s\n", updated.Memo)
 	}
-	fmt.Printf("Cleared:  %s\n", updated.Cleared)
+	if updated.Cleared != existing.Cleared {
+		fmt.Printf("Cleared:  %s (was %s)\n", updated.Cleared, existing.Cleared)
+	} else {
+		fmt.Printf("Cleared:  %s\n", updated.Cleared)
+	}
+	if updated.FlagColor != "" {
+		fmt.Printf("Flag:     %s\n", updated.FlagColor)
+	}
 
 	return nil
 }
+
+// appendMemo adds note to the end of memo, separated by memoSeparator. An
+// empty memo becomes just the note.
+func appendMemo(memo, note string) string {
+	memo, note = strings.TrimSpace(memo), strings.TrimSpace(note)
+	switch {
+	case memo == "":
+		return note
+	case note == "":
+		return memo
+	}
+	return memo + memoSeparator + note
+}
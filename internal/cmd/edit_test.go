@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAppendMemo(t *testing.T) {
+	tests := []struct {
+		memo, note, want string
+	}{
+		{"", "reimbursed", "reimbursed"},
+		{"  ", "reimbursed", "reimbursed"},
+		{"Dinner with Sam", "reimbursed", "Dinner with Sam; reimbursed"},
+		{"Dinner with Sam ", " reimbursed ", "Dinner with Sam; reimbursed"},
+		{"Dinner with Sam", "  ", "Dinner with Sam"},
+	}
+	for _, tt := range tests {
+		if got := appendMemo(tt.memo, tt.note); got != tt.want {
+			t.Errorf("appendMemo(%q, %q) = %q, want %q", tt.memo, tt.note, got, tt.want)
+		}
+	}
+}
+
+func TestEditCmd_ClearedStatus(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var sent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		cleared := "cleared"
+		if r.Method == http.MethodPut {
+			var body struct {
+				Transaction map[string]any `json:"transaction"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			sent, _ = body.Transaction["cleared"].(string)
+			cleared = sent
+		}
+		w.Write([]byte(`{"data":{"transaction":{"id":"txn-1","date":"2025-03-01","amount":-4500,"payee_name":"Cafe","account_id":"acc-1","cleared":"` + cleared + `"}}}`))
+	}))
+	defer server.Close()
+	client := createTestClient(t, server)
+	client.SetDefaultBudgetID("budget-1")
+
+	out, err := captureStdout(t, func() error {
+		return EditCmd(client, "txn-1", nil, "", "", "", "", "", "", "", "", "uncleared", false, false, false)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != "uncleared" {
+		t.Errorf("sent cleared = %q, want uncleared", sent)
+	}
+	if !strings.Contains(out, "Cleared:  uncleared (was cleared)") {
+		t.Errorf("output does not confirm the new status:\n%s", out)
+	}
+
+	err = EditCmd(client, "txn-1", nil, "", "", "", "", "", "", "", "", "pending", false, false, false)
+	if err == nil || !strings.Contains(err.Error(), "cleared, uncleared, reconciled") {
+		t.Errorf("expected an error listing the statuses, got %v", err)
+	}
+}
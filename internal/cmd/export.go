@@ -0,0 +1,351 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+// ExportOptions holds the parameters for the export command.
+type ExportOptions struct {
+	Format       string // "json" (the whole budget) or "ledger"
+	Output       string // file to write; "" or "-" for stdout
+	Version      string // CLI version, recorded in JSON backups
+	SinceDate    string // ledger: YYYY-MM-DD, today, Nd, Nw or Nm (default: DefaultSince, then 30d)
+	DefaultSince string // default_since from config
+	Account      string // ledger: only export this account's transactions
+}
+
+// BudgetExport is the file written by export --format json: the full budget
+// as the API returns it, plus enough to tell when and by what it was made.
+type BudgetExport struct {
+	ExportedAt string `json:"exported_at"` // RFC 3339
+	Version    string `json:"version"`
+	BudgetID   string `json:"budget_id"`
+	*api.BudgetDetail
+}
+
+// ledgerPosting is one leg of a double-entry ledger transaction.
+type ledgerPosting struct {
+	Account string
+	Amount  int64 // milliunits
+}
+
+// ledgerEntry is a transaction rendered as balanced postings.
+type ledgerEntry struct {
+	Date     string
+	Cleared  bool
+	Payee    string
+	Memo     string
+	Postings []ledgerPosting
+}
+
+// liabilityAccountTypes are account types exported under Liabilities.
+var liabilityAccountTypes = map[string]bool{
+	"creditCard":     true,
+	"lineOfCredit":   true,
+	"otherLiability": true,
+	"mortgage":       true,
+	"autoLoan":       true,
+	"studentLoan":    true,
+	"personalLoan":   true,
+	"medicalDebt":    true,
+	"otherDebt":      true,
+}
+
+// ExportCmd writes the default budget in the requested format, to a file or
+// to stdout.
+func ExportCmd(client *api.Client, opts ExportOptions) error {
+	if opts.Format != "json" && opts.Format != "ledger" {
+		return fmt.Errorf("unsupported export format: %s (expected json or ledger)", opts.Format)
+	}
+
+	w := io.Writer(os.Stdout)
+	if opts.Output != "" && opts.Output != "-" {
+		// Backups hold the same data the token grants access to
+		f, err := os.OpenFile(opts.Output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", opts.Output, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if opts.Format == "json" {
+		return exportBudget(client, w, opts)
+	}
+	return exportLedger(client, w, opts)
+}
+
+// exportBudget writes the full budget, fetched in one request, as JSON.
+func exportBudget(client *api.Client, w io.Writer, opts ExportOptions) error {
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	detail, err := client.GetBudget(budgetID, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get budget: %w", err)
+	}
+
+	if err := writeBudgetExport(w, budgetID, opts.Version, time.Now(), detail); err != nil {
+		return err
+	}
+
+	if opts.Output != "" && opts.Output != "-" {
+		fmt.Printf("Exported %d accounts, %d payees and %d transactions to %s\n",
+			len(detail.Accounts), len(detail.Payees), len(detail.Transactions), opts.Output)
+	}
+	return nil
+}
+
+// writeBudgetExport encodes detail as an indented BudgetExport.
+func writeBudgetExport(w io.Writer, budgetID, version string, now time.Time, detail *api.BudgetDetail) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(BudgetExport{
+		ExportedAt:   now.UTC().Format(time.RFC3339),
+		Version:      version,
+		BudgetID:     budgetID,
+		BudgetDetail: detail,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
+// exportLedger writes transactions as a plain-text accounting journal.
+func exportLedger(client *api.Client, w io.Writer, opts ExportOptions) error {
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	sinceDate, err := resolveSinceDate(opts.SinceDate, opts.DefaultSince, time.Now())
+	if err != nil {
+		return err
+	}
+
+	accounts, err := client.GetAccounts(budgetID)
+	if err != nil {
+		return fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	var transactions []*api.Transaction
+	if opts.Account != "" {
+		var accountID string
+		accountID, err = findAccountID(accounts, opts.Account)
+		if err != nil {
+			return err
+		}
+		if accountID == "" {
+			return fmt.Errorf("no account found matching '%s'", opts.Account)
+		}
+		transactions, err = client.GetTransactionsByAccount(budgetID, accountID, sinceDate)
+	} else {
+		transactions, err = client.GetTransactions(budgetID, sinceDate)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	// The budget list carries the currency; fall back to bare amounts if the
+	// budget can't be found (e.g. a "last-used" alias)
+	var currency *api.CurrencyFormat
+	if budgets, err := client.GetBudgets(); err == nil {
+		for _, b := range budgets {
+			if b.ID == budgetID {
+				currency = b.CurrencyFormat
+			}
+		}
+	}
+
+	entries := buildLedgerEntries(transactions, accounts)
+	return writeLedger(w, budgetID, sinceDate, currency, entries)
+}
+
+// buildLedgerEntries converts transactions into balanced ledger entries.
+// A transfer appears in both accounts' registers; only one side is exported,
+// as a single account-to-account entry.
+func buildLedgerEntries(transactions []*api.Transaction, accounts []*api.Account) []ledgerEntry {
+	names := make(map[string]string, len(accounts))
+	for _, a := range accounts {
+		names[a.ID] = ledgerAccountName(a)
+	}
+	accountName := func(id, fallback string) string {
+		if name, ok := names[id]; ok {
+			return name
+		}
+		return "Assets:" + ledgerName(fallback)
+	}
+
+	// The counterpart of a transfer made from a split is exported as a leg
+	// of the split, never on its own
+	exported := make(map[string]bool)
+	for _, t := range transactions {
+		for _, sub := range t.Subtransactions {
+			if !sub.Deleted && sub.TransferTransactionID != "" {
+				exported[sub.TransferTransactionID] = true
+			}
+		}
+	}
+
+	var entries []ledgerEntry
+	for _, t := range transactions {
+		if t.Deleted || exported[t.ID] {
+			continue
+		}
+		if t.TransferAccountID != "" && exported[t.TransferTransactionID] {
+			continue
+		}
+		exported[t.ID] = true
+
+		entry := ledgerEntry{
+			Date:    t.Date,
+			Cleared: t.Cleared == "cleared" || t.Cleared == "reconciled",
+			Payee:   t.PayeeName,
+			Memo:    t.Memo,
+			Postings: []ledgerPosting{
+				{Account: accountName(t.AccountID, t.AccountName), Amount: t.Amount},
+			},
+		}
+
+		remaining := -t.Amount
+		switch {
+		case len(t.Subtransactions) > 0:
+			for _, sub := range t.Subtransactions {
+				if sub.Deleted {
+					continue
+				}
+				account := ledgerCategoryAccount(sub.CategoryName, -sub.Amount)
+				if sub.TransferAccountID != "" {
+					account = accountName(sub.TransferAccountID, sub.PayeeName)
+				}
+				entry.Postings = append(entry.Postings, ledgerPosting{Account: account, Amount: -sub.Amount})
+				remaining += sub.Amount
+			}
+			// Splits should always sum to the parent; if YNAB ever disagrees,
+			// book the difference so the entry still balances
+			if remaining != 0 {
+				entry.Postings = append(entry.Postings, ledgerPosting{
+					Account: ledgerCategoryAccount("", remaining),
+					Amount:  remaining,
+				})
+			}
+		case t.TransferAccountID != "":
+			entry.Postings = append(entry.Postings, ledgerPosting{
+				Account: accountName(t.TransferAccountID, t.PayeeName),
+				Amount:  remaining,
+			})
+		default:
+			entry.Postings = append(entry.Postings, ledgerPosting{
+				Account: ledgerCategoryAccount(t.CategoryName, remaining),
+				Amount:  remaining,
+			})
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Date < entries[j].Date
+	})
+	return entries
+}
+
+// writeLedger renders entries in ledger/hledger journal syntax.
+func writeLedger(w io.Writer, budgetID, sinceDate string, currency *api.CurrencyFormat, entries []ledgerEntry) error {
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	digits := 2
+	commodity := ""
+	if currency != nil {
+		digits = currency.DecimalDigits
+		commodity = currency.ISOCode
+	}
+
+	fmt.Fprintf(bw, "; Exported by ynab-cli\n")
+	fmt.Fprintf(bw, "; budget: %s\n", budgetID)
+	fmt.Fprintf(bw, "; since: %s\n", sinceDate)
+
+	for _, e := range entries {
+		mark := "!"
+		if e.Cleared {
+			mark = "*"
+		}
+		fmt.Fprintf(bw, "\n%s %s %s\n", e.Date, mark, strings.TrimSpace(e.Payee))
+		if memo := strings.TrimSpace(e.Memo); memo != "" {
+			fmt.Fprintf(bw, "    ; %s\n", memo)
+		}
+
+		width := 40
+		for _, p := range e.Postings {
+			if len(p.Account) > width {
+				width = len(p.Account)
+			}
+		}
+		for _, p := range e.Postings {
+			amount := formatLedgerAmount(p.Amount, digits)
+			if commodity != "" {
+				amount += " " + commodity
+			}
+			fmt.Fprintf(bw, "    %-*s  %12s\n", width, p.Account, amount)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ledgerAccountName maps a YNAB account to an Assets or Liabilities account.
+func ledgerAccountName(acc *api.Account) string {
+	if liabilityAccountTypes[acc.Type] {
+		return "Liabilities:" + ledgerName(acc.Name)
+	}
+	return "Assets:" + ledgerName(acc.Name)
+}
+
+// ledgerCategoryAccount maps a category leg to Expenses or Income depending on
+// which way the money moved. amount is the category leg, so positive is spending.
+func ledgerCategoryAccount(categoryName string, amount int64) string {
+	name := ledgerName(strings.TrimPrefix(categoryName, "Inflow: "))
+	if name == "" {
+		name = "Uncategorized"
+	}
+	if amount < 0 {
+		return "Income:" + name
+	}
+	return "Expenses:" + name
+}
+
+// ledgerName makes a YNAB name safe as a ledger account component: colons
+// would start a sub-account and runs of spaces would end the account name.
+func ledgerName(name string) string {
+	name = strings.ReplaceAll(name, ":", "-")
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// formatLedgerAmount formats milliunits as a plain decimal with the currency's
+// precision. Sub-unit milliunits are kept rather than rounded so entries
+// always balance exactly.
+func formatLedgerAmount(milliunits int64, digits int) string {
+	sign := ""
+	if milliunits < 0 {
+		sign = "-"
+		milliunits = -milliunits
+	}
+	s := fmt.Sprintf("%d.%03d", milliunits/1000, milliunits%1000)
+	for trim := 3 - digits; trim > 0 && strings.HasSuffix(s, "0"); trim-- {
+		s = s[:len(s)-1]
+	}
+	return sign + strings.TrimSuffix(s, ".")
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestBuildLedgerEntries(t *testing.T) {
+	accounts := []*api.Account{
+		{ID: "chk", Name: "Checking", Type: "checking", OnBudget: true},
+		{ID: "sav", Name: "Savings", Type: "savings", OnBudget: true},
+		{ID: "visa", Name: "Visa: Rewards", Type: "creditCard", OnBudget: true},
+	}
+	transactions := []*api.Transaction{
+		{ID: "t3", Date: "2025-01-03", Amount: -100000, AccountID: "chk", PayeeName: "Transfer : Savings",
+			TransferAccountID: "sav", TransferTransactionID: "t4", Cleared: "cleared"},
+		{ID: "t1", Date: "2025-01-01", Amount: -12500, AccountID: "visa", PayeeName: "Grocer",
+			CategoryName: "Groceries", Cleared: "uncleared", Memo: "weekly shop"},
+		{ID: "t2", Date: "2025-01-02", Amount: 2500000, AccountID: "chk", PayeeName: "Employer",
+			CategoryName: "Inflow: Ready to Assign", Cleared: "reconciled"},
+		{ID: "t4", Date: "2025-01-03", Amount: 100000, AccountID: "sav", PayeeName: "Transfer : Checking",
+			TransferAccountID: "chk", TransferTransactionID: "t3", Cleared: "cleared"},
+		{ID: "t5", Date: "2025-01-04", Amount: -60000, AccountID: "chk", PayeeName: "Costco",
+			Subtransactions: []*api.SubTransaction{
+				{ID: "s1", Amount: -40000, CategoryName: "Groceries"},
+				{ID: "s2", Amount: -20000, TransferAccountID: "sav", TransferTransactionID: "t6"},
+			}},
+		{ID: "t6", Date: "2025-01-04", Amount: 20000, AccountID: "sav", PayeeName: "Transfer : Checking",
+			TransferAccountID: "chk", TransferTransactionID: "s2"},
+		{ID: "t7", Date: "2025-01-05", Amount: -1000, AccountID: "chk", Deleted: true},
+	}
+
+	entries := buildLedgerEntries(transactions, accounts)
+
+	// t4 and t6 are the other sides of transfers; t7 is deleted
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d: %+v", len(entries), entries)
+	}
+
+	for _, e := range entries {
+		var sum int64
+		for _, p := range e.Postings {
+			sum += p.Amount
+		}
+		if sum != 0 {
+			t.Errorf("%s %s does not balance: %+v", e.Date, e.Payee, e.Postings)
+		}
+	}
+
+	if entries[0].Date != "2025-01-01" {
+		t.Errorf("expected entries sorted by date, first is %s", entries[0].Date)
+	}
+
+	grocer := entries[0]
+	if grocer.Cleared {
+		t.Error("uncleared transaction should not be marked cleared")
+	}
+	if grocer.Postings[0].Account != "Liabilities:Visa- Rewards" {
+		t.Errorf("card account = %s", grocer.Postings[0].Account)
+	}
+	if grocer.Postings[1].Account != "Expenses:Groceries" || grocer.Postings[1].Amount != 12500 {
+		t.Errorf("expense leg = %+v", grocer.Postings[1])
+	}
+
+	income := entries[1]
+	if income.Postings[1].Account != "Income:Ready to Assign" || income.Postings[1].Amount != -2500000 {
+		t.Errorf("income leg = %+v", income.Postings[1])
+	}
+
+	transfer := entries[2]
+	if transfer.Postings[0].Account != "Assets:Checking" || transfer.Postings[1].Account != "Assets:Savings" {
+		t.Errorf("transfer postings = %+v", transfer.Postings)
+	}
+
+	split := entries[3]
+	if len(split.Postings) != 3 {
+		t.Fatalf("split postings = %+v", split.Postings)
+	}
+	if split.Postings[2].Account != "Assets:Savings" || split.Postings[2].Amount != 20000 {
+		t.Errorf("split transfer leg = %+v", split.Postings[2])
+	}
+}
+
+func TestWriteLedger(t *testing.T) {
+	entries := []ledgerEntry{{
+		Date:    "2025-01-01",
+		Cleared: true,
+		Payee:   "Grocer",
+		Memo:    "weekly shop",
+		Postings: []ledgerPosting{
+			{Account: "Assets:Checking", Amount: -12500},
+			{Account: "Expenses:Groceries", Amount: 12500},
+		},
+	}}
+
+	var buf bytes.Buffer
+	currency := &api.CurrencyFormat{ISOCode: "USD", DecimalDigits: 2}
+	if err := writeLedger(&buf, "budget-1", "2025-01-01", currency, entries); err != nil {
+		t.Fatalf("writeLedger: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"; budget: budget-1",
+		"2025-01-01 * Grocer\n",
+		"    ; weekly shop\n",
+		"-12.50 USD",
+		"12.50 USD",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatLedgerAmount(t *testing.T) {
+	tests := []struct {
+		milliunits int64
+		digits     int
+		want       string
+	}{
+		{12500, 2, "12.50"},
+		{-12500, 2, "-12.50"},
+		{5, 2, "0.005"},
+		{1000, 0, "1"},
+		{1234, 3, "1.234"},
+		{0, 2, "0.00"},
+	}
+
+	for _, tt := range tests {
+		if got := formatLedgerAmount(tt.milliunits, tt.digits); got != tt.want {
+			t.Errorf("formatLedgerAmount(%d, %d) = %s, want %s", tt.milliunits, tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestWriteBudgetExport(t *testing.T) {
+	detail := &api.BudgetDetail{
+		Budget:          &api.Budget{ID: "budget-1", Name: "Household"},
+		ServerKnowledge: 42,
+		Accounts:        []*api.Account{{ID: "chk", Name: "Checking"}},
+		Transactions:    []*api.Transaction{{ID: "txn-1", Amount: -5000}},
+	}
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+
+	var buf bytes.Buffer
+	if err := writeBudgetExport(&buf, "budget-1", "3.0.0", now, detail); err != nil {
+		t.Fatalf("writeBudgetExport: %v", err)
+	}
+
+	var got map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("export is not JSON: %v", err)
+	}
+	for key, want := range map[string]string{
+		"exported_at":      `"2025-03-01T17:00:00Z"`,
+		"version":          `"3.0.0"`,
+		"budget_id":        `"budget-1"`,
+		"server_knowledge": `42`,
+	} {
+		if string(got[key]) != want {
+			t.Errorf("%s = %s, want %s", key, got[key], want)
+		}
+	}
+	for _, key := range []string{"budget", "accounts", "transactions"} {
+		if _, ok := got[key]; !ok {
+			t.Errorf("export has no %s", key)
+		}
+	}
+}
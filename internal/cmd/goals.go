@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+// goalBarWidth is the number of cells in a goal progress bar.
+const goalBarWidth = 10
+
+// GoalsOutput represents the JSON output for budget --goals.
+type GoalsOutput struct {
+	BudgetID   string     `json:"budget_id"`
+	Month      string     `json:"month"`
+	Categories []GoalItem `json:"categories"`
+}
+
+// GoalItem is one category's goal. The goal_* fields are YNAB's own; a
+// category without a goal has them empty and a description of "no goal".
+type GoalItem struct {
+	ID                     string `json:"id"`
+	Name                   string `json:"name"`
+	Group                  string `json:"group"`
+	Balance                int64  `json:"balance"`
+	GoalType               string `json:"goal_type,omitempty"`
+	GoalTarget             int64  `json:"goal_target,omitempty"`
+	GoalTargetMonth        string `json:"goal_target_month,omitempty"`
+	GoalPercentageComplete int    `json:"goal_percentage_complete"`
+	Description            string `json:"description"`
+}
+
+// BudgetGoalsCmd shows goal progress for the current month's categories.
+// With goalsOnly, categories without a goal are left out; otherwise they are
+// listed as "no goal".
+func BudgetGoalsCmd(client *api.Client, goalsOnly, jsonOutput bool) error {
+	if csvOutput {
+		return fmt.Errorf("--csv lists categories; it can't be combined with --goals")
+	}
+
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	categoryGroups, err := client.GetCategories(budgetID)
+	if err != nil {
+		return fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	now := time.Now()
+	currentMonth := transform.FormatMonth(now.Year(), int(now.Month())) + "-01"
+
+	items := make([]GoalItem, 0)
+	for _, group := range categoryGroups {
+		if group.Hidden || group.Deleted || group.Name == "Internal Master Category" {
+			continue
+		}
+		for _, c := range group.Categories {
+			if c.Hidden || c.Deleted {
+				continue
+			}
+			if goalsOnly && c.GoalType == "" {
+				continue
+			}
+			items = append(items, GoalItem{
+				ID:                     c.ID,
+				Name:                   c.Name,
+				Group:                  group.Name,
+				Balance:                c.Balance,
+				GoalType:               c.GoalType,
+				GoalTarget:             c.GoalTarget,
+				GoalTargetMonth:        c.GoalTargetMonth,
+				GoalPercentageComplete: c.GoalPercentageComplete,
+				Description:            describeGoal(c),
+			})
+		}
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(GoalsOutput{BudgetID: budgetID, Month: currentMonth, Categories: items})
+	}
+
+	if len(items) == 0 {
+		fmt.Println("No categories have goals.")
+		return nil
+	}
+
+	year, month, _ := transform.ParseMonth(currentMonth)
+	fmt.Printf("Goals for %s\n\n", transform.FormatMonth(year, month))
+
+	tbl := table{columns: []tableColumn{
+		{Header: "Group", MinWidth: 12, MaxWidth: 20},
+		{Header: "Category", MinWidth: 15, MaxWidth: 25},
+		{Header: "Goal", MinWidth: 20},
+		{Header: "Progress", MinWidth: 18},
+		{Header: "Balance", Right: true, MinWidth: 12},
+	}}
+	withGoal, complete := 0, 0
+	for _, item := range items {
+		progress := ""
+		if item.GoalType != "" {
+			progress = goalBar(item.GoalPercentageComplete)
+			withGoal++
+			if item.GoalPercentageComplete >= 100 {
+				complete++
+			}
+		}
+		tbl.addRow(item.Group, item.Name, item.Description, progress, formatAmount(item.Balance))
+	}
+	tbl.render(os.Stdout)
+	fmt.Printf("\n%d of %d goal(s) complete\n", complete, withGoal)
+	return nil
+}
+
+// describeGoal explains a category's goal in words, e.g. "Save $500.00 by
+// 2025-12". Unknown goal types are shown by their code.
+func describeGoal(c *api.Category) string {
+	target := transform.FormatCurrency(c.GoalTarget)
+	by := ""
+	if len(c.GoalTargetMonth) >= 7 {
+		by = " by " + c.GoalTargetMonth[:7]
+	}
+
+	switch c.GoalType {
+	case "":
+		return "no goal"
+	case "TB":
+		return "Save " + target
+	case "TBD":
+		return "Save " + target + by
+	case "MF":
+		return "Budget " + target + " monthly"
+	case "NEED":
+		if by != "" {
+			return "Need " + target + by
+		}
+		return "Need " + target + " monthly"
+	case "DEBT":
+		return "Pay " + target + " monthly"
+	default:
+		return c.GoalType + " " + target
+	}
+}
+
+// goalBar draws percent complete as a fixed-width bar, e.g. "[####------]  40%".
+// Percentages outside 0-100 are clamped for the bar but printed as given.
+func goalBar(percent int) string {
+	filled := min(max(percent, 0), 100) * goalBarWidth / 100
+	return fmt.Sprintf("[%s%s] %3d%%", strings.Repeat("#", filled), strings.Repeat("-", goalBarWidth-filled), percent)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestDescribeGoal(t *testing.T) {
+	tests := []struct {
+		category api.Category
+		want     string
+	}{
+		{api.Category{}, "no goal"},
+		{api.Category{GoalType: "TB", GoalTarget: 500000}, "Save $500.00"},
+		{api.Category{GoalType: "TBD", GoalTarget: 1200000, GoalTargetMonth: "2025-12-01"}, "Save $1,200.00 by 2025-12"},
+		{api.Category{GoalType: "MF", GoalTarget: 50000}, "Budget $50.00 monthly"},
+		{api.Category{GoalType: "NEED", GoalTarget: 300000}, "Need $300.00 monthly"},
+		{api.Category{GoalType: "NEED", GoalTarget: 300000, GoalTargetMonth: "2026-03-01"}, "Need $300.00 by 2026-03"},
+		{api.Category{GoalType: "DEBT", GoalTarget: 250000}, "Pay $250.00 monthly"},
+		{api.Category{GoalType: "XYZ", GoalTarget: 1000}, "XYZ $1.00"},
+	}
+	for _, tt := range tests {
+		if got := describeGoal(&tt.category); got != tt.want {
+			t.Errorf("describeGoal(%s) = %q, want %q", tt.category.GoalType, got, tt.want)
+		}
+	}
+}
+
+func TestGoalBar(t *testing.T) {
+	tests := []struct {
+		percent int
+		want    string
+	}{
+		{0, "[----------]   0%"},
+		{45, "[####------]  45%"},
+		{100, "[##########] 100%"},
+		{130, "[##########] 130%"},
+		{-5, "[----------]  -5%"},
+	}
+	for _, tt := range tests {
+		if got := goalBar(tt.percent); got != tt.want {
+			t.Errorf("goalBar(%d) = %q, want %q", tt.percent, got, tt.want)
+		}
+	}
+}
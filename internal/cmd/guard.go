@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+// BlockedOutput represents the JSON output when a guard refuses to modify a transaction.
+type BlockedOutput struct {
+	TransactionID string `json:"transaction_id"`
+	Blocked       string `json:"blocked"`
+	Message       string `json:"message"`
+}
+
+// guardReconciled refuses to modify a reconciled transaction unless force is set.
+// YNAB locks reconciled transactions so the reconciled balance stays correct;
+// changing one silently breaks that history.
+func guardReconciled(txn *api.Transaction, action string, force, jsonOutput bool) error {
+	if force || txn.Cleared != "reconciled" {
+		return nil
+	}
+
+	message := fmt.Sprintf("transaction %s is reconciled; refusing to %s it without --force", txn.ID, action)
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(BlockedOutput{
+			TransactionID: txn.ID,
+			Blocked:       "reconciled",
+			Message:       message,
+		}); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+	}
+
+	return fmt.Errorf("%s\n\nReconciled transactions have been matched against a bank statement.\nChanging them will make your reconciled balance disagree with the bank.\nRe-run with --force if you really mean to %s it", message, action)
+}
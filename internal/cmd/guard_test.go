@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+// TestGuardReconciled tests that reconciled transactions require --force.
+func TestGuardReconciled(t *testing.T) {
+	tests := []struct {
+		name    string
+		cleared string
+		force   bool
+		wantErr bool
+	}{
+		{"uncleared", "uncleared", false, false},
+		{"cleared", "cleared", false, false},
+		{"reconciled blocked", "reconciled", false, true},
+		{"reconciled forced", "reconciled", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txn := &api.Transaction{ID: "t1", Cleared: tt.cleared}
+			err := guardReconciled(txn, "edit", tt.force, false)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("guardReconciled(%s, force=%v) error = %v, wantErr %v", tt.cleared, tt.force, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestGuardReconciled_JSON tests the blocked reason in JSON output.
+func TestGuardReconciled_JSON(t *testing.T) {
+	txn := &api.Transaction{ID: "t1", Cleared: "reconciled"}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := guardReconciled(txn, "delete", false, true)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	out := buf.String()
+
+	if err == nil {
+		t.Fatal("expected guard to block reconciled transaction")
+	}
+
+	var blocked BlockedOutput
+	if jsonErr := json.Unmarshal([]byte(out), &blocked); jsonErr != nil {
+		t.Fatalf("invalid JSON output %q: %v", out, jsonErr)
+	}
+	if blocked.Blocked != "reconciled" || blocked.TransactionID != "t1" {
+		t.Errorf("unexpected blocked output: %+v", blocked)
+	}
+}
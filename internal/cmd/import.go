@@ -0,0 +1,355 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/ofx"
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+// ImportOutput represents the JSON output for the import command.
+type ImportOutput struct {
+	BudgetID    string          `json:"budget_id"`
+	AccountID   string          `json:"account_id"`
+	AccountName string          `json:"account_name"`
+	DryRun      bool            `json:"dry_run"`
+	Created     int             `json:"created"`
+	Duplicates  int             `json:"duplicates"`
+	Rows        []ImportRowItem `json:"rows"`
+}
+
+// ImportRowItem represents one imported CSV row or OFX transaction.
+type ImportRowItem struct {
+	Line          int    `json:"line"`
+	Date          string `json:"date"`
+	Amount        int64  `json:"amount"`
+	AmountDisplay string `json:"amount_display"`
+	Payee         string `json:"payee"`
+	Memo          string `json:"memo,omitempty"`
+	ImportID      string `json:"import_id"`
+	Status        string `json:"status"` // "created", "duplicate" or, with --dry-run, "new"
+}
+
+// ImportOptions holds the parameters for the import command.
+type ImportOptions struct {
+	File       string // CSV, OFX or QFX file to read
+	Account    string // Account name, alias or ID to import into
+	DateCol    int    // Zero-based CSV column indexes
+	AmountCol  int
+	PayeeCol   int
+	MemoCol    int    // -1 for no memo column
+	DateFormat string // Go layout; empty tries YYYY-MM-DD, then MM/DD/YYYY
+	DryRun     bool   // Parse and show the rows without creating anything
+}
+
+// importRow is a parsed CSV row or OFX transaction.
+type importRow struct {
+	Line     int    // CSV line, or the line of the OFX <STMTTRN>
+	Date     string // YYYY-MM-DD
+	Amount   int64  // milliunits, negative for outflows
+	Payee    string
+	Memo     string
+	ImportID string
+}
+
+// importDateLayouts are tried in order when no --date-format is given.
+var importDateLayouts = []string{"2006-01-02", "01/02/2006", "1/2/2006"}
+
+// ImportCmd creates transactions from a bank CSV or OFX/QFX export in one
+// bulk request. OFX files are recognized by their .ofx or .qfx extension or
+// their header; anything else is read as CSV. Rows are created cleared and
+// unapproved, like YNAB's own file imports, and carry import IDs (derived
+// from a CSV row's contents, or the OFX FITID) so importing the same file
+// again skips them.
+func ImportCmd(client *api.Client, opts ImportOptions, jsonOutput bool) error {
+	data, err := os.ReadFile(opts.File)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", opts.File, err)
+	}
+
+	var rows []importRow
+	if isOFXFile(opts.File, data) {
+		rows, err = parseImportOFX(data)
+	} else {
+		rows, err = parseImportCSV(bytes.NewReader(data), opts)
+	}
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("no transactions found in %s", opts.File)
+	}
+
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	accounts, err := client.GetAccounts(budgetID)
+	if err != nil {
+		return fmt.Errorf("failed to get accounts: %w", err)
+	}
+	var candidates []*api.Account
+	for _, acc := range accounts {
+		if !acc.Closed && !acc.Deleted {
+			candidates = append(candidates, acc)
+		}
+	}
+	account, err := matchAccount(candidates, opts.Account)
+	if err != nil {
+		return err
+	}
+
+	status := make(map[string]string, len(rows))
+	created, duplicates := 0, 0
+	if opts.DryRun {
+		for _, r := range rows {
+			status[r.ImportID] = "new"
+		}
+	} else {
+		reqs := make([]*api.TransactionRequest, 0, len(rows))
+		for _, r := range rows {
+			reqs = append(reqs, &api.TransactionRequest{
+				BudgetID:  budgetID,
+				AccountID: account.ID,
+				Date:      r.Date,
+				Amount:    r.Amount,
+				PayeeName: r.Payee,
+				Memo:      r.Memo,
+				Cleared:   "cleared",
+				Approved:  false,
+				ImportID:  r.ImportID,
+			})
+		}
+		_, dupIDs, err := client.CreateTransactions(budgetID, reqs)
+		if err != nil {
+			return fmt.Errorf("failed to import transactions: %w", err)
+		}
+		for _, r := range rows {
+			status[r.ImportID] = "created"
+		}
+		for _, id := range dupIDs {
+			status[id] = "duplicate"
+		}
+		for _, r := range rows {
+			if status[r.ImportID] == "duplicate" {
+				duplicates++
+			} else {
+				created++
+			}
+		}
+	}
+
+	if jsonOutput {
+		output := ImportOutput{
+			BudgetID:    budgetID,
+			AccountID:   account.ID,
+			AccountName: account.Name,
+			DryRun:      opts.DryRun,
+			Created:     created,
+			Duplicates:  duplicates,
+			Rows:        make([]ImportRowItem, 0, len(rows)),
+		}
+		for _, r := range rows {
+			output.Rows = append(output.Rows, ImportRowItem{
+				Line:          r.Line,
+				Date:          r.Date,
+				Amount:        r.Amount,
+				AmountDisplay: transform.FormatCurrency(r.Amount),
+				Payee:         r.Payee,
+				Memo:          r.Memo,
+				ImportID:      r.ImportID,
+				Status:        status[r.ImportID],
+			})
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	if opts.DryRun {
+		fmt.Printf("Would import into '%s':\n\n", account.Name)
+	} else {
+		fmt.Printf("Imported into '%s':\n\n", account.Name)
+	}
+
+	tbl := table{columns: []tableColumn{
+		{Header: "Line", Right: true},
+		{Header: "Date", MinWidth: 12},
+		{Header: "Payee", MinWidth: 15, MaxWidth: 30},
+		{Header: "Amount", Right: true, MinWidth: 12},
+		{Header: "Status"},
+	}}
+	for _, r := range rows {
+		tbl.addRow(strconv.Itoa(r.Line), r.Date, r.Payee, transform.FormatCurrency(r.Amount), status[r.ImportID])
+	}
+	tbl.render(os.Stdout)
+
+	if opts.DryRun {
+		fmt.Printf("\n%d row(s) parsed; nothing was created.\n", len(rows))
+		return nil
+	}
+	fmt.Printf("\n%d created, %d skipped as already imported.\n", created, duplicates)
+	if created > 0 {
+		fmt.Println("Review them with: ynab transactions --unapproved")
+	}
+	return nil
+}
+
+// parseImportCSV reads transactions from r using the column mapping in opts.
+// A first row whose date doesn't parse is taken as a header; blank rows are
+// skipped, and any other bad row is an error naming its line.
+func parseImportCSV(r io.Reader, opts ImportOptions) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // banks pad some rows
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	needed := max(opts.DateCol, opts.AmountCol, opts.PayeeCol, opts.MemoCol)
+	occurrences := make(map[string]int)
+	var rows []importRow
+	for i, record := range records {
+		line := i + 1
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(record) <= needed {
+			return nil, fmt.Errorf("line %d: expected at least %d columns, got %d", line, needed+1, len(record))
+		}
+
+		date, err := parseImportDate(record[opts.DateCol], opts.DateFormat)
+		if err != nil {
+			if i == 0 {
+				continue // header
+			}
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		amount, err := parseImportAmount(record[opts.AmountCol])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		row := importRow{
+			Line:   line,
+			Date:   date,
+			Amount: amount,
+			Payee:  strings.TrimSpace(record[opts.PayeeCol]),
+		}
+		if opts.MemoCol >= 0 {
+			row.Memo = strings.TrimSpace(record[opts.MemoCol])
+		}
+
+		key := fmt.Sprintf("%s|%d|%s", row.Date, row.Amount, row.Payee)
+		occurrences[key]++
+		row.ImportID = importRowID(key, occurrences[key])
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// isOFXFile reports whether the import file is OFX rather than CSV.
+func isOFXFile(path string, data []byte) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ofx", ".qfx":
+		return true
+	}
+	return ofx.Detect(data)
+}
+
+// parseImportOFX reads the transactions of an OFX or QFX statement. A
+// transaction without a NAME uses its MEMO as the payee.
+func parseImportOFX(data []byte) ([]importRow, error) {
+	txns, err := ofx.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]importRow, 0, len(txns))
+	for _, t := range txns {
+		row := importRow{
+			Line:     t.Line,
+			Date:     t.Date,
+			Amount:   t.Amount,
+			Payee:    t.Name,
+			Memo:     t.Memo,
+			ImportID: ofxImportID(t.FITID),
+		}
+		if row.Payee == "" {
+			row.Payee, row.Memo = row.Memo, ""
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ofxImportID derives an import ID from the bank's FITID, which is unique
+// within an account, so statements that overlap import each transaction
+// once. A FITID too long for YNAB's 36-character limit is hashed.
+func ofxImportID(fitid string) string {
+	id := "OFX:" + fitid
+	if len(id) <= api.MaxImportIDLength {
+		return id
+	}
+	sum := sha256.Sum256([]byte(fitid))
+	return "OFX:" + hex.EncodeToString(sum[:16])
+}
+
+// importRowID derives an import ID from a row's date, amount and payee, plus
+// its occurrence among identical rows so both of two equal purchases import.
+// It fits YNAB's 36-character limit.
+func importRowID(key string, occurrence int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", key, occurrence)))
+	return "CSV:" + hex.EncodeToString(sum[:16])
+}
+
+// parseImportDate parses a CSV date with layout, or with the common bank
+// layouts if layout is empty, and returns it as YYYY-MM-DD.
+func parseImportDate(s, layout string) (string, error) {
+	s = strings.TrimSpace(s)
+	layouts := importDateLayouts
+	if layout != "" {
+		layouts = []string{layout}
+	}
+	for _, l := range layouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return transform.FormatDate(t), nil
+		}
+	}
+	return "", fmt.Errorf("invalid date: %q", s)
+}
+
+// parseImportAmount parses a bank amount such as "-1,234.56", "$12.50" or
+// the accounting form "(12.50)" into milliunits.
+func parseImportAmount(s string) (int64, error) {
+	clean := strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(clean, "(") && strings.HasSuffix(clean, ")") {
+		negative = true
+		clean = clean[1 : len(clean)-1]
+	}
+	clean = strings.NewReplacer("$", "", ",", "", " ", "").Replace(clean)
+
+	f, err := strconv.ParseFloat(clean, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %q", s)
+	}
+	if negative {
+		f = -f
+	}
+	return transform.DollarsToMilliunits(f), nil
+}
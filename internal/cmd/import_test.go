@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	input := `Date,Amount,Description,Notes
+01/15/2025,-4.50,Coffee Shop,latte
+01/15/2025,-4.50,Coffee Shop,latte
+
+2025-01-16,"$1,200.00",Employer,
+01/17/2025,(12.50),Hardware Store,
+`
+	opts := ImportOptions{DateCol: 0, AmountCol: 1, PayeeCol: 2, MemoCol: 3}
+
+	rows, err := parseImportCSV(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatalf("parseImportCSV: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows, got %d: %+v", len(rows), rows)
+	}
+
+	first := rows[0]
+	if first.Line != 2 || first.Date != "2025-01-15" || first.Amount != -4500 || first.Payee != "Coffee Shop" || first.Memo != "latte" {
+		t.Errorf("unexpected first row: %+v", first)
+	}
+	if rows[2].Amount != 1200000 || rows[3].Amount != -12500 || rows[3].Date != "2025-01-17" {
+		t.Errorf("unexpected amounts or dates: %+v, %+v", rows[2], rows[3])
+	}
+
+	// Identical rows get distinct IDs, and parsing again gives the same IDs
+	if rows[0].ImportID == rows[1].ImportID {
+		t.Errorf("identical rows share import ID %s", rows[0].ImportID)
+	}
+	again, _ := parseImportCSV(strings.NewReader(input), opts)
+	for i := range rows {
+		if again[i].ImportID != rows[i].ImportID {
+			t.Errorf("row %d: import ID changed between runs", i)
+		}
+		if len(rows[i].ImportID) > 36 {
+			t.Errorf("row %d: import ID %s is too long", i, rows[i].ImportID)
+		}
+	}
+}
+
+func TestParseImportCSV_Errors(t *testing.T) {
+	opts := ImportOptions{DateCol: 0, AmountCol: 1, PayeeCol: 2, MemoCol: -1}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"bad date after the header", "2025-01-15,-1.00,A\nyesterday,-2.00,B\n", "line 2"},
+		{"bad amount", "2025-01-15,ten,A\n", "invalid amount"},
+		{"missing column", "2025-01-15,-1.00\n", "line 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseImportCSV(strings.NewReader(tt.input), opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestParseImportOFX(t *testing.T) {
+	input := `OFXHEADER:100
+DATA:OFXSGML
+
+<OFX>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>POS
+<DTPOSTED>20250115
+<TRNAMT>-4.50
+<FITID>20250115-1
+<NAME>Coffee Shop
+<MEMO>latte
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>FEE
+<DTPOSTED>20250131
+<TRNAMT>-2.00
+<FITID>` + strings.Repeat("9", 40) + `
+<MEMO>Monthly fee
+</STMTTRN>
+</BANKTRANLIST>
+</OFX>
+`
+	if !isOFXFile("statement.txt", []byte(input)) || !isOFXFile("export.QFX", nil) || isOFXFile("statement.csv", []byte("Date,Amount\n")) {
+		t.Error("isOFXFile misdetected a file")
+	}
+
+	rows, err := parseImportOFX([]byte(input))
+	if err != nil {
+		t.Fatalf("parseImportOFX: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d: %+v", len(rows), rows)
+	}
+	first := rows[0]
+	if first.Line != 6 || first.Date != "2025-01-15" || first.Amount != -4500 || first.Payee != "Coffee Shop" || first.Memo != "latte" || first.ImportID != "OFX:20250115-1" {
+		t.Errorf("unexpected first row: %+v", first)
+	}
+
+	// No NAME falls back to the memo, and a long FITID still fits
+	if rows[1].Payee != "Monthly fee" || rows[1].Memo != "" {
+		t.Errorf("unexpected payee or memo: %+v", rows[1])
+	}
+	if len(rows[1].ImportID) > 36 || !strings.HasPrefix(rows[1].ImportID, "OFX:") {
+		t.Errorf("import ID %s is too long or unprefixed", rows[1].ImportID)
+	}
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// jsonLines writes --jsonl output: one compact JSON object per line, with no
+// enclosing object, so each line can be handled as soon as it arrives.
+// Output is buffered; call flush when done.
+type jsonLines struct {
+	bw      *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newJSONLines(w io.Writer) *jsonLines {
+	bw := bufio.NewWriter(w)
+	return &jsonLines{bw: bw, encoder: json.NewEncoder(bw)}
+}
+
+// write encodes v as the next line.
+func (j *jsonLines) write(v any) error {
+	if err := j.encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return nil
+}
+
+func (j *jsonLines) flush() error {
+	return j.bw.Flush()
+}
+
+// checkJSONLines rejects --jsonl together with --csv, the other alternative
+// to the default output; with --json, --jsonl wins.
+func checkJSONLines(jsonl bool) error {
+	if jsonl && csvOutput {
+		return fmt.Errorf("choose one of --jsonl and --csv")
+	}
+	return nil
+}
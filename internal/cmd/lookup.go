@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/storage"
+)
+
+// lookupCacheTTL is how long account and category names may be served from
+// the lookup cache; zero disables it. Set once at startup from config.
+var lookupCacheTTL time.Duration
+
+// lookupCacheBypass is set by --no-cache: lookups go to the API and the
+// cache is discarded.
+var lookupCacheBypass bool
+
+// SetLookupCache configures the lookup cache used by name resolution.
+func SetLookupCache(ttl time.Duration, bypass bool) {
+	lookupCacheTTL = ttl
+	lookupCacheBypass = bypass
+}
+
+// lookupAccounts returns the budget's accounts for name resolution, from the
+// lookup cache when it is enabled and fresh. Cached accounts carry only
+// IDs, names and status flags, so callers needing balances must use the API.
+func lookupAccounts(client *api.Client, budgetID string) ([]*api.Account, error) {
+	if lookupCacheBypass {
+		storage.InvalidateLookup(budgetID)
+	}
+	if lookupCacheTTL <= 0 || lookupCacheBypass {
+		return client.GetAccounts(budgetID)
+	}
+
+	now := time.Now()
+	cache := storage.LoadLookup(budgetID)
+	if cache.AccountsFresh(lookupCacheTTL, now) {
+		return cache.APIAccounts(), nil
+	}
+	accounts, err := client.GetAccounts(budgetID)
+	if err != nil {
+		return nil, err
+	}
+	cache.SetAccounts(accounts, now)
+	storage.SaveLookup(cache) // best effort; the next lookup refetches
+	return accounts, nil
+}
+
+// lookupCategories is lookupAccounts for category groups. Cached categories
+// carry no budgeted, activity or balance amounts.
+func lookupCategories(client *api.Client, budgetID string) ([]*api.CategoryGroup, error) {
+	if lookupCacheBypass {
+		storage.InvalidateLookup(budgetID)
+	}
+	if lookupCacheTTL <= 0 || lookupCacheBypass {
+		return client.GetCategories(budgetID)
+	}
+
+	now := time.Now()
+	cache := storage.LoadLookup(budgetID)
+	if cache.CategoriesFresh(lookupCacheTTL, now) {
+		return cache.APICategories(), nil
+	}
+	groups, err := client.GetCategories(budgetID)
+	if err != nil {
+		return nil, err
+	}
+	cache.SetCategories(groups, now)
+	storage.SaveLookup(cache)
+	return groups, nil
+}
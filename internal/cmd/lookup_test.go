@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/storage"
+)
+
+func TestFindAccountUsesLookupCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	server := createTestServer()
+	defer server.Close()
+	requests := 0
+	counting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/accounts") {
+			requests++
+		}
+		server.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer counting.Close()
+	client := createTestClient(t, counting)
+
+	find := func() {
+		t.Helper()
+		_, name, err := findAccount(client, "test-budget-1", "savings", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != "Savings Account" {
+			t.Errorf("found %q, want Savings Account", name)
+		}
+	}
+
+	// Off by default: every lookup asks the API
+	SetLookupCache(0, false)
+	defer SetLookupCache(0, false)
+	find()
+	find()
+	if requests != 2 {
+		t.Errorf("uncached: %d account requests, want 2", requests)
+	}
+
+	requests = 0
+	SetLookupCache(time.Hour, false)
+	find()
+	find()
+	if requests != 1 {
+		t.Errorf("cached: %d account requests, want 1", requests)
+	}
+
+	// --no-cache goes to the API and drops the cache file
+	requests = 0
+	SetLookupCache(time.Hour, true)
+	find()
+	if requests != 1 {
+		t.Errorf("bypassed: %d account requests, want 1", requests)
+	}
+	if _, err := os.Stat(storage.LookupPath("test-budget-1")); !os.IsNotExist(err) {
+		t.Errorf("lookup cache not removed: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+// MonthCompareOutput represents the JSON output for months --compare.
+type MonthCompareOutput struct {
+	BudgetID string            `json:"budget_id"`
+	Month    MonthDetailOutput `json:"month"`
+	Against  MonthDetailOutput `json:"against"`
+	Delta    MonthDelta        `json:"delta"` // month minus against
+}
+
+// MonthDelta is the change from one month to another. Activity is negative
+// for spending, so a negative activity delta means more was spent.
+type MonthDelta struct {
+	Income       int64           `json:"income"`
+	Budgeted     int64           `json:"budgeted"`
+	Activity     int64           `json:"activity"`
+	ToBeBudgeted int64           `json:"to_be_budgeted"`
+	Categories   []CategoryDelta `json:"categories"`
+}
+
+// CategoryDelta is the change in one category. A category present in only
+// one of the months counts as zero in the other.
+type CategoryDelta struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Status   string `json:"status"` // "both", "new" (only in month) or "gone" (only in against)
+	Budgeted int64  `json:"budgeted"`
+	Activity int64  `json:"activity"`
+	Balance  int64  `json:"balance"`
+}
+
+// MonthsCompareCmd compares month (YYYY-MM, or the current month if empty)
+// against another month, or against the month before it if against is
+// empty, category by category.
+func MonthsCompareCmd(client *api.Client, month, against string, jsonOutput bool) error {
+	month, err := assignMonth(month, time.Now())
+	if err != nil {
+		return err
+	}
+	if against == "" {
+		against = previousMonth(month)
+	} else if against, err = assignMonth(against, time.Now()); err != nil {
+		return err
+	}
+	if against == month {
+		return fmt.Errorf("cannot compare %s with itself", month[:7])
+	}
+
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+	base, err := client.GetMonth(budgetID, month)
+	if err != nil {
+		return fmt.Errorf("failed to get month %s: %w", month[:7], err)
+	}
+	other, err := client.GetMonth(budgetID, against)
+	if err != nil {
+		return fmt.Errorf("failed to get month %s: %w", against[:7], err)
+	}
+
+	delta := diffMonths(base, other)
+
+	if jsonOutput {
+		output := MonthCompareOutput{
+			BudgetID: budgetID,
+			Month:    newMonthDetailOutput(budgetID, base),
+			Against:  newMonthDetailOutput(budgetID, other),
+			Delta:    delta,
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	fmt.Printf("%s vs %s\n\n", month[:7], against[:7])
+	totals := table{columns: []tableColumn{
+		{Header: "", MinWidth: 15},
+		{Header: month[:7], Right: true, MinWidth: 12},
+		{Header: against[:7], Right: true, MinWidth: 12},
+		{Header: "Change", Right: true, MinWidth: 12},
+	}}
+	totals.addRow("Income", formatAmount(base.Income), formatAmount(other.Income), formatAmount(delta.Income))
+	totals.addRow("Budgeted", formatAmount(base.Budgeted), formatAmount(other.Budgeted), formatAmount(delta.Budgeted))
+	totals.addRow("Activity", formatAmount(base.Activity), formatAmount(other.Activity), formatAmount(delta.Activity))
+	totals.addRow("To Be Budgeted", formatAmount(base.ToBeBudgeted), formatAmount(other.ToBeBudgeted), formatAmount(delta.ToBeBudgeted))
+	totals.render(os.Stdout)
+
+	tbl := table{columns: []tableColumn{
+		{Header: "Category", MinWidth: 15, MaxWidth: 25},
+		{Header: "Budgeted", Right: true, MinWidth: 12},
+		{Header: "Activity", Right: true, MinWidth: 12},
+		{Header: "Balance", Right: true, MinWidth: 12},
+		{Header: ""},
+	}}
+	for _, c := range delta.Categories {
+		if c.Budgeted == 0 && c.Activity == 0 && c.Balance == 0 {
+			continue
+		}
+		note := ""
+		switch c.Status {
+		case "new":
+			note = "only in " + month[:7]
+		case "gone":
+			note = "only in " + against[:7]
+		}
+		tbl.addRow(c.Name, formatAmount(c.Budgeted), formatAmount(c.Activity), formatAmount(c.Balance), note)
+	}
+	if len(tbl.rows) == 0 {
+		fmt.Println("\nNo category changed.")
+		return nil
+	}
+	fmt.Printf("\nCategory changes:\n\n")
+	tbl.render(os.Stdout)
+	return nil
+}
+
+// diffMonths returns month minus against. Categories are matched by ID, in
+// month's order followed by those only against has; hidden and deleted
+// categories are left out.
+func diffMonths(month, against *api.Month) MonthDelta {
+	delta := MonthDelta{
+		Income:       month.Income - against.Income,
+		Budgeted:     month.Budgeted - against.Budgeted,
+		Activity:     month.Activity - against.Activity,
+		ToBeBudgeted: month.ToBeBudgeted - against.ToBeBudgeted,
+		Categories:   make([]CategoryDelta, 0),
+	}
+
+	visible := func(c *api.Category) bool { return !c.Hidden && !c.Deleted }
+	previous := make(map[string]*api.Category)
+	for _, c := range against.Categories {
+		if visible(c) {
+			previous[c.ID] = c
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, c := range month.Categories {
+		if !visible(c) {
+			continue
+		}
+		seen[c.ID] = true
+		d := CategoryDelta{ID: c.ID, Name: c.Name, Status: "new", Budgeted: c.Budgeted, Activity: c.Activity, Balance: c.Balance}
+		if p, ok := previous[c.ID]; ok {
+			d.Status = "both"
+			d.Budgeted -= p.Budgeted
+			d.Activity -= p.Activity
+			d.Balance -= p.Balance
+		}
+		delta.Categories = append(delta.Categories, d)
+	}
+	for _, c := range against.Categories {
+		if !visible(c) || seen[c.ID] {
+			continue
+		}
+		delta.Categories = append(delta.Categories, CategoryDelta{
+			ID: c.ID, Name: c.Name, Status: "gone",
+			Budgeted: -c.Budgeted, Activity: -c.Activity, Balance: -c.Balance,
+		})
+	}
+	return delta
+}
+
+// previousMonth returns the first of the month before month (YYYY-MM-01).
+func previousMonth(month string) string {
+	year, m, err := transform.ParseMonth(month)
+	if err != nil {
+		return month
+	}
+	if m == 1 {
+		return transform.FormatMonth(year-1, 12) + "-01"
+	}
+	return transform.FormatMonth(year, m-1) + "-01"
+}
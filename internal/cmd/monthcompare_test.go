@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestDiffMonths(t *testing.T) {
+	month := &api.Month{
+		Income: 5000000, Budgeted: 4000000, Activity: -3500000, ToBeBudgeted: 1000000,
+		Categories: []*api.Category{
+			{ID: "cat-groc", Name: "Groceries", Budgeted: 600000, Activity: -550000, Balance: 50000},
+			{ID: "cat-rent", Name: "Rent", Budgeted: 1500000, Activity: -1500000},
+			{ID: "cat-new", Name: "Gifts", Budgeted: 100000, Activity: -20000, Balance: 80000},
+			{ID: "cat-hidden", Name: "Old", Hidden: true, Budgeted: 1},
+		},
+	}
+	against := &api.Month{
+		Income: 4500000, Budgeted: 4200000, Activity: -3000000, ToBeBudgeted: 1200000,
+		Categories: []*api.Category{
+			{ID: "cat-rent", Name: "Rent", Budgeted: 1500000, Activity: -1500000},
+			{ID: "cat-groc", Name: "Groceries", Budgeted: 500000, Activity: -400000, Balance: 100000},
+			{ID: "cat-gone", Name: "Gym", Budgeted: 50000, Activity: -50000},
+			{ID: "cat-deleted", Name: "Deleted", Deleted: true, Budgeted: 1},
+		},
+	}
+
+	delta := diffMonths(month, against)
+	if delta.Income != 500000 || delta.Budgeted != -200000 || delta.Activity != -500000 || delta.ToBeBudgeted != -200000 {
+		t.Errorf("totals = %+v", delta)
+	}
+
+	want := []CategoryDelta{
+		{ID: "cat-groc", Name: "Groceries", Status: "both", Budgeted: 100000, Activity: -150000, Balance: -50000},
+		{ID: "cat-rent", Name: "Rent", Status: "both"},
+		{ID: "cat-new", Name: "Gifts", Status: "new", Budgeted: 100000, Activity: -20000, Balance: 80000},
+		{ID: "cat-gone", Name: "Gym", Status: "gone", Budgeted: -50000, Activity: 50000},
+	}
+	if len(delta.Categories) != len(want) {
+		t.Fatalf("categories = %+v, want %+v", delta.Categories, want)
+	}
+	for i, w := range want {
+		if delta.Categories[i] != w {
+			t.Errorf("categories[%d] = %+v, want %+v", i, delta.Categories[i], w)
+		}
+	}
+}
+
+func TestPreviousMonth(t *testing.T) {
+	tests := map[string]string{
+		"2025-03-01": "2025-02-01",
+		"2025-01-01": "2024-12-01",
+		"2024-12-01": "2024-11-01",
+	}
+	for month, want := range tests {
+		if got := previousMonth(month); got != want {
+			t.Errorf("previousMonth(%s) = %s, want %s", month, got, want)
+		}
+	}
+}
@@ -12,7 +12,8 @@ import (
 
 // MonthsListOutput represents the JSON output for the months list.
 type MonthsListOutput struct {
-	Months []MonthSummary `json:"months"`
+	BudgetID string         `json:"budget_id"`
+	Months   []MonthSummary `json:"months"`
 }
 
 // MonthSummary represents a single month summary.
@@ -27,12 +28,13 @@ type MonthSummary struct {
 
 // MonthDetailOutput represents the JSON output for a single month detail.
 type MonthDetailOutput struct {
-	Month        string               `json:"month"`
-	Income       int64                `json:"income"`
-	Budgeted     int64                `json:"budgeted"`
-	Activity     int64                `json:"activity"`
-	ToBeBudgeted int64                `json:"to_be_budgeted"`
-	Categories   []MonthCategoryItem  `json:"categories,omitempty"`
+	BudgetID     string              `json:"budget_id"`
+	Month        string              `json:"month"`
+	Income       int64               `json:"income"`
+	Budgeted     int64               `json:"budgeted"`
+	Activity     int64               `json:"activity"`
+	ToBeBudgeted int64               `json:"to_be_budgeted"`
+	Categories   []MonthCategoryItem `json:"categories,omitempty"`
 }
 
 // MonthCategoryItem represents a category within a month.
@@ -45,14 +47,21 @@ type MonthCategoryItem struct {
 }
 
 // MonthsCmd lists all budget months or shows detail for a specific month.
+// A range argument (YYYY-MM..YYYY-MM) lists only the months in that range.
 func MonthsCmd(client *api.Client, monthArg string, jsonOutput bool) error {
 	budgetID, err := client.GetDefaultBudgetID()
 	if err != nil {
 		return err
 	}
 
-	// If a specific month is requested, show detail
-	if monthArg != "" {
+	var monthRange []string
+	if start, end, ok := strings.Cut(monthArg, ".."); ok {
+		monthRange, err = transform.MonthsBetween(start, end)
+		if err != nil {
+			return err
+		}
+	} else if monthArg != "" {
+		// If a specific month is requested, show detail
 		return monthDetailCmd(client, budgetID, monthArg, jsonOutput)
 	}
 
@@ -62,9 +71,14 @@ func MonthsCmd(client *api.Client, monthArg string, jsonOutput bool) error {
 		return fmt.Errorf("failed to get months: %w", err)
 	}
 
+	if monthRange != nil {
+		months = monthsInRange(months, monthRange)
+	}
+
 	if jsonOutput {
 		output := MonthsListOutput{
-			Months: make([]MonthSummary, 0, len(months)),
+			BudgetID: budgetID,
+			Months:   make([]MonthSummary, 0, len(months)),
 		}
 		for _, m := range months {
 			if m.Deleted {
@@ -85,25 +99,72 @@ func MonthsCmd(client *api.Client, monthArg string, jsonOutput bool) error {
 	}
 
 	fmt.Printf("Budget Months:\n\n")
-	fmt.Printf("%-12s  %12s  %12s  %12s  %12s\n",
-		"Month", "Income", "Budgeted", "Activity", "TBB")
-	fmt.Printf("%s\n", strings.Repeat("-", 64))
-
+	tbl := table{columns: []tableColumn{
+		{Header: "Month", MinWidth: 12},
+		{Header: "Income", Right: true, MinWidth: 12},
+		{Header: "Budgeted", Right: true, MinWidth: 12},
+		{Header: "Activity", Right: true, MinWidth: 12},
+		{Header: "TBB", Right: true, MinWidth: 12},
+	}}
 	for _, m := range months {
 		if m.Deleted {
 			continue
 		}
-		fmt.Printf("%-12s  %12s  %12s  %12s  %12s\n",
-			m.Month[:7], // YYYY-MM
-			transform.FormatCurrency(m.Income),
-			transform.FormatCurrency(m.Budgeted),
-			transform.FormatCurrency(m.Activity),
-			transform.FormatCurrency(m.ToBeBudgeted))
+		tbl.addRow(m.Month[:7], // YYYY-MM
+			formatAmount(m.Income),
+			formatAmount(m.Budgeted),
+			formatAmount(m.Activity),
+			formatAmount(m.ToBeBudgeted))
 	}
+	tbl.render(os.Stdout)
 
 	return nil
 }
 
+// monthsInRange returns the months matching each entry of monthRange, in
+// range order. Months the budget has no data for are skipped.
+func monthsInRange(months []*api.Month, monthRange []string) []*api.Month {
+	byMonth := make(map[string]*api.Month, len(months))
+	for _, m := range months {
+		byMonth[m.Month] = m
+	}
+
+	var result []*api.Month
+	for _, month := range monthRange {
+		if m, ok := byMonth[month]; ok {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// newMonthDetailOutput builds the JSON detail of month, without hidden or
+// deleted categories.
+func newMonthDetailOutput(budgetID string, month *api.Month) MonthDetailOutput {
+	output := MonthDetailOutput{
+		BudgetID:     budgetID,
+		Month:        month.Month,
+		Income:       month.Income,
+		Budgeted:     month.Budgeted,
+		Activity:     month.Activity,
+		ToBeBudgeted: month.ToBeBudgeted,
+		Categories:   make([]MonthCategoryItem, 0),
+	}
+	for _, c := range month.Categories {
+		if c.Hidden || c.Deleted {
+			continue
+		}
+		output.Categories = append(output.Categories, MonthCategoryItem{
+			ID:       c.ID,
+			Name:     c.Name,
+			Budgeted: c.Budgeted,
+			Activity: c.Activity,
+			Balance:  c.Balance,
+		})
+	}
+	return output
+}
+
 func monthDetailCmd(client *api.Client, budgetID, monthArg string, jsonOutput bool) error {
 	// Normalize month format: YYYY-MM -> YYYY-MM-01
 	if len(monthArg) == 7 {
@@ -116,60 +177,36 @@ func monthDetailCmd(client *api.Client, budgetID, monthArg string, jsonOutput bo
 	}
 
 	if jsonOutput {
-		output := MonthDetailOutput{
-			Month:        month.Month,
-			Income:       month.Income,
-			Budgeted:     month.Budgeted,
-			Activity:     month.Activity,
-			ToBeBudgeted: month.ToBeBudgeted,
-			Categories:   make([]MonthCategoryItem, 0),
-		}
-		for _, c := range month.Categories {
-			if c.Hidden || c.Deleted {
-				continue
-			}
-			output.Categories = append(output.Categories, MonthCategoryItem{
-				ID:       c.ID,
-				Name:     c.Name,
-				Budgeted: c.Budgeted,
-				Activity: c.Activity,
-				Balance:  c.Balance,
-			})
-		}
 		encoder := json.NewEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
-		return encoder.Encode(output)
+		return encoder.Encode(newMonthDetailOutput(budgetID, month))
 	}
 
 	fmt.Printf("Month: %s\n\n", month.Month[:7])
-	fmt.Printf("Income:         %s\n", transform.FormatCurrency(month.Income))
-	fmt.Printf("Budgeted:       %s\n", transform.FormatCurrency(month.Budgeted))
-	fmt.Printf("Activity:       %s\n", transform.FormatCurrency(month.Activity))
-	fmt.Printf("To Be Budgeted: %s\n", transform.FormatCurrency(month.ToBeBudgeted))
+	fmt.Printf("Income:         %s\n", formatAmount(month.Income))
+	fmt.Printf("Budgeted:       %s\n", formatAmount(month.Budgeted))
+	fmt.Printf("Activity:       %s\n", formatAmount(month.Activity))
+	fmt.Printf("To Be Budgeted: %s\n", formatAmount(month.ToBeBudgeted))
 
 	if month.Categories != nil && len(month.Categories) > 0 {
 		fmt.Printf("\nCategories:\n\n")
 
-		maxName := 15
-		for _, c := range month.Categories {
-			if !c.Hidden && !c.Deleted && len(c.Name) > maxName && len(c.Name) <= 25 {
-				maxName = len(c.Name)
-			}
-		}
-
-		fmt.Printf("%-*s  %12s  %12s  %12s\n", maxName, "Category", "Budgeted", "Activity", "Balance")
-		fmt.Printf("%s\n", strings.Repeat("-", maxName+12+12+12+6))
-
+		tbl := table{columns: []tableColumn{
+			{Header: "Category", MinWidth: 15, MaxWidth: 25},
+			{Header: "Budgeted", Right: true, MinWidth: 12},
+			{Header: "Activity", Right: true, MinWidth: 12},
+			{Header: "Balance", Right: true, MinWidth: 12},
+		}}
 		for _, c := range month.Categories {
 			if c.Hidden || c.Deleted {
 				continue
 			}
-			fmt.Printf("%-*s  %12s  %12s  %12s\n",
-				maxName, c.Name,
-				transform.FormatCurrency(c.Budgeted),
-				transform.FormatCurrency(c.Activity),
-				transform.FormatCurrency(c.Balance))
+			tbl.addRow(c.Name,
+				formatAmount(c.Budgeted),
+				formatAmount(c.Activity),
+				formatAmount(c.Balance))
 		}
+		tbl.render(os.Stdout)
 	}
 
 	return nil
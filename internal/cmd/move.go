@@ -7,28 +7,38 @@ import (
 	"time"
 
 	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/storage"
 	"github.com/joeyhipolito/ynab-cli/internal/transform"
 )
 
 // MoveOutput represents the JSON output for the move command.
 type MoveOutput struct {
-	Amount       int64  `json:"amount"`
-	AmountDisplay string `json:"amount_display"`
-	Month        string `json:"month"`
-	From         MoveCategoryInfo `json:"from"`
-	To           MoveCategoryInfo `json:"to"`
+	BudgetID      string           `json:"budget_id"`
+	Amount        int64            `json:"amount"`
+	AmountDisplay string           `json:"amount_display"`
+	Month         string           `json:"month"`
+	From          MoveCategoryInfo `json:"from"`
+	To            MoveCategoryInfo `json:"to"`
 }
 
 // MoveCategoryInfo represents category info in a move operation.
 type MoveCategoryInfo struct {
-	ID              string `json:"id"`
-	Name            string `json:"name"`
-	BudgetedBefore  int64  `json:"budgeted_before"`
-	BudgetedAfter   int64  `json:"budgeted_after"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	BudgetedBefore int64  `json:"budgeted_before"`
+	BudgetedAfter  int64  `json:"budgeted_after"`
+	BalanceAfter   int64  `json:"balance_after"`
 }
 
-// MoveCmd moves money between budget categories.
-func MoveCmd(client *api.Client, amountMilliunits int64, fromCategory, toCategory, month string, jsonOutput bool) error {
+// MoveCmd moves money between budget categories by lowering the source's
+// budgeted amount for the month and raising the destination's. Moving more
+// than the source has available is refused unless allowNegative is set. With
+// dryRun, the two updates are printed instead of sent.
+func MoveCmd(client *api.Client, amountMilliunits int64, fromCategory, toCategory, month string, allowNegative, dryRun, jsonOutput bool) error {
+	if amountMilliunits <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+
 	budgetID, err := client.GetDefaultBudgetID()
 	if err != nil {
 		return err
@@ -59,6 +69,9 @@ func MoveCmd(client *api.Client, amountMilliunits int64, fromCategory, toCategor
 
 	fromName := findCategoryName(groups, fromID)
 	toName := findCategoryName(groups, toID)
+	if fromID == toID {
+		return fmt.Errorf("--from and --to both resolve to '%s'; a move needs two different categories", fromName)
+	}
 
 	// Get current budgeted amounts for the month
 	monthData, err := client.GetMonth(budgetID, month)
@@ -66,34 +79,62 @@ func MoveCmd(client *api.Client, amountMilliunits int64, fromCategory, toCategor
 		return fmt.Errorf("failed to get month data: %w", err)
 	}
 
-	var fromBudgeted, toBudgeted int64
+	var fromBudgeted, toBudgeted, fromBalance int64
 	for _, c := range monthData.Categories {
 		if c.ID == fromID {
 			fromBudgeted = c.Budgeted
+			fromBalance = c.Balance
 		}
 		if c.ID == toID {
 			toBudgeted = c.Budgeted
 		}
 	}
 
-	// Update source (decrease)
+	if err := checkMoveAvailable(fromName, fromBalance, amountMilliunits, allowNegative); err != nil {
+		return err
+	}
+
 	newFromBudgeted := fromBudgeted - amountMilliunits
-	_, err = client.UpdateCategoryBudget(fromID, newFromBudgeted, month, budgetID)
+	newToBudgeted := toBudgeted + amountMilliunits
+
+	if dryRun {
+		fromReq, err := client.BuildUpdateCategoryBudget(fromID, newFromBudgeted, month, budgetID)
+		if err != nil {
+			return err
+		}
+		toReq, err := client.BuildUpdateCategoryBudget(toID, newToBudgeted, month, budgetID)
+		if err != nil {
+			return err
+		}
+		return printDryRun(budgetID, []*api.PreparedRequest{fromReq, toReq}, jsonOutput)
+	}
+
+	// Update source (decrease)
+	fromAfter, err := client.UpdateCategoryBudget(fromID, newFromBudgeted, month, budgetID)
 	if err != nil {
 		return fmt.Errorf("failed to update source category: %w", err)
 	}
 
 	// Update destination (increase)
-	newToBudgeted := toBudgeted + amountMilliunits
-	_, err = client.UpdateCategoryBudget(toID, newToBudgeted, month, budgetID)
+	toAfter, err := client.UpdateCategoryBudget(toID, newToBudgeted, month, budgetID)
 	if err != nil {
 		// Try to roll back source on failure
 		_, _ = client.UpdateCategoryBudget(fromID, fromBudgeted, month, budgetID)
 		return fmt.Errorf("failed to update destination category: %w", err)
 	}
+	recordAction(storage.Action{
+		BudgetID: budgetID,
+		Kind:     storage.KindMove,
+		Summary:  fmt.Sprintf("move %s from %s to %s (%s)", transform.FormatCurrency(amountMilliunits), fromName, toName, month[:7]),
+		Budgeted: []storage.BudgetedBefore{
+			{CategoryID: fromID, Month: month, Budgeted: fromBudgeted},
+			{CategoryID: toID, Month: month, Budgeted: toBudgeted},
+		},
+	})
 
 	if jsonOutput {
 		output := MoveOutput{
+			BudgetID:      budgetID,
 			Amount:        amountMilliunits,
 			AmountDisplay: transform.FormatCurrency(amountMilliunits),
 			Month:         month[:7],
@@ -102,12 +143,14 @@ func MoveCmd(client *api.Client, amountMilliunits int64, fromCategory, toCategor
 				Name:           fromName,
 				BudgetedBefore: fromBudgeted,
 				BudgetedAfter:  newFromBudgeted,
+				BalanceAfter:   fromAfter.Balance,
 			},
 			To: MoveCategoryInfo{
 				ID:             toID,
 				Name:           toName,
 				BudgetedBefore: toBudgeted,
 				BudgetedAfter:  newToBudgeted,
+				BalanceAfter:   toAfter.Balance,
 			},
 		}
 		encoder := json.NewEncoder(os.Stdout)
@@ -117,14 +160,26 @@ func MoveCmd(client *api.Client, amountMilliunits int64, fromCategory, toCategor
 
 	fmt.Printf("Moved %s from '%s' to '%s' (%s)\n\n",
 		transform.FormatCurrency(amountMilliunits), fromName, toName, month[:7])
-	fmt.Printf("  %s: %s -> %s\n", fromName,
-		transform.FormatCurrency(fromBudgeted), transform.FormatCurrency(newFromBudgeted))
-	fmt.Printf("  %s: %s -> %s\n", toName,
-		transform.FormatCurrency(toBudgeted), transform.FormatCurrency(newToBudgeted))
+	fmt.Printf("  %s: budgeted %s -> %s, balance %s\n", fromName,
+		transform.FormatCurrency(fromBudgeted), transform.FormatCurrency(newFromBudgeted),
+		formatAmount(fromAfter.Balance))
+	fmt.Printf("  %s: budgeted %s -> %s, balance %s\n", toName,
+		transform.FormatCurrency(toBudgeted), transform.FormatCurrency(newToBudgeted),
+		formatAmount(toAfter.Balance))
 
 	return nil
 }
 
+// checkMoveAvailable refuses a move that would take the source category's
+// balance below zero, unless allowNegative is set.
+func checkMoveAvailable(name string, balance, amount int64, allowNegative bool) error {
+	if allowNegative || amount <= balance {
+		return nil
+	}
+	return fmt.Errorf("'%s' has only %s available; moving %s would overspend it (use --allow-negative to move anyway)",
+		name, transform.FormatCurrency(balance), transform.FormatCurrency(amount))
+}
+
 // findCategoryName finds a category name by ID.
 func findCategoryName(groups []*api.CategoryGroup, id string) string {
 	for _, g := range groups {
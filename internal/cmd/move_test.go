@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckMoveAvailable(t *testing.T) {
+	tests := []struct {
+		name          string
+		balance       int64
+		amount        int64
+		allowNegative bool
+		wantErr       bool
+	}{
+		{"within balance", 100000, 50000, false, false},
+		{"whole balance", 100000, 100000, false, false},
+		{"over balance", 100000, 100010, false, true},
+		{"overspent source", -5000, 1000, false, true},
+		{"over balance allowed", 100000, 250000, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMoveAvailable("Dining Out", tt.balance, tt.amount, tt.allowNegative)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkMoveAvailable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMoveCmd_SameCategory(t *testing.T) {
+	var writes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodGet {
+			writes++
+		}
+		io.WriteString(w, `{"data":{"category_groups":[{"id":"g1","name":"Everyday","categories":[
+			{"id":"c1","name":"Groceries","budgeted":100000,"balance":100000}]}]}}`)
+	}))
+	defer server.Close()
+	client := createTestClient(t, server)
+	client.SetDefaultBudgetID("b1")
+
+	err := MoveCmd(client, 50000, "Groceries", "groceries", "", false, false, false)
+	if err == nil || !strings.Contains(err.Error(), "two different categories") {
+		t.Fatalf("expected a same-category error, got %v", err)
+	}
+	if writes != 0 {
+		t.Errorf("expected no updates, got %d", writes)
+	}
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+// NetWorthOutput represents the JSON output for the net-worth command.
+type NetWorthOutput struct {
+	BudgetID        string         `json:"budget_id"`
+	IncludesClosed  bool           `json:"includes_closed"`
+	Assets          int64          `json:"assets"`
+	Liabilities     int64          `json:"liabilities"` // zero or negative, as YNAB reports debts
+	NetWorth        int64          `json:"net_worth"`
+	NetWorthDisplay string         `json:"net_worth_display"`
+	ByType          []NetWorthType `json:"by_type"`
+}
+
+// NetWorthType totals the accounts of one type.
+type NetWorthType struct {
+	Type     string `json:"type"`
+	Kind     string `json:"kind"` // "asset" or "liability"
+	Accounts int    `json:"accounts"`
+	Balance  int64  `json:"balance"`
+}
+
+// NetWorthCmd sums every account, on- and off-budget, into assets,
+// liabilities and net worth. Closed accounts count unless excludeClosed.
+func NetWorthCmd(client *api.Client, excludeClosed, jsonOutput bool) error {
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	accounts, err := client.GetAccounts(budgetID)
+	if err != nil {
+		return fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	output := summarizeNetWorth(accounts, excludeClosed)
+	output.BudgetID = budgetID
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	tbl := table{columns: []tableColumn{
+		{Header: "Type", MinWidth: 15},
+		{Header: "Accounts", Right: true},
+		{Header: "Balance", Right: true, MinWidth: 15},
+	}}
+	for _, t := range output.ByType {
+		tbl.addRow(formatAccountType(t.Type), strconv.Itoa(t.Accounts), formatAmount(t.Balance))
+	}
+	tbl.render(os.Stdout)
+
+	fmt.Println()
+	fmt.Printf("Assets:       %s\n", formatAmount(output.Assets))
+	fmt.Printf("Liabilities:  %s\n", formatAmount(output.Liabilities))
+	fmt.Printf("Net worth:    %s\n", formatAmount(output.NetWorth))
+	return nil
+}
+
+// summarizeNetWorth totals accounts by type. Deleted accounts never count.
+// Types are listed assets first, then liabilities, each by name.
+func summarizeNetWorth(accounts []*api.Account, excludeClosed bool) NetWorthOutput {
+	output := NetWorthOutput{IncludesClosed: !excludeClosed, ByType: make([]NetWorthType, 0)}
+	byType := make(map[string]*NetWorthType)
+	for _, a := range accounts {
+		if a.Deleted || (excludeClosed && a.Closed) {
+			continue
+		}
+		t, ok := byType[a.Type]
+		if !ok {
+			kind := "asset"
+			if liabilityAccountTypes[a.Type] {
+				kind = "liability"
+			}
+			t = &NetWorthType{Type: a.Type, Kind: kind}
+			byType[a.Type] = t
+		}
+		t.Accounts++
+		t.Balance += a.Balance
+
+		if liabilityAccountTypes[a.Type] {
+			output.Liabilities += a.Balance
+		} else {
+			output.Assets += a.Balance
+		}
+	}
+	output.NetWorth = output.Assets + output.Liabilities
+	output.NetWorthDisplay = transform.FormatCurrency(output.NetWorth)
+
+	for _, t := range byType {
+		output.ByType = append(output.ByType, *t)
+	}
+	sort.Slice(output.ByType, func(i, j int) bool {
+		a, b := output.ByType[i], output.ByType[j]
+		if a.Kind != b.Kind {
+			return a.Kind == "asset"
+		}
+		return a.Type < b.Type
+	})
+	return output
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestSummarizeNetWorth(t *testing.T) {
+	accounts := []*api.Account{
+		{Name: "Checking", Type: "checking", Balance: 250000, OnBudget: true},
+		{Name: "Brokerage", Type: "otherAsset", Balance: 10000000},
+		{Name: "Visa", Type: "creditCard", Balance: -150000, OnBudget: true},
+		{Name: "Mortgage", Type: "mortgage", Balance: -8000000},
+		{Name: "Old Savings", Type: "savings", Balance: 5000, Closed: true},
+		{Name: "Gone", Type: "checking", Balance: 999000, Deleted: true},
+	}
+
+	got := summarizeNetWorth(accounts, false)
+	if got.Assets != 10255000 || got.Liabilities != -8150000 || got.NetWorth != 2105000 {
+		t.Errorf("assets %d, liabilities %d, net %d", got.Assets, got.Liabilities, got.NetWorth)
+	}
+	wantTypes := []string{"checking", "otherAsset", "savings", "creditCard", "mortgage"}
+	if len(got.ByType) != len(wantTypes) {
+		t.Fatalf("by type = %+v", got.ByType)
+	}
+	for i, typ := range wantTypes {
+		if got.ByType[i].Type != typ {
+			t.Errorf("by type[%d] = %s, want %s", i, got.ByType[i].Type, typ)
+		}
+	}
+	if got.ByType[3].Kind != "liability" || got.ByType[0].Kind != "asset" {
+		t.Errorf("kinds = %+v", got.ByType)
+	}
+
+	excluded := summarizeNetWorth(accounts, true)
+	if excluded.Assets != 10250000 || len(excluded.ByType) != 4 || excluded.IncludesClosed {
+		t.Errorf("excluding closed: %+v", excluded)
+	}
+}
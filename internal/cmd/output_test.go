@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestJSONOutputs_IncludeBudgetID tests that every top-level JSON output
+// reports the budget it operated against.
+func TestJSONOutputs_IncludeBudgetID(t *testing.T) {
+	outputs := []interface{}{
+		AccountOutput{},
+		AccountEditOutput{},
+		AccountSummaryOutput{},
+		AddOutput{},
+		ApproveOutput{},
+		BalanceOutput{},
+		BudgetExport{},
+		BudgetOutput{},
+		CategoriesOutput{},
+		ExtractedSummaryOutput{},
+		ImportOutput{},
+		MonthsListOutput{},
+		MonthDetailOutput{},
+		MonthCompareOutput{},
+		GoalsOutput{},
+		MoveOutput{},
+		DryRunOutput{},
+		NetWorthOutput{},
+		AssignOutput{},
+		PayeeMergeOutput{},
+		PayeeRenameOutput{},
+		PayeesOutput{},
+		ReconcileOutput{},
+		RestoreOutput{},
+		RestoreListOutput{},
+		UndoOutput{},
+		UndoListOutput{},
+		ScheduledOutput{},
+		StatusOutput{},
+		SweepOutput{},
+		StatsOutput{},
+		SyncOutput{},
+		TransactionsOutput{},
+		PeriodSummaryOutput{},
+		TransferOutput{},
+		TransactionItem{}, // edit and delete output
+	}
+
+	for _, output := range outputs {
+		typ := reflect.TypeOf(output)
+		t.Run(typ.Name(), func(t *testing.T) {
+			for i := 0; i < typ.NumField(); i++ {
+				tag := typ.Field(i).Tag.Get("json")
+				if strings.Split(tag, ",")[0] == "budget_id" {
+					return
+				}
+			}
+			t.Errorf("%s has no budget_id field", typ.Name())
+		})
+	}
+}
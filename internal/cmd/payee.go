@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+// PayeeRenameOutput represents the JSON output for payees rename.
+type PayeeRenameOutput struct {
+	BudgetID string `json:"budget_id"`
+	PayeeID  string `json:"payee_id"`
+	OldName  string `json:"old_name"`
+	NewName  string `json:"new_name"`
+}
+
+// PayeeMergeOutput represents the JSON output for payees merge.
+type PayeeMergeOutput struct {
+	BudgetID string    `json:"budget_id"`
+	Source   PayeeItem `json:"source"`
+	Dest     PayeeItem `json:"dest"`
+	Moved    int       `json:"moved"` // transactions reassigned to dest
+}
+
+// PayeeRenameCmd renames a payee. A name already used by another payee is
+// refused, since YNAB would then show two payees with the same name; merge
+// them instead.
+func PayeeRenameCmd(client *api.Client, oldName, newName string, jsonOutput bool) error {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return fmt.Errorf("new payee name is required")
+	}
+
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	payees, err := client.GetPayees(budgetID)
+	if err != nil {
+		return fmt.Errorf("failed to get payees: %w", err)
+	}
+	payee, err := findPayee(payees, oldName)
+	if err != nil {
+		return err
+	}
+	if payee.TransferAccountID != "" {
+		return fmt.Errorf("'%s' is a transfer payee; rename the account instead", payee.Name)
+	}
+	for _, p := range payees {
+		if !p.Deleted && p.ID != payee.ID && strings.EqualFold(p.Name, newName) {
+			return fmt.Errorf("a payee named '%s' already exists; use 'ynab payees merge' to combine them", p.Name)
+		}
+	}
+
+	updated, err := client.UpdatePayee(budgetID, payee.ID, newName)
+	if err != nil {
+		return fmt.Errorf("failed to rename payee: %w", err)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(PayeeRenameOutput{
+			BudgetID: budgetID,
+			PayeeID:  updated.ID,
+			OldName:  payee.Name,
+			NewName:  updated.Name,
+		})
+	}
+
+	fmt.Printf("Renamed '%s' to '%s'\n", payee.Name, updated.Name)
+	return nil
+}
+
+// PayeeMergeCmd merges the source payee into dest by moving all of the
+// source's transactions to dest in one bulk update. The API can't delete
+// payees, so the source is left behind with no transactions.
+func PayeeMergeCmd(client *api.Client, sourceName, destName string, jsonOutput bool) error {
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	payees, err := client.GetPayees(budgetID)
+	if err != nil {
+		return fmt.Errorf("failed to get payees: %w", err)
+	}
+	source, err := findPayee(payees, sourceName)
+	if err != nil {
+		return err
+	}
+	dest, err := findPayee(payees, destName)
+	if err != nil {
+		return err
+	}
+	if source.ID == dest.ID {
+		return fmt.Errorf("'%s' and '%s' are the same payee", sourceName, destName)
+	}
+	for _, p := range []*api.Payee{source, dest} {
+		if p.TransferAccountID != "" {
+			return fmt.Errorf("'%s' is a transfer payee and can't be merged", p.Name)
+		}
+	}
+
+	transactions, err := client.GetTransactionsByPayee(budgetID, source.ID, "")
+	if err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
+	}
+	var updates []map[string]interface{}
+	for _, t := range transactions {
+		if !t.Deleted {
+			updates = append(updates, map[string]interface{}{"id": t.ID, "payee_id": dest.ID})
+		}
+	}
+	if len(updates) > 0 {
+		if _, err := client.PatchTransactions(budgetID, updates); err != nil {
+			return fmt.Errorf("failed to move transactions: %w", err)
+		}
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(PayeeMergeOutput{
+			BudgetID: budgetID,
+			Source:   PayeeItem{ID: source.ID, Name: source.Name},
+			Dest:     PayeeItem{ID: dest.ID, Name: dest.Name},
+			Moved:    len(updates),
+		})
+	}
+
+	fmt.Printf("Moved %d transaction(s) from '%s' to '%s'\n", len(updates), source.Name, dest.Name)
+	fmt.Printf("'%s' now has no transactions; the API can't delete payees, so remove it in YNAB if you like.\n", source.Name)
+	return nil
+}
+
+// findPayee finds a payee by ID or name. An exact (case-insensitive) name
+// wins; otherwise the name must match part of exactly one payee. Aliases
+// are expanded first.
+func findPayee(payees []*api.Payee, name string) (*api.Payee, error) {
+	name = expandAlias(name)
+	lower := strings.ToLower(name)
+
+	var matches []*api.Payee
+	for _, p := range payees {
+		if p.Deleted {
+			continue
+		}
+		if p.ID == name || strings.ToLower(p.Name) == lower {
+			return p, nil
+		}
+		if strings.Contains(strings.ToLower(p.Name), lower) {
+			matches = append(matches, p)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no payee found matching '%s'", name)
+	case 1:
+		return matches[0], nil
+	default:
+		var names []string
+		for _, p := range matches {
+			names = append(names, p.Name)
+		}
+		return nil, fmt.Errorf("multiple payees match '%s': %s\nPlease be more specific",
+			name, strings.Join(names, ", "))
+	}
+}
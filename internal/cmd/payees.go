@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/joeyhipolito/ynab-cli/internal/api"
@@ -11,8 +14,9 @@ import (
 
 // PayeesOutput represents the JSON output for the payees command.
 type PayeesOutput struct {
-	Payees []PayeeItem `json:"payees"`
-	Count  int         `json:"count"`
+	BudgetID string      `json:"budget_id"`
+	Payees   []PayeeItem `json:"payees"`
+	Count    int         `json:"count"`
 }
 
 // PayeeItem represents a single payee in the output.
@@ -21,8 +25,21 @@ type PayeeItem struct {
 	Name string `json:"name"`
 }
 
+// PayeesOptions holds the filters for the payees command.
+type PayeesOptions struct {
+	Filter  string
+	Limit   int    // 0 means no limit
+	JSONL   bool   // one JSON object per line, streamed
+	Sort    string // "name", "id", or "" / "none" for API order
+	Reverse bool
+}
+
 // PayeesCmd lists all payees with optional name filtering.
-func PayeesCmd(client *api.Client, filter string, jsonOutput bool) error {
+func PayeesCmd(client *api.Client, opts PayeesOptions, jsonOutput bool) error {
+	if err := checkJSONLines(opts.JSONL); err != nil {
+		return err
+	}
+
 	budgetID, err := client.GetDefaultBudgetID()
 	if err != nil {
 		return err
@@ -33,60 +50,163 @@ func PayeesCmd(client *api.Client, filter string, jsonOutput bool) error {
 		return fmt.Errorf("failed to get payees: %w", err)
 	}
 
-	// Filter
-	var filtered []*api.Payee
-	filterLower := strings.ToLower(filter)
+	return writePayees(os.Stdout, budgetID, payees, opts, jsonOutput)
+}
+
+// eachPayee calls fn for every non-deleted payee matching the filter,
+// stopping after opts.Limit matches. It returns the number of payees visited.
+func eachPayee(payees []*api.Payee, opts PayeesOptions, fn func(p *api.Payee) error) (int, error) {
+	filterLower := strings.ToLower(opts.Filter)
+	count := 0
 	for _, p := range payees {
+		if opts.Limit > 0 && count >= opts.Limit {
+			break
+		}
 		if p.Deleted {
 			continue
 		}
-		if filter != "" && !strings.Contains(strings.ToLower(p.Name), filterLower) {
+		if opts.Filter != "" && !strings.Contains(strings.ToLower(p.Name), filterLower) {
 			continue
 		}
-		filtered = append(filtered, p)
+		if err := fn(p); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// writePayees renders payees to w. The human, JSON Lines and CSV paths write
+// each payee as it is visited instead of building an intermediate slice; only the
+// single-object --json path buffers.
+func writePayees(w io.Writer, budgetID string, payees []*api.Payee, opts PayeesOptions, jsonOutput bool) error {
+	payees = sortPayees(payees, opts.Sort, opts.Reverse)
+
+	if opts.JSONL {
+		lines := newJSONLines(w)
+		_, err := eachPayee(payees, opts, func(p *api.Payee) error {
+			return lines.write(PayeeItem{ID: p.ID, Name: p.Name})
+		})
+		if err != nil {
+			return err
+		}
+		return lines.flush()
+	}
+
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	if csvOutput {
+		if err := writePayeesCSV(bw, payees, opts); err != nil {
+			return err
+		}
+		return bw.Flush()
 	}
 
 	if jsonOutput {
 		output := PayeesOutput{
-			Payees: make([]PayeeItem, 0, len(filtered)),
-			Count:  len(filtered),
+			BudgetID: budgetID,
+			Payees:   make([]PayeeItem, 0),
 		}
-		for _, p := range filtered {
+		eachPayee(payees, opts, func(p *api.Payee) error {
 			output.Payees = append(output.Payees, PayeeItem{
 				ID:   p.ID,
 				Name: p.Name,
 			})
-		}
-		encoder := json.NewEncoder(os.Stdout)
+			return nil
+		})
+		output.Count = len(output.Payees)
+		encoder := json.NewEncoder(bw)
 		encoder.SetIndent("", "  ")
-		return encoder.Encode(output)
+		if err := encoder.Encode(output); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return bw.Flush()
 	}
 
-	if len(filtered) == 0 {
-		if filter != "" {
-			fmt.Printf("No payees found matching '%s'.\n", filter)
-		} else {
-			fmt.Println("No payees found.")
+	// First pass sizes the name column; the second pass prints
+	maxName := 20
+	total, _ := eachPayee(payees, opts, func(p *api.Payee) error {
+		if w := displayWidth(p.Name); w > maxName && w <= 40 {
+			maxName = w
 		}
 		return nil
+	})
+
+	if total == 0 {
+		if opts.Filter != "" {
+			fmt.Fprintf(bw, "No payees found matching '%s'.\n", opts.Filter)
+		} else {
+			fmt.Fprintln(bw, "No payees found.")
+		}
+		return bw.Flush()
 	}
 
-	fmt.Printf("Payees:\n\n")
+	fmt.Fprintf(bw, "Payees:\n\n")
+	if tableStyle == TableStylePlain {
+		fmt.Fprintf(bw, "%-*s  %s\n", maxName, "Name", "ID")
+		fmt.Fprintf(bw, "%s\n", strings.Repeat("-", maxName+2+36))
 
-	maxName := 20
-	for _, p := range filtered {
-		if len(p.Name) > maxName && len(p.Name) <= 40 {
-			maxName = len(p.Name)
-		}
+		eachPayee(payees, opts, func(p *api.Payee) error {
+			_, err := fmt.Fprintf(bw, "%s  %s\n", padCell(p.Name, maxName, false), p.ID)
+			return err
+		})
+	} else {
+		// Bordered styles need every row before drawing the first line
+		tbl := table{columns: []tableColumn{
+			{Header: "Name", MinWidth: maxName, MaxWidth: 40},
+			{Header: "ID", MinWidth: 36},
+		}}
+		eachPayee(payees, opts, func(p *api.Payee) error {
+			tbl.addRow(p.Name, p.ID)
+			return nil
+		})
+		tbl.render(bw)
+	}
+
+	fmt.Fprintf(bw, "\n%d payee(s)\n", total)
+	return bw.Flush()
+}
+
+// sortPayees returns payees ordered by name (case-insensitive) or ID.
+// Any other key keeps API order. The input slice is not modified.
+func sortPayees(payees []*api.Payee, by string, reverse bool) []*api.Payee {
+	if (by != "name" && by != "id") && !reverse {
+		return payees
 	}
 
-	fmt.Printf("%-*s  %s\n", maxName, "Name", "ID")
-	fmt.Printf("%s\n", strings.Repeat("-", maxName+2+36))
+	sorted := make([]*api.Payee, len(payees))
+	copy(sorted, payees)
 
-	for _, p := range filtered {
-		fmt.Printf("%-*s  %s\n", maxName, p.Name, p.ID)
+	switch by {
+	case "name":
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return lessByName(sorted[i].Name, sorted[j].Name, sorted[i].ID, sorted[j].ID)
+		})
+	case "id":
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].ID < sorted[j].ID
+		})
 	}
+	if reverse {
+		reverseSlice(sorted)
+	}
+	return sorted
+}
 
-	fmt.Printf("\n%d payee(s)\n", len(filtered))
-	return nil
+// lessByName orders by case-insensitive name, breaking ties by ID so the
+// result is fully deterministic.
+func lessByName(nameA, nameB, idA, idB string) bool {
+	a, b := strings.ToLower(nameA), strings.ToLower(nameB)
+	if a != b {
+		return a < b
+	}
+	return idA < idB
+}
+
+// reverseSlice reverses s in place.
+func reverseSlice[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
 }
@@ -0,0 +1,366 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+// makePayees builds n synthetic payees, like a large long-lived budget.
+func makePayees(n int) []*api.Payee {
+	payees := make([]*api.Payee, 0, n)
+	for i := 0; i < n; i++ {
+		payees = append(payees, &api.Payee{
+			ID:   fmt.Sprintf("00000000-0000-0000-0000-%012d", i),
+			Name: fmt.Sprintf("Payee %d", i),
+		})
+	}
+	return payees
+}
+
+// TestWritePayees tests filtering, limits and output formats for payees.
+func TestWritePayees(t *testing.T) {
+	payees := []*api.Payee{
+		{ID: "p1", Name: "Coffee Shop"},
+		{ID: "p2", Name: "Grocery Store"},
+		{ID: "p3", Name: "Coffee Roasters"},
+		{ID: "p4", Name: "Old Coffee", Deleted: true},
+		{ID: "p5", Name: "Coffee Cart"},
+	}
+
+	t.Run("jsonl with filter and limit", func(t *testing.T) {
+		var buf bytes.Buffer
+		opts := PayeesOptions{Filter: "coffee", Limit: 2, JSONL: true}
+		if err := writePayees(&buf, "b1", payees, opts, false); err != nil {
+			t.Fatalf("writePayees failed: %v", err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+		}
+		var first PayeeItem
+		if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", lines[0], err)
+		}
+		if first.ID != "p1" {
+			t.Errorf("expected first payee p1, got %s", first.ID)
+		}
+	})
+
+	t.Run("json count honors limit", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writePayees(&buf, "b1", payees, PayeesOptions{Limit: 3}, true); err != nil {
+			t.Fatalf("writePayees failed: %v", err)
+		}
+		var output PayeesOutput
+		if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if output.BudgetID != "b1" {
+			t.Errorf("expected budget_id b1, got %q", output.BudgetID)
+		}
+		if output.Count != 3 || len(output.Payees) != 3 {
+			t.Errorf("expected 3 payees, got count=%d len=%d", output.Count, len(output.Payees))
+		}
+	})
+
+	t.Run("csv with filter and limit", func(t *testing.T) {
+		SetCSVOutput(true)
+		defer SetCSVOutput(false)
+		var buf bytes.Buffer
+		if err := writePayees(&buf, "b1", payees, PayeesOptions{Filter: "coffee", Limit: 2}, false); err != nil {
+			t.Fatalf("writePayees failed: %v", err)
+		}
+		want := "name,id\nCoffee Shop,p1\nCoffee Roasters,p3\n"
+		if buf.String() != want {
+			t.Errorf("CSV = %q, want %q", buf.String(), want)
+		}
+	})
+
+	t.Run("human skips deleted", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := writePayees(&buf, "b1", payees, PayeesOptions{Filter: "coffee"}, false); err != nil {
+			t.Fatalf("writePayees failed: %v", err)
+		}
+		output := buf.String()
+		if strings.Contains(output, "Old Coffee") {
+			t.Error("expected deleted payee to be skipped")
+		}
+		if !strings.Contains(output, "3 payee(s)") {
+			t.Errorf("expected 3 payees in summary, got %q", output)
+		}
+	})
+}
+
+// TestWriteCategories_Limit tests that limits apply across category groups.
+func TestWriteCategories_Limit(t *testing.T) {
+	groups := []*api.CategoryGroup{
+		{ID: "g1", Name: "Bills", Categories: []*api.Category{
+			{ID: "c1", Name: "Rent"},
+			{ID: "c2", Name: "Electric", Hidden: true},
+			{ID: "c3", Name: "Water"},
+		}},
+		{ID: "g2", Name: "Everyday", Categories: []*api.Category{
+			{ID: "c4", Name: "Groceries"},
+			{ID: "c5", Name: "Dining"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := writeCategories(&buf, "b1", groups, CategoriesOptions{Limit: 3, JSONL: true}, false); err != nil {
+		t.Fatalf("writeCategories failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d: %q", len(lines), buf.String())
+	}
+	var last CategoryLine
+	if err := json.Unmarshal([]byte(lines[2]), &last); err != nil {
+		t.Fatalf("invalid JSON line %q: %v", lines[2], err)
+	}
+	if last.ID != "c4" || last.GroupName != "Everyday" {
+		t.Errorf("expected c4 in Everyday, got %s in %s", last.ID, last.GroupName)
+	}
+}
+
+// BenchmarkWritePayees_JSON measures the buffered single-object output for 5000 payees.
+func BenchmarkWritePayees_JSON(b *testing.B) {
+	payees := makePayees(5000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		writePayees(io.Discard, "b1", payees, PayeesOptions{}, true)
+	}
+}
+
+// BenchmarkWritePayees_JSONL measures streamed JSON Lines output for 5000 payees.
+func BenchmarkWritePayees_JSONL(b *testing.B) {
+	payees := makePayees(5000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		writePayees(io.Discard, "b1", payees, PayeesOptions{JSONL: true}, false)
+	}
+}
+
+// BenchmarkWritePayees_CSV measures streamed CSV output for 5000 payees.
+func BenchmarkWritePayees_CSV(b *testing.B) {
+	SetCSVOutput(true)
+	defer SetCSVOutput(false)
+	payees := makePayees(5000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		writePayees(io.Discard, "b1", payees, PayeesOptions{}, false)
+	}
+}
+
+// BenchmarkWritePayees_Limit measures short-circuiting a large list with --limit.
+func BenchmarkWritePayees_Limit(b *testing.B) {
+	payees := makePayees(5000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		writePayees(io.Discard, "b1", payees, PayeesOptions{Limit: 20, JSONL: true}, false)
+	}
+}
+
+// TestWritePayees_Sort tests that --sort orders both human and JSON output.
+func TestWritePayees_Sort(t *testing.T) {
+	payees := []*api.Payee{
+		{ID: "p2", Name: "coffee shop"},
+		{ID: "p3", Name: "Bakery"},
+		{ID: "p1", Name: "Diner"},
+	}
+
+	tests := []struct {
+		name     string
+		opts     PayeesOptions
+		expected []string
+	}{
+		{"api order", PayeesOptions{Sort: "none"}, []string{"p2", "p3", "p1"}},
+		{"by name", PayeesOptions{Sort: "name"}, []string{"p3", "p2", "p1"}},
+		{"by id", PayeesOptions{Sort: "id"}, []string{"p1", "p2", "p3"}},
+		{"by name reversed", PayeesOptions{Sort: "name", Reverse: true}, []string{"p1", "p2", "p3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" json", func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writePayees(&buf, "b1", payees, tt.opts, true); err != nil {
+				t.Fatalf("writePayees failed: %v", err)
+			}
+			var output PayeesOutput
+			if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			var got []string
+			for _, p := range output.Payees {
+				got = append(got, p.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("got %v, want %v", got, tt.expected)
+			}
+		})
+
+		t.Run(tt.name+" human", func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writePayees(&buf, "b1", payees, tt.opts, false); err != nil {
+				t.Fatalf("writePayees failed: %v", err)
+			}
+			output := buf.String()
+			last := -1
+			for _, id := range tt.expected {
+				idx := strings.Index(output, id)
+				if idx <= last {
+					t.Errorf("expected %s after previous payee in %q", id, output)
+				}
+				last = idx
+			}
+		})
+	}
+
+	if payees[0].ID != "p2" {
+		t.Error("sorting must not reorder the caller's slice")
+	}
+}
+
+// TestWriteCategories_Sort tests that --sort orders groups and categories.
+func TestWriteCategories_Sort(t *testing.T) {
+	groups := []*api.CategoryGroup{
+		{ID: "g2", Name: "Everyday", Categories: []*api.Category{
+			{ID: "c4", Name: "Groceries"},
+			{ID: "c3", Name: "dining"},
+		}},
+		{ID: "g1", Name: "Bills", Categories: []*api.Category{
+			{ID: "c2", Name: "Water"},
+			{ID: "c1", Name: "Rent"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := writeCategories(&buf, "b1", groups, CategoriesOptions{Sort: "name"}, true); err != nil {
+		t.Fatalf("writeCategories failed: %v", err)
+	}
+	var output CategoriesOutput
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	var got []string
+	for _, g := range output.CategoryGroups {
+		for _, c := range g.Categories {
+			got = append(got, c.ID)
+		}
+	}
+	if strings.Join(got, ",") != "c1,c2,c3,c4" {
+		t.Errorf("json order = %v, want [c1 c2 c3 c4]", got)
+	}
+
+	buf.Reset()
+	if err := writeCategories(&buf, "b1", groups, CategoriesOptions{Sort: "name", Reverse: true}, false); err != nil {
+		t.Fatalf("writeCategories failed: %v", err)
+	}
+	human := buf.String()
+	if strings.Index(human, "Everyday") > strings.Index(human, "Bills") {
+		t.Errorf("expected Everyday before Bills when reversed, got %q", human)
+	}
+	if strings.Index(human, "Groceries") > strings.Index(human, "dining") {
+		t.Errorf("expected Groceries before dining when reversed, got %q", human)
+	}
+
+	if groups[0].Categories[0].ID != "c4" {
+		t.Error("sorting must not reorder the caller's categories")
+	}
+}
+
+// TestWriteCategories_GroupAndAmounts tests --group and --with-amounts.
+func TestWriteCategories_GroupAndAmounts(t *testing.T) {
+	groups := []*api.CategoryGroup{
+		{ID: "g1", Name: "Monthly Bills", Categories: []*api.Category{
+			{ID: "c1", Name: "Rent", Budgeted: 1500000, Activity: -1500000},
+		}},
+		{ID: "g2", Name: "Everyday", Categories: []*api.Category{
+			{ID: "c2", Name: "Groceries", Budgeted: 400000, Activity: -120000, Balance: 280000},
+		}},
+	}
+
+	var buf bytes.Buffer
+	opts := CategoriesOptions{Group: "bills", WithAmounts: true}
+	if err := writeCategories(&buf, "b1", groups, opts, true); err != nil {
+		t.Fatalf("writeCategories failed: %v", err)
+	}
+	var output CategoriesOutput
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(output.CategoryGroups) != 1 || output.CategoryGroups[0].ID != "g1" {
+		t.Fatalf("expected only Monthly Bills, got %+v", output.CategoryGroups)
+	}
+	rent := output.CategoryGroups[0].Categories[0]
+	if rent.Budgeted == nil || *rent.Budgeted != 1500000 || rent.Balance == nil || *rent.Balance != 0 {
+		t.Errorf("amounts missing or wrong: %+v", rent)
+	}
+	if !strings.Contains(buf.String(), `"balance": 0`) {
+		t.Error("a zero balance should still be written with --with-amounts")
+	}
+
+	// Without --with-amounts the JSON is unchanged
+	buf.Reset()
+	if err := writeCategories(&buf, "b1", groups, CategoriesOptions{}, true); err != nil {
+		t.Fatalf("writeCategories failed: %v", err)
+	}
+	if strings.Contains(buf.String(), "budgeted") {
+		t.Errorf("amounts written without --with-amounts: %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := writeCategories(&buf, "b1", groups, CategoriesOptions{Group: "every", WithAmounts: true}, false); err != nil {
+		t.Fatalf("writeCategories failed: %v", err)
+	}
+	human := buf.String()
+	if strings.Contains(human, "Rent") || !strings.Contains(human, "$280.00") || !strings.Contains(human, "c2") {
+		t.Errorf("unexpected human output:\n%s", human)
+	}
+
+	if err := writeCategories(&buf, "b1", groups, CategoriesOptions{Group: "savings"}, false); err == nil {
+		t.Error("expected an error for a group that matches nothing")
+	}
+}
+
+func TestFindPayee(t *testing.T) {
+	payees := []*api.Payee{
+		{ID: "p1", Name: "Whole Foods"},
+		{ID: "p2", Name: "Whole Foods Market"},
+		{ID: "p3", Name: "Costco"},
+		{ID: "p4", Name: "Costco Gas", Deleted: true},
+	}
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr string
+	}{
+		{"whole foods", "p1", ""}, // exact beats the longer partial match
+		{"market", "p2", ""},
+		{"p3", "p3", ""},
+		{"cost", "p3", ""}, // deleted payees don't count
+		{"whole", "", "multiple payees match"},
+		{"trader", "", "no payee found"},
+	}
+
+	for _, tt := range tests {
+		got, err := findPayee(payees, tt.name)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("findPayee(%q) error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got.ID != tt.want {
+			t.Errorf("findPayee(%q) = %v, %v; want %s", tt.name, got, err, tt.want)
+		}
+	}
+}
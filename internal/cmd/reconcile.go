@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+// ReconcilePayee is the payee of balance adjustments, the same name YNAB
+// uses when reconciling in the app.
+const ReconcilePayee = "Reconciliation Balance Adjustment"
+
+// ReconcileOutput represents the JSON output for the reconcile command.
+type ReconcileOutput struct {
+	BudgetID                string `json:"budget_id"`
+	AccountID               string `json:"account_id"`
+	AccountName             string `json:"account_name"`
+	StatementBalance        int64  `json:"statement_balance"`
+	ClearedBalance          int64  `json:"cleared_balance"`
+	Adjustment              int64  `json:"adjustment"`
+	AdjustmentDisplay       string `json:"adjustment_display"`
+	DryRun                  bool   `json:"dry_run"`
+	AdjustmentTransactionID string `json:"adjustment_transaction_id,omitempty"`
+}
+
+// ReconcileOptions holds the parameters for the reconcile command.
+type ReconcileOptions struct {
+	Account          string // Account name, alias or ID
+	StatementBalance int64  // Balance on the statement, in milliunits
+	DryRun           bool   // Report the adjustment without creating it
+}
+
+// ReconcileCmd compares a statement balance with an account's cleared
+// balance and, when they differ, creates a cleared adjustment transaction
+// for the difference.
+//
+// On-budget adjustments are categorized as Ready to Assign, like the ones
+// YNAB creates itself.
+func ReconcileCmd(client *api.Client, opts ReconcileOptions, jsonOutput bool) error {
+	if opts.Account == "" {
+		return fmt.Errorf("account is required")
+	}
+
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	accounts, err := client.GetAccounts(budgetID)
+	if err != nil {
+		return fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	// Off-budget accounts have statements too, closed ones don't
+	var candidates []*api.Account
+	for _, acc := range accounts {
+		if !acc.Closed && !acc.Deleted {
+			candidates = append(candidates, acc)
+		}
+	}
+
+	account, err := matchAccount(candidates, opts.Account)
+	if err != nil {
+		return err
+	}
+
+	adjustment := opts.StatementBalance - account.ClearedBalance
+
+	var txnID string
+	if adjustment != 0 && !opts.DryRun {
+		var categoryID string
+		if account.OnBudget {
+			groups, err := client.GetCategories(budgetID)
+			if err != nil {
+				return fmt.Errorf("failed to get categories: %w", err)
+			}
+			categoryID = readyToAssignCategoryID(groups)
+		}
+
+		txn, err := client.CreateTransaction(&api.TransactionRequest{
+			BudgetID:   budgetID,
+			AccountID:  account.ID,
+			Date:       transform.FormatDate(time.Now()),
+			Amount:     adjustment,
+			PayeeName:  ReconcilePayee,
+			CategoryID: categoryID,
+			Cleared:    "cleared",
+			Approved:   true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create adjustment: %w", err)
+		}
+		txnID = txn.ID
+	}
+
+	if jsonOutput {
+		output := ReconcileOutput{
+			BudgetID:                budgetID,
+			AccountID:               account.ID,
+			AccountName:             account.Name,
+			StatementBalance:        opts.StatementBalance,
+			ClearedBalance:          account.ClearedBalance,
+			Adjustment:              adjustment,
+			AdjustmentDisplay:       transform.FormatCurrency(adjustment),
+			DryRun:                  opts.DryRun,
+			AdjustmentTransactionID: txnID,
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	fmt.Printf("Account:           %s\n", account.Name)
+	fmt.Printf("Statement balance: %s\n", transform.FormatCurrency(opts.StatementBalance))
+	fmt.Printf("Cleared balance:   %s\n", transform.FormatCurrency(account.ClearedBalance))
+	fmt.Println()
+
+	switch {
+	case adjustment == 0:
+		fmt.Println("Balances match; no adjustment needed.")
+	case opts.DryRun:
+		fmt.Printf("Would create a %s adjustment (%s).\n", transform.FormatCurrency(adjustment), ReconcilePayee)
+	default:
+		fmt.Printf("Created a %s adjustment (%s).\n", transform.FormatCurrency(adjustment), ReconcilePayee)
+		fmt.Printf("Transaction ID: %s\n", txnID)
+	}
+	return nil
+}
+
+// readyToAssignCategoryID returns the ID of the internal inflow category
+// ("Inflow: Ready to Assign", formerly "Inflow: To be Budgeted"), or "" if the
+// budget has none.
+func readyToAssignCategoryID(groups []*api.CategoryGroup) string {
+	for _, g := range groups {
+		if g.Name != "Internal Master Category" {
+			continue
+		}
+		for _, c := range g.Categories {
+			if strings.HasPrefix(c.Name, "Inflow:") {
+				return c.ID
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestReadyToAssignCategoryID(t *testing.T) {
+	groups := []*api.CategoryGroup{
+		{Name: "Everyday", Categories: []*api.Category{
+			{ID: "groceries", Name: "Groceries"},
+			{ID: "decoy", Name: "Inflow: Side Hustle"},
+		}},
+		{Name: "Internal Master Category", Categories: []*api.Category{
+			{ID: "uncategorized", Name: "Uncategorized"},
+			{ID: "rta", Name: "Inflow: Ready to Assign"},
+		}},
+	}
+	if got := readyToAssignCategoryID(groups); got != "rta" {
+		t.Errorf("readyToAssignCategoryID = %q, want rta", got)
+	}
+
+	if got := readyToAssignCategoryID(groups[:1]); got != "" {
+		t.Errorf("readyToAssignCategoryID without the internal group = %q, want empty", got)
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+// noPayee labels transactions without a payee in the payee report.
+const noPayee = "(no payee)"
+
+// PayeeReportOptions holds the window and display options for PayeeReportCmd.
+type PayeeReportOptions struct {
+	SinceDate        string // YYYY-MM-DD, today, Nd, Nw or Nm (default: DefaultSince, then 30d)
+	DefaultSince     string // default_since from config
+	Top              int    // Payees listed per table
+	Net              bool   // One list of net totals instead of separate outflows and inflows
+	IncludeTransfers bool   // Count transfers between accounts
+}
+
+// PayeeReportOutput represents the JSON output for report payees. Without
+// --net, Outflows and Inflows are lists and Payees is null; with it, the
+// other way round.
+type PayeeReportOutput struct {
+	BudgetID  string       `json:"budget_id"`
+	SinceDate string       `json:"since_date"`
+	Net       bool         `json:"net"`
+	Outflows  []PayeeTotal `json:"outflows"`
+	Inflows   []PayeeTotal `json:"inflows"`
+	Payees    []PayeeTotal `json:"payees"`
+}
+
+// PayeeTotal is the sum of one payee's transactions. Total is negative for
+// money out, following YNAB's sign convention.
+type PayeeTotal struct {
+	Payee string `json:"payee"`
+	Total int64  `json:"total"`
+	Count int    `json:"count"`
+}
+
+// PayeeReportCmd ranks payees by how much money went to or came from them
+// since a date.
+func PayeeReportCmd(client *api.Client, opts PayeeReportOptions, jsonOutput bool) error {
+	if opts.Top < 1 {
+		return fmt.Errorf("--top must be at least 1")
+	}
+
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	sinceDate, err := resolveSinceDate(opts.SinceDate, opts.DefaultSince, time.Now())
+	if err != nil {
+		return err
+	}
+
+	transactions, err := client.GetTransactions(budgetID, sinceDate)
+	if err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
+	}
+	transactions = filterTransactions(transactions, "", "", "")
+	if !opts.IncludeTransfers {
+		transactions = withoutTransfers(transactions)
+	}
+
+	output := PayeeReportOutput{BudgetID: budgetID, SinceDate: sinceDate, Net: opts.Net}
+	if opts.Net {
+		output.Payees = topPayees(totalByPayee(transactions, 0), opts.Top)
+	} else {
+		output.Outflows = topPayees(totalByPayee(transactions, -1), opts.Top)
+		output.Inflows = topPayees(totalByPayee(transactions, 1), opts.Top)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	window := describeWindow(sinceDate, "")
+	if opts.Net {
+		printPayeeTotals(fmt.Sprintf("Net by payee (%s):", window), output.Payees)
+		return nil
+	}
+	printPayeeTotals(fmt.Sprintf("Spending by payee (%s):", window), output.Outflows)
+	fmt.Println()
+	printPayeeTotals(fmt.Sprintf("Income by payee (%s):", window), output.Inflows)
+	return nil
+}
+
+// totalByPayee sums transactions per payee. sign picks outflows (-1) or
+// inflows (1); 0 nets both, counting every transaction.
+func totalByPayee(transactions []*api.Transaction, sign int) []PayeeTotal {
+	byPayee := make(map[string]*PayeeTotal)
+	var order []string
+	for _, t := range transactions {
+		if (sign < 0 && t.Amount >= 0) || (sign > 0 && t.Amount <= 0) {
+			continue
+		}
+		name := t.PayeeName
+		if name == "" {
+			name = noPayee
+		}
+		total, ok := byPayee[name]
+		if !ok {
+			total = &PayeeTotal{Payee: name}
+			byPayee[name] = total
+			order = append(order, name)
+		}
+		total.Total += t.Amount
+		total.Count++
+	}
+
+	totals := make([]PayeeTotal, 0, len(order))
+	for _, name := range order {
+		totals = append(totals, *byPayee[name])
+	}
+	return totals
+}
+
+// topPayees sorts totals by size, largest first, ties by name, and keeps at
+// most top of them.
+func topPayees(totals []PayeeTotal, top int) []PayeeTotal {
+	sort.SliceStable(totals, func(i, j int) bool {
+		a, b := absAmount(totals[i].Total), absAmount(totals[j].Total)
+		if a != b {
+			return a > b
+		}
+		return totals[i].Payee < totals[j].Payee
+	})
+	return totals[:min(top, len(totals))]
+}
+
+func printPayeeTotals(title string, totals []PayeeTotal) {
+	fmt.Println(title)
+	if len(totals) == 0 {
+		fmt.Println("  None.")
+		return
+	}
+	fmt.Println()
+	tbl := table{columns: []tableColumn{
+		{Header: "Payee", MinWidth: 15, MaxWidth: 30},
+		{Header: "Total", Right: true, MinWidth: 12},
+		{Header: "Count", Right: true},
+	}}
+	for _, p := range totals {
+		tbl.addRow(p.Payee, formatAmount(p.Total), fmt.Sprintf("%d", p.Count))
+	}
+	tbl.render(os.Stdout)
+}
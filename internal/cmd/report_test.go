@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestTotalByPayee(t *testing.T) {
+	transactions := []*api.Transaction{
+		{PayeeName: "Grocer", Amount: -50000},
+		{PayeeName: "Cafe", Amount: -4500},
+		{PayeeName: "Grocer", Amount: -30000},
+		{PayeeName: "Grocer", Amount: 10000}, // refund
+		{PayeeName: "Employer", Amount: 2000000},
+		{PayeeName: "", Amount: -1000},
+	}
+
+	tests := []struct {
+		name string
+		sign int
+		top  int
+		want []PayeeTotal
+	}{
+		{"outflows", -1, 10, []PayeeTotal{
+			{Payee: "Grocer", Total: -80000, Count: 2},
+			{Payee: "Cafe", Total: -4500, Count: 1},
+			{Payee: noPayee, Total: -1000, Count: 1},
+		}},
+		{"inflows", 1, 10, []PayeeTotal{
+			{Payee: "Employer", Total: 2000000, Count: 1},
+			{Payee: "Grocer", Total: 10000, Count: 1},
+		}},
+		{"net, top 2", 0, 2, []PayeeTotal{
+			{Payee: "Employer", Total: 2000000, Count: 1},
+			{Payee: "Grocer", Total: -70000, Count: 3},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := topPayees(totalByPayee(transactions, tt.sign), tt.top)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("row %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
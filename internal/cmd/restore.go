@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/storage"
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+// RestoreOutput represents the JSON output for the restore command.
+type RestoreOutput struct {
+	BudgetID     string          `json:"budget_id"`
+	RestoredFrom string          `json:"restored_from"` // ID of the deleted transaction
+	Transaction  TransactionItem `json:"transaction"`
+}
+
+// RestoreListOutput represents the JSON output for restore --list.
+type RestoreListOutput struct {
+	BudgetID string      `json:"budget_id"`
+	Deleted  []TrashItem `json:"deleted"`
+}
+
+// TrashItem is one deleted transaction in restore --list.
+type TrashItem struct {
+	DeletedAt string `json:"deleted_at"`
+	TransactionItem
+}
+
+// RestoreCmd recreates a transaction removed by `delete` from the copy kept
+// in the trash. YNAB assigns the restored transaction a new ID.
+func RestoreCmd(client *api.Client, transactionID string, jsonOutput bool) error {
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	entries, err := storage.LoadTrash()
+	if err != nil {
+		return err
+	}
+	i := storage.FindTrash(entries, budgetID, transactionID)
+	if i < 0 {
+		return fmt.Errorf("transaction %s is not in the trash\n\nRun 'ynab restore --list' to see recently deleted transactions", transactionID)
+	}
+	deleted := entries[i].Transaction
+
+	restored, err := client.CreateTransaction(restoreRequest(budgetID, deleted))
+	if err != nil {
+		return fmt.Errorf("failed to restore transaction: %w", err)
+	}
+
+	// Restoring twice would duplicate the transaction
+	entries = append(entries[:i], entries[i+1:]...)
+	if err := storage.SaveTrash(entries); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: restored, but could not update the trash: %v\n", err)
+	}
+
+	if jsonOutput {
+		item := newTransactionItem(restored)
+		item.BudgetID = budgetID
+		output := RestoreOutput{
+			BudgetID:     budgetID,
+			RestoredFrom: transactionID,
+			Transaction:  item,
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	fmt.Println("Transaction restored!")
+	fmt.Println()
+	fmt.Printf("ID:       %s (was %s)\n", restored.ID, transactionID)
+	fmt.Printf("Date:     %s\n", restored.Date)
+	fmt.Printf("Amount:   %s\n", transform.FormatCurrency(restored.Amount))
+	fmt.Printf("Payee:    %s\n", restored.PayeeName)
+	fmt.Printf("Category: %s\n", restored.CategoryName)
+	fmt.Printf("Account:  %s\n", restored.AccountName)
+	return nil
+}
+
+// RestoreListCmd lists the default budget's transactions in the trash,
+// most recently deleted first.
+func RestoreListCmd(client *api.Client, jsonOutput bool) error {
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	entries, err := storage.LoadTrash()
+	if err != nil {
+		return err
+	}
+	var kept []storage.TrashEntry
+	for _, e := range entries {
+		if e.BudgetID == budgetID && e.Transaction != nil {
+			kept = append(kept, e)
+		}
+	}
+
+	items := make([]TrashItem, 0, len(kept))
+	for _, e := range kept {
+		items = append(items, TrashItem{
+			DeletedAt:       e.DeletedAt.Format(time.RFC3339),
+			TransactionItem: newTransactionItem(e.Transaction),
+		})
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(RestoreListOutput{BudgetID: budgetID, Deleted: items})
+	}
+
+	if len(items) == 0 {
+		fmt.Println("The trash is empty.")
+		return nil
+	}
+
+	tbl := table{columns: []tableColumn{
+		{Header: "Deleted", MinWidth: 16},
+		{Header: "Date", MinWidth: 12},
+		{Header: "Payee", MinWidth: 15, MaxWidth: 30},
+		{Header: "Amount", Right: true, MinWidth: 12},
+		{Header: "Account", MinWidth: 10, MaxWidth: 15},
+		{Header: "ID"},
+	}}
+	for _, e := range kept {
+		t := e.Transaction
+		tbl.addRow(e.DeletedAt.Local().Format("2006-01-02 15:04"), t.Date, t.PayeeName,
+			formatAmount(t.Amount), t.AccountName, t.ID)
+	}
+	tbl.render(os.Stdout)
+	fmt.Println("\nRestore one with: ynab restore <id>")
+	return nil
+}
+
+// restoreRequest rebuilds the create request for a deleted transaction. The
+// payee ID is reused, so a transfer is recreated as a transfer. The import
+// ID is dropped: YNAB would treat the restored copy as a duplicate import.
+func restoreRequest(budgetID string, t *api.Transaction) *api.TransactionRequest {
+	req := &api.TransactionRequest{
+		BudgetID:   budgetID,
+		AccountID:  t.AccountID,
+		Date:       t.Date,
+		Amount:     t.Amount,
+		PayeeID:    t.PayeeID,
+		PayeeName:  t.PayeeName,
+		CategoryID: t.CategoryID,
+		Memo:       t.Memo,
+		Cleared:    t.Cleared,
+		Approved:   t.Approved,
+		FlagColor:  t.FlagColor,
+	}
+	for _, s := range t.Subtransactions {
+		if s.Deleted {
+			continue
+		}
+		req.Subtransactions = append(req.Subtransactions, api.SubTransactionRequest{
+			Amount:     s.Amount,
+			CategoryID: s.CategoryID,
+			Memo:       s.Memo,
+		})
+	}
+	// A split's parent has no category of its own
+	if len(req.Subtransactions) > 0 {
+		req.CategoryID = ""
+	}
+	return req
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestRestoreRequest(t *testing.T) {
+	deleted := &api.Transaction{
+		ID:         "txn-1",
+		Date:       "2025-03-01",
+		Amount:     -100000,
+		AccountID:  "acc-1",
+		PayeeID:    "payee-1",
+		PayeeName:  "Costco",
+		CategoryID: "cat-split",
+		Memo:       "weekly shop",
+		Cleared:    "cleared",
+		Approved:   true,
+		FlagColor:  "blue",
+		ImportID:   "YNAB:-100000:2025-03-01:1",
+		Deleted:    true,
+		Subtransactions: []*api.SubTransaction{
+			{ID: "sub-1", Amount: -60000, CategoryID: "cat-groceries"},
+			{ID: "sub-2", Amount: -40000, CategoryID: "cat-household", Memo: "bulk"},
+		},
+	}
+
+	req := restoreRequest("budget-1", deleted)
+	if err := req.Validate(); err != nil {
+		t.Fatalf("restore request invalid: %v", err)
+	}
+	if req.BudgetID != "budget-1" || req.AccountID != "acc-1" || req.Date != "2025-03-01" ||
+		req.Amount != -100000 || req.PayeeID != "payee-1" || req.Memo != "weekly shop" ||
+		req.Cleared != "cleared" || !req.Approved || req.FlagColor != "blue" {
+		t.Errorf("restore request = %+v", req)
+	}
+	if req.ImportID != "" {
+		t.Errorf("ImportID = %q, want it dropped", req.ImportID)
+	}
+	if req.CategoryID != "" {
+		t.Errorf("split parent CategoryID = %q, want empty", req.CategoryID)
+	}
+	if len(req.Subtransactions) != 2 || req.Subtransactions[1].CategoryID != "cat-household" ||
+		req.Subtransactions[1].Memo != "bulk" {
+		t.Errorf("subtransactions = %+v", req.Subtransactions)
+	}
+
+	plain := restoreRequest("budget-1", &api.Transaction{AccountID: "acc-1", Date: "2025-03-02", CategoryID: "cat-1"})
+	if plain.CategoryID != "cat-1" || plain.Subtransactions != nil {
+		t.Errorf("plain restore request = %+v", plain)
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/joeyhipolito/ynab-cli/internal/api"
 	"github.com/joeyhipolito/ynab-cli/internal/transform"
@@ -12,25 +15,73 @@ import (
 
 // ScheduledOutput represents the JSON output for the scheduled command.
 type ScheduledOutput struct {
+	BudgetID     string          `json:"budget_id"`
 	Transactions []ScheduledItem `json:"scheduled_transactions"`
 	Count        int             `json:"count"`
 }
 
 // ScheduledItem represents a scheduled transaction in the output.
 type ScheduledItem struct {
-	ID           string `json:"id"`
-	DateNext     string `json:"date_next"`
-	Frequency    string `json:"frequency"`
-	Amount       int64  `json:"amount"`
+	ID            string   `json:"id"`
+	DateNext      string   `json:"date_next"`
+	Frequency     string   `json:"frequency"`
+	Amount        int64    `json:"amount"`
+	AmountDisplay string   `json:"amount_display"`
+	PayeeName     string   `json:"payee_name"`
+	CategoryName  string   `json:"category_name"`
+	AccountName   string   `json:"account_name"`
+	Memo          string   `json:"memo,omitempty"`
+	Upcoming      []string `json:"upcoming,omitempty"` // with --upcoming: the next dates, from date_next
+}
+
+// ScheduleAddOutput represents the JSON output for schedule add.
+type ScheduleAddOutput struct {
+	BudgetID      string `json:"budget_id"`
+	ID            string `json:"id"`
+	DateFirst     string `json:"date_first"`
+	DateNext      string `json:"date_next"`
+	Frequency     string `json:"frequency"`
+	Amount        int64  `json:"amount"`
 	AmountDisplay string `json:"amount_display"`
-	PayeeName    string `json:"payee_name"`
-	CategoryName string `json:"category_name"`
-	AccountName  string `json:"account_name"`
-	Memo         string `json:"memo,omitempty"`
+	Payee         string `json:"payee"`
+	Category      string `json:"category,omitempty"`
+	Account       string `json:"account"`
+	AccountID     string `json:"account_id"`
+	Memo          string `json:"memo,omitempty"`
+	FlagColor     string `json:"flag_color,omitempty"`
+}
+
+// ScheduleAddOptions holds the parameters for schedule add.
+type ScheduleAddOptions struct {
+	Amount    string // Dollar amount, an outflow unless it starts with +
+	Payee     string // Payee name (required)
+	Frequency string // One of api.ScheduledFrequencies (default: monthly)
+	Date      string // First occurrence, YYYY-MM-DD, in the future (required)
+	Category  string // Category name (optional)
+	Account   string // Account name (optional - uses DefaultAccount, then the first on-budget account, if empty)
+	Memo      string // Memo (optional)
+	Flag      string // Flag color (optional)
+
+	DefaultAccount string // default_account from config
+}
+
+// maxScheduleYears is how far ahead YNAB accepts the first date of a
+// scheduled transaction.
+const maxScheduleYears = 5
+
+// scheduledOccurrence is one projected date of a scheduled transaction.
+type scheduledOccurrence struct {
+	Date      string
+	Scheduled *api.ScheduledTransaction
 }
 
-// ScheduledCmd lists all scheduled/recurring transactions.
-func ScheduledCmd(client *api.Client, jsonOutput bool) error {
+// ScheduledCmd lists all scheduled/recurring transactions. With upcoming > 0
+// each one is expanded into its next upcoming dates.
+func ScheduledCmd(client *api.Client, upcoming int, jsonOutput bool) error {
+	if upcoming < 0 {
+		return fmt.Errorf("--upcoming must be a positive number")
+	}
+
 	budgetID, err := client.GetDefaultBudgetID()
 	if err != nil {
 		return err
@@ -51,6 +102,7 @@ func ScheduledCmd(client *api.Client, jsonOutput bool) error {
 
 	if jsonOutput {
 		output := ScheduledOutput{
+			BudgetID:     budgetID,
 			Transactions: make([]ScheduledItem, 0, len(filtered)),
 			Count:        len(filtered),
 		}
@@ -65,6 +117,7 @@ func ScheduledCmd(client *api.Client, jsonOutput bool) error {
 				CategoryName:  s.CategoryName,
 				AccountName:   s.AccountName,
 				Memo:          s.Memo,
+				Upcoming:      upcomingDates(s, upcoming),
 			})
 		}
 		encoder := json.NewEncoder(os.Stdout)
@@ -77,7 +130,21 @@ func ScheduledCmd(client *api.Client, jsonOutput bool) error {
 		return nil
 	}
 
-	fmt.Printf("Scheduled Transactions:\n\n")
+	rows := make([]scheduledOccurrence, 0, len(filtered))
+	if upcoming > 0 {
+		for _, s := range filtered {
+			for _, d := range upcomingDates(s, upcoming) {
+				rows = append(rows, scheduledOccurrence{Date: d, Scheduled: s})
+			}
+		}
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].Date < rows[j].Date })
+		fmt.Printf("Upcoming Scheduled Transactions (next %d each):\n\n", upcoming)
+	} else {
+		for _, s := range filtered {
+			rows = append(rows, scheduledOccurrence{Date: s.DateNext, Scheduled: s})
+		}
+		fmt.Printf("Scheduled Transactions:\n\n")
+	}
 
 	maxPayee := 15
 	maxCategory := 12
@@ -90,13 +157,18 @@ func ScheduledCmd(client *api.Client, jsonOutput bool) error {
 		}
 	}
 
+	dateHeader := "Next Date"
+	if upcoming > 0 {
+		dateHeader = "Date"
+	}
 	fmt.Printf("%-12s  %-14s  %-*s  %-*s  %12s\n",
-		"Next Date", "Frequency", maxPayee, "Payee", maxCategory, "Category", "Amount")
+		dateHeader, "Frequency", maxPayee, "Payee", maxCategory, "Category", "Amount")
 	fmt.Printf("%s\n", strings.Repeat("-", 12+14+maxPayee+maxCategory+12+8))
 
-	for _, s := range filtered {
+	for _, r := range rows {
+		s := r.Scheduled
 		fmt.Printf("%-12s  %-14s  %-*s  %-*s  %12s\n",
-			s.DateNext, formatFrequency(s.Frequency),
+			r.Date, formatFrequency(s.Frequency),
 			maxPayee, s.PayeeName, maxCategory, s.CategoryName,
 			transform.FormatCurrency(s.Amount))
 	}
@@ -105,6 +177,126 @@ func ScheduledCmd(client *api.Client, jsonOutput bool) error {
 	return nil
 }
 
+// ScheduleAddCmd creates a scheduled transaction. Accounts, categories and
+// amounts are read the same way as by add.
+func ScheduleAddCmd(client *api.Client, opts ScheduleAddOptions, jsonOutput bool) error {
+	if opts.Payee == "" {
+		return fmt.Errorf("payee is required")
+	}
+	if opts.Frequency == "" {
+		opts.Frequency = "monthly"
+	}
+	if !slices.Contains(api.ScheduledFrequencies, opts.Frequency) {
+		return fmt.Errorf("invalid --frequency: %s (expected %s)", opts.Frequency, strings.Join(api.ScheduledFrequencies, ", "))
+	}
+	if opts.Date == "" {
+		return fmt.Errorf("--date is required: the first date of the schedule (YYYY-MM-DD)")
+	}
+	date := transform.ParseDate(opts.Date)
+	if date.IsZero() {
+		return fmt.Errorf("invalid date format: %s (expected YYYY-MM-DD)", opts.Date)
+	}
+	today := transform.ParseDate(transform.FormatDate(time.Now()))
+	if !date.After(today) {
+		return fmt.Errorf("--date must be in the future: YNAB enters transactions due today or earlier itself")
+	}
+	if date.After(today.AddDate(maxScheduleYears, 0, 0)) {
+		return fmt.Errorf("--date must be at most %d years ahead", maxScheduleYears)
+	}
+	amount, err := parseAddAmount(opts.Amount)
+	if err != nil {
+		return err
+	}
+	flag, err := parseFlagColor(opts.Flag)
+	if err != nil {
+		return err
+	}
+
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+	accountID, accountName, err := findAccount(client, budgetID, opts.Account, opts.DefaultAccount)
+	if err != nil {
+		return err
+	}
+	var categoryID, categoryName string
+	if opts.Category != "" {
+		categoryID, categoryName, err = findCategory(client, budgetID, opts.Category)
+		if err != nil {
+			return err
+		}
+	}
+
+	scheduled, err := client.CreateScheduledTransaction(budgetID, &api.ScheduledTransactionRequest{
+		AccountID:  accountID,
+		Date:       opts.Date,
+		Frequency:  opts.Frequency,
+		Amount:     amount,
+		PayeeName:  expandAlias(opts.Payee),
+		CategoryID: categoryID,
+		Memo:       opts.Memo,
+		FlagColor:  flag,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create scheduled transaction: %w", err)
+	}
+
+	if jsonOutput {
+		output := ScheduleAddOutput{
+			BudgetID:      budgetID,
+			ID:            scheduled.ID,
+			DateFirst:     scheduled.DateFirst,
+			DateNext:      scheduled.DateNext,
+			Frequency:     scheduled.Frequency,
+			Amount:        scheduled.Amount,
+			AmountDisplay: transform.FormatCurrency(scheduled.Amount),
+			Payee:         scheduled.PayeeName,
+			Category:      categoryName,
+			Account:       accountName,
+			AccountID:     accountID,
+			Memo:          scheduled.Memo,
+			FlagColor:     scheduled.FlagColor,
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	fmt.Printf("Scheduled transaction created successfully!\n\n")
+	fmt.Printf("Next:      %s\n", formatDateHuman(scheduled.DateNext))
+	fmt.Printf("Repeats:   %s\n", formatFrequency(scheduled.Frequency))
+	fmt.Printf("Amount:    %s\n", transform.FormatCurrency(scheduled.Amount))
+	fmt.Printf("Payee:     %s\n", scheduled.PayeeName)
+	if categoryName != "" {
+		fmt.Printf("Category:  %s\n", categoryName)
+	} else {
+		fmt.Printf("Category:  Uncategorized\n")
+	}
+	fmt.Printf("Account:   %s\n", accountName)
+	if scheduled.Memo != "" {
+		fmt.Printf("Memo:      %s\n", scheduled.Memo)
+	}
+	if scheduled.FlagColor != "" {
+		fmt.Printf("Flag:      %s\n", scheduled.FlagColor)
+	}
+	fmt.Printf("\nScheduled transaction ID: %s\n", scheduled.ID)
+	return nil
+}
+
+// upcomingDates returns the next n dates of a scheduled transaction,
+// starting with its date_next. A one-time transaction has just the one.
+func upcomingDates(s *api.ScheduledTransaction, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	var dates []string
+	for _, d := range transform.ProjectOccurrences(transform.ParseDate(s.DateNext), s.Frequency, time.Time{}, n) {
+		dates = append(dates, transform.FormatDate(d))
+	}
+	return dates
+}
+
 func formatFrequency(freq string) string {
 	switch freq {
 	case "never":
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+func TestScheduleAddCmd(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	date := transform.FormatDate(time.Now().AddDate(0, 1, 0))
+
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/budgets/budget-1/scheduled_transactions":
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+			w.Write([]byte(`{"data":{"scheduled_transaction":{"id":"sched-1","date_first":"` + date + `","date_next":"` + date + `",
+				"frequency":"monthly","amount":-1500000,"account_id":"acc-1","payee_name":"Landlord"}}}`))
+		case r.URL.Path == "/budgets/budget-1/accounts":
+			w.Write([]byte(`{"data":{"accounts":[{"id":"acc-1","name":"Checking","type":"checking","on_budget":true}]}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := createTestClient(t, server)
+	client.SetDefaultBudgetID("budget-1")
+
+	opts := ScheduleAddOptions{Amount: "1500", Payee: "Landlord", Date: date}
+	out, err := captureStdout(t, func() error { return ScheduleAddCmd(client, opts, true) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"frequency":"monthly"`, `"amount":-1500000`, `"account_id":"acc-1"`, `"date":"` + date + `"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("request body %s is missing %s", body, want)
+		}
+	}
+	var output ScheduleAddOutput
+	if err := json.Unmarshal([]byte(out), &output); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if output.ID != "sched-1" || output.DateNext != date || output.Account != "Checking" {
+		t.Errorf("output = %+v", output)
+	}
+
+	yesterday := transform.FormatDate(time.Now().AddDate(0, 0, -1))
+	for _, tt := range []struct {
+		opts ScheduleAddOptions
+		want string
+	}{
+		{ScheduleAddOptions{Amount: "1", Payee: "A", Date: date, Frequency: "fortnightly"}, "invalid --frequency"},
+		{ScheduleAddOptions{Amount: "1", Payee: "A"}, "--date is required"},
+		{ScheduleAddOptions{Amount: "1", Payee: "A", Date: yesterday}, "must be in the future"},
+		{ScheduleAddOptions{Amount: "1", Payee: "A", Date: "2099-01-01"}, "at most 5 years"},
+	} {
+		if err := ScheduleAddCmd(client, tt.opts, true); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ScheduleAddCmd(%+v) = %v, want an error containing %q", tt.opts, err, tt.want)
+		}
+	}
+}
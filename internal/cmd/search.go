@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+// SearchOptions holds the parameters for the search command.
+type SearchOptions struct {
+	Query        string // Text to look for; may contain spaces
+	SinceDate    string // YYYY-MM-DD, today, Nd, Nw or Nm (default: DefaultSince, then 30d)
+	DefaultSince string // default_since from config
+	Limit        int    // Max results shown, most recent kept (0 = no limit)
+	Exact        bool   // Match whole words only
+}
+
+// SearchCmd finds transactions whose payee, memo or category contains the
+// query. Matching is case-insensitive; each result lists the fields that
+// matched.
+func SearchCmd(client *api.Client, opts SearchOptions, jsonOutput bool) error {
+	query := strings.TrimSpace(opts.Query)
+	if query == "" {
+		return fmt.Errorf("search query is required")
+	}
+	match := newSearchMatcher(query, opts.Exact)
+
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	sinceDate, err := resolveSinceDate(opts.SinceDate, opts.DefaultSince, time.Now())
+	if err != nil {
+		return err
+	}
+
+	transactions, err := client.GetTransactions(budgetID, sinceDate)
+	if err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	var results []*api.Transaction
+	var fields [][]string
+	for _, t := range transactions {
+		if t.Deleted {
+			continue
+		}
+		if matched := searchFields(t, match); len(matched) > 0 {
+			results = append(results, t)
+			fields = append(fields, matched)
+		}
+	}
+
+	// Keep the most recent results, like transactions --limit
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[len(results)-opts.Limit:]
+		fields = fields[len(fields)-opts.Limit:]
+	}
+
+	var total int64
+	for _, t := range results {
+		total += t.Amount
+	}
+
+	if jsonOutput {
+		output := TransactionsOutput{
+			BudgetID:     budgetID,
+			Transactions: make([]TransactionItem, 0, len(results)),
+			Count:        len(results),
+			Total:        total,
+		}
+		for i, t := range results {
+			item := newTransactionItem(t)
+			item.MatchedFields = fields[i]
+			output.Transactions = append(output.Transactions, item)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	if len(results) == 0 {
+		fmt.Printf("No transactions matching '%s' since %s.\n", query, sinceDate)
+		return nil
+	}
+
+	fmt.Printf("Transactions matching '%s' (since %s):\n\n", query, sinceDate)
+
+	tbl := table{columns: []tableColumn{
+		{Header: "Date", MinWidth: 12},
+		{Header: "Payee", MinWidth: 15, MaxWidth: 30},
+		{Header: "Category", MinWidth: 12, MaxWidth: 20},
+		{Header: "Amount", Right: true, MinWidth: 12},
+		{Header: "Account", MinWidth: 10, MaxWidth: 15},
+		{Header: "Matched"},
+		{Header: "Memo", MaxWidth: 30},
+	}}
+	for i, t := range results {
+		tbl.addRow(t.Date, t.PayeeName, t.CategoryName,
+			transform.FormatCurrency(t.Amount), t.AccountName,
+			strings.Join(fields[i], ", "), t.Memo)
+	}
+	tbl.render(os.Stdout)
+
+	fmt.Printf("\n%d transaction(s)\n", len(results))
+	return nil
+}
+
+// newSearchMatcher returns a case-insensitive matcher for query. With exact,
+// the query must stand as whole words: not preceded or followed by a letter
+// or digit.
+func newSearchMatcher(query string, exact bool) func(string) bool {
+	if !exact {
+		queryLower := strings.ToLower(query)
+		return func(s string) bool {
+			return strings.Contains(strings.ToLower(s), queryLower)
+		}
+	}
+	re := regexp.MustCompile(`(?i)(^|[^\pL\pN])` + regexp.QuoteMeta(query) + `($|[^\pL\pN])`)
+	return re.MatchString
+}
+
+// searchFields returns the names of the fields of t that match, in display
+// order.
+func searchFields(t *api.Transaction, match func(string) bool) []string {
+	var fields []string
+	if match(t.PayeeName) {
+		fields = append(fields, "payee")
+	}
+	if match(t.CategoryName) {
+		fields = append(fields, "category")
+	}
+	if match(t.Memo) {
+		fields = append(fields, "memo")
+	}
+	return fields
+}
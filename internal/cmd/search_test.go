@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestSearchFields(t *testing.T) {
+	txn := &api.Transaction{
+		PayeeName:    "Whole Foods Market",
+		CategoryName: "Groceries",
+		Memo:         "gas and groceries in Las Vegas",
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		exact bool
+		want  []string
+	}{
+		{"payee substring", "foods", false, []string{"payee"}},
+		{"case-insensitive", "WHOLE FOODS", false, []string{"payee"}},
+		{"several fields", "groceries", false, []string{"category", "memo"}},
+		{"substring inside a word", "vega", false, []string{"memo"}},
+		{"exact whole word", "gas", true, []string{"memo"}},
+		{"exact rejects part of a word", "vega", true, nil},
+		{"exact multi-word phrase", "whole foods", true, []string{"payee"}},
+		{"no match", "costco", false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := searchFields(txn, newSearchMatcher(tt.query, tt.exact))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("searchFields(%q, exact=%v) = %v, want %v", tt.query, tt.exact, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSearchMatcher_ExactQuotesMeta(t *testing.T) {
+	match := newSearchMatcher("A+B (co)", true)
+	if !match("paid a+b (co) today") {
+		t.Error("regexp metacharacters in the query should match literally")
+	}
+	if match("paid AAB co today") {
+		t.Error("query should not be treated as a regexp")
+	}
+}
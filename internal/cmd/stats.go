@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+// StatsOutput represents the JSON output for the stats command.
+type StatsOutput struct {
+	BudgetID      string          `json:"budget_id"`
+	Month         string          `json:"month"`
+	Income        int64           `json:"income"`
+	Spending      int64           `json:"spending"`     // category activity, negative when money went out
+	Saved         int64           `json:"saved"`        // income + spending
+	SavingsRate   *float64        `json:"savings_rate"` // percent of income; null without income
+	TopCategories []StatsCategory `json:"top_categories"`
+}
+
+// StatsCategory is one of the month's biggest spending categories.
+type StatsCategory struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Activity int64   `json:"activity"`
+	Share    float64 `json:"share"` // percent of the month's spending
+}
+
+// StatsCmd summarizes a month: income, spending, savings rate and the
+// categories with the most spending. An empty month defaults to the current one.
+func StatsCmd(client *api.Client, month string, top int, jsonOutput bool) error {
+	if top < 1 {
+		return fmt.Errorf("--top must be at least 1")
+	}
+
+	monthDate, err := assignMonth(month, time.Now())
+	if err != nil {
+		return err
+	}
+
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	m, err := client.GetMonth(budgetID, monthDate)
+	if err != nil {
+		return fmt.Errorf("failed to get month: %w", err)
+	}
+
+	output := summarizeMonthStats(m, top)
+	output.BudgetID = budgetID
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	fmt.Printf("Month: %s\n\n", output.Month[:7])
+	fmt.Printf("Income:        %s\n", formatAmount(output.Income))
+	fmt.Printf("Spending:      %s\n", formatAmount(output.Spending))
+	fmt.Printf("Saved:         %s\n", formatAmount(output.Saved))
+	if output.SavingsRate != nil {
+		fmt.Printf("Savings rate:  %.1f%%\n", *output.SavingsRate)
+	} else {
+		fmt.Printf("Savings rate:  n/a (no income)\n")
+	}
+
+	if len(output.TopCategories) == 0 {
+		fmt.Println("\nNo spending this month.")
+		return nil
+	}
+
+	fmt.Printf("\nTop %d categories by spending:\n\n", len(output.TopCategories))
+	tbl := table{columns: []tableColumn{
+		{Header: "Category", MinWidth: 15, MaxWidth: 25},
+		{Header: "Activity", Right: true, MinWidth: 12},
+		{Header: "Share", Right: true},
+	}}
+	for _, c := range output.TopCategories {
+		tbl.addRow(c.Name, formatAmount(c.Activity), fmt.Sprintf("%.1f%%", c.Share))
+	}
+	tbl.render(os.Stdout)
+	return nil
+}
+
+// summarizeMonthStats aggregates a month's category activity. Spending is
+// YNAB's total activity for the month, so refunds offset purchases; the top
+// categories are those with the most money out, at most top of them.
+func summarizeMonthStats(m *api.Month, top int) StatsOutput {
+	output := StatsOutput{
+		Month:         m.Month,
+		Income:        m.Income,
+		Spending:      m.Activity,
+		Saved:         m.Income + m.Activity,
+		TopCategories: make([]StatsCategory, 0),
+	}
+	if m.Income > 0 {
+		rate := float64(output.Saved) / float64(m.Income) * 100
+		output.SavingsRate = &rate
+	}
+
+	var spent []*api.Category
+	var totalOut int64
+	for _, c := range m.Categories {
+		if c.Deleted || c.Activity >= 0 {
+			continue
+		}
+		spent = append(spent, c)
+		totalOut += c.Activity
+	}
+	sort.SliceStable(spent, func(i, j int) bool { return spent[i].Activity < spent[j].Activity })
+
+	for _, c := range spent[:min(top, len(spent))] {
+		output.TopCategories = append(output.TopCategories, StatsCategory{
+			ID:       c.ID,
+			Name:     c.Name,
+			Activity: c.Activity,
+			Share:    float64(c.Activity) / float64(totalOut) * 100,
+		})
+	}
+	return output
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestSummarizeMonthStats(t *testing.T) {
+	month := &api.Month{
+		Month:    "2025-01-01",
+		Income:   5000000,
+		Activity: -4000000,
+		Categories: []*api.Category{
+			{ID: "rent", Name: "Rent", Activity: -2000000},
+			{ID: "groc", Name: "Groceries", Activity: -1000000},
+			{ID: "fun", Name: "Fun", Activity: -600000},
+			{ID: "gas", Name: "Gas", Activity: -400000},
+			{ID: "refund", Name: "Gifts", Activity: 50000},
+			{ID: "old", Name: "Old", Activity: -900000, Deleted: true},
+		},
+	}
+
+	got := summarizeMonthStats(month, 2)
+	if got.Saved != 1000000 || got.SavingsRate == nil || *got.SavingsRate != 20 {
+		t.Errorf("saved %d, rate %v", got.Saved, got.SavingsRate)
+	}
+	if len(got.TopCategories) != 2 || got.TopCategories[0].ID != "rent" || got.TopCategories[1].ID != "groc" {
+		t.Fatalf("top categories = %+v", got.TopCategories)
+	}
+	if got.TopCategories[0].Share != 50 {
+		t.Errorf("rent share = %v, want 50", got.TopCategories[0].Share)
+	}
+
+	if n := len(summarizeMonthStats(month, 10).TopCategories); n != 4 {
+		t.Errorf("top 10 listed %d categories, want the 4 with spending", n)
+	}
+}
+
+func TestSummarizeMonthStats_Empty(t *testing.T) {
+	got := summarizeMonthStats(&api.Month{Month: "2025-02-01"}, 5)
+	if got.SavingsRate != nil {
+		t.Errorf("savings rate = %v, want nil without income", *got.SavingsRate)
+	}
+	if got.TopCategories == nil || len(got.TopCategories) != 0 {
+		t.Errorf("top categories = %#v, want empty", got.TopCategories)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/joeyhipolito/ynab-cli/internal/api"
 	"github.com/joeyhipolito/ynab-cli/internal/transform"
@@ -20,6 +21,10 @@ type StatusOutput struct {
 	CurrencyCode     string `json:"currency_code,omitempty"`
 	CurrencySymbol   string `json:"currency_symbol,omitempty"`
 	AccountCount     int    `json:"account_count,omitempty"`
+	Month            string `json:"month,omitempty"`          // month the figures below come from
+	ToBeBudgeted     *int64 `json:"to_be_budgeted,omitempty"` // nil if no month is available
+	ToBeBudgetedText string `json:"to_be_budgeted_formatted,omitempty"` // in the budget's currency format
+	AgeOfMoney       *int   `json:"age_of_money,omitempty"`   // nil until YNAB has enough history
 }
 
 // StatusCmd retrieves and displays information about the default YNAB budget.
@@ -48,6 +53,11 @@ func StatusCmd(client *api.Client, jsonOutput bool) error {
 		return fmt.Errorf("budget %s not found", budgetID)
 	}
 
+	month, err := statusMonth(client, budget, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to get month: %w", err)
+	}
+
 	// If JSON output requested, marshal and print
 	if jsonOutput {
 		output := StatusOutput{
@@ -69,6 +79,15 @@ func StatusCmd(client *api.Client, jsonOutput bool) error {
 			output.AccountCount = len(budget.Accounts)
 		}
 
+		if month != nil {
+			output.Month = month.Month
+			output.ToBeBudgeted = &month.ToBeBudgeted
+			output.ToBeBudgetedText = transform.FormatCurrencyWithFormat(month.ToBeBudgeted, currencyFormat(budget.CurrencyFormat))
+			if month.AgeOfMoney > 0 {
+				output.AgeOfMoney = &month.AgeOfMoney
+			}
+		}
+
 		encoder := json.NewEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
@@ -107,9 +126,34 @@ func StatusCmd(client *api.Client, jsonOutput bool) error {
 		fmt.Printf("Accounts: %d total, %d on-budget\n", len(budget.Accounts), onBudgetCount)
 	}
 
+	if month != nil {
+		fmt.Printf("\nMonth: %s\n", formatMonth(month.Month))
+		fmt.Printf("To Be Budgeted: %s\n", formatAmountIn(month.ToBeBudgeted, budget.CurrencyFormat))
+		if month.AgeOfMoney > 0 {
+			fmt.Printf("Age of Money: %d days\n", month.AgeOfMoney)
+		} else {
+			fmt.Printf("Age of Money: not enough history yet\n")
+		}
+	}
+
 	return nil
 }
 
+// statusMonth fetches the current month for status. Near a month boundary
+// YNAB may not have created it yet; then the budget's latest month is used.
+// It returns nil if neither exists.
+func statusMonth(client *api.Client, budget *api.Budget, now time.Time) (*api.Month, error) {
+	current, _ := assignMonth("", now)
+	month, err := client.GetMonth(budget.ID, current)
+	if api.IsNotFoundError(err) && budget.LastMonth != "" && budget.LastMonth != current {
+		month, err = client.GetMonth(budget.ID, budget.LastMonth)
+	}
+	if api.IsNotFoundError(err) {
+		return nil, nil
+	}
+	return month, err
+}
+
 // formatLastModified formats a last modified timestamp for display.
 // YNAB returns ISO 8601 timestamps like "2024-01-15T10:30:00.000Z"
 func formatLastModified(timestamp string) string {
@@ -9,6 +9,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/joeyhipolito/ynab-cli/internal/api"
 )
@@ -43,45 +44,110 @@ func TestStatusCmd(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Create client with test server
-	_, err := api.NewClient("test-token")
-	if err != nil {
-		t.Fatalf("Failed to create client: %v", err)
-	}
-
-	// Override base URL to point to test server
-	// Use reflection or create a setter method in production code
-	// For now, we'll test the logic indirectly
+	client := createTestClient(t, server)
 
 	t.Run("human readable output", func(t *testing.T) {
-		// Capture stdout
-		oldStdout := os.Stdout
-		r, w, _ := os.Pipe()
-		os.Stdout = w
-
-		// Note: This test will fail because we can't override baseURL easily
-		// In a real scenario, we'd add a method to set baseURL for testing
-		// For now, we'll just verify the code compiles and has the right structure
-		_ = r // Prevent unused variable error
-
-		// Restore stdout
-		w.Close()
-		os.Stdout = oldStdout
+		output, err := captureStdout(t, func() error { return StatusCmd(client, false) })
+		if err != nil {
+			t.Fatalf("StatusCmd failed: %v", err)
+		}
+		for _, want := range []string{"Budget: Test Budget", "ID: test-budget-id", "Last Modified: 2024-01-15"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("output missing %q:\n%s", want, output)
+			}
+		}
 	})
 
 	t.Run("json output", func(t *testing.T) {
-		// Capture stdout
-		oldStdout := os.Stdout
-		r, w, _ := os.Pipe()
-		os.Stdout = w
+		output, err := captureStdout(t, func() error { return StatusCmd(client, true) })
+		if err != nil {
+			t.Fatalf("StatusCmd failed: %v", err)
+		}
+		var result StatusOutput
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Invalid JSON output: %v", err)
+		}
+		if result.BudgetID != "test-budget-id" || result.CurrencyCode != "USD" {
+			t.Errorf("unexpected output: %+v", result)
+		}
+	})
+}
 
-		// Similar limitation as above
-		_ = r
+// TestStatusCmd_CurrencyFormat tests that To Be Budgeted uses the budget's
+// currency format.
+func TestStatusCmd_CurrencyFormat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/budgets" {
+			io.WriteString(w, `{"data":{"budgets":[{"id":"b1","name":"Euro Budget","last_modified_on":"2024-01-15T10:30:00.000Z",
+				"currency_format":{"iso_code":"EUR","decimal_digits":2,"decimal_separator":",","group_separator":".",
+				"symbol_first":false,"currency_symbol":"€","display_symbol":true}}]}}`)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/budgets/b1/months/") {
+			io.WriteString(w, `{"data":{"month":{"month":"2024-01-01","to_be_budgeted":1234560}}}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	client := createTestClient(t, server)
+	client.SetDefaultBudgetID("b1")
 
-		// Restore stdout
-		w.Close()
-		os.Stdout = oldStdout
-	})
+	output, err := captureStdout(t, func() error { return StatusCmd(client, false) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output, "To Be Budgeted: 1.234,56 €") {
+		t.Errorf("output missing the EUR amount:\n%s", output)
+	}
+
+	output, err = captureStdout(t, func() error { return StatusCmd(client, true) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result StatusOutput
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Invalid JSON output: %v", err)
+	}
+	if result.ToBeBudgeted == nil || *result.ToBeBudgeted != 1234560 || result.ToBeBudgetedText != "1.234,56 €" {
+		t.Errorf("unexpected output: %+v", result)
+	}
+}
+
+func TestStatusMonth(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/budgets/b1/months/2026-02-01" {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error":{"id":"404.2","name":"resource_not_found","detail":"Resource not found"}}`)
+			return
+		}
+		io.WriteString(w, `{"data":{"month":{"month":"2026-02-01","to_be_budgeted":125000,"age_of_money":42}}}`)
+	}))
+	defer server.Close()
+	client := createTestClient(t, server)
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	// March isn't created yet, so the budget's last month is used
+	month, err := statusMonth(client, &api.Budget{ID: "b1", LastMonth: "2026-02-01"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if month == nil || month.Month != "2026-02-01" || month.ToBeBudgeted != 125000 || month.AgeOfMoney != 42 {
+		t.Errorf("month = %+v, want February", month)
+	}
+	if len(requested) != 2 || requested[0] != "/budgets/b1/months/2026-03-01" {
+		t.Errorf("requested %v, want March then February", requested)
+	}
+
+	// No month at all leaves the figures out rather than failing
+	month, err = statusMonth(client, &api.Budget{ID: "b1"}, now)
+	if err != nil || month != nil {
+		t.Errorf("statusMonth() = %+v, %v; want nil, nil", month, err)
+	}
 }
 
 func TestStatusOutput_JSON(t *testing.T) {
@@ -191,10 +257,10 @@ func TestStatusCmd_NoBudgets(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// This test demonstrates the structure but can't easily test without
-	// being able to inject the server URL into the client
-	// In production code, we'd add dependency injection for the HTTP client
-	_ = server
+	client := createTestClient(t, server)
+	if err := StatusCmd(client, false); err == nil || !strings.Contains(err.Error(), "no budgets found") {
+		t.Errorf("expected no budgets error, got %v", err)
+	}
 }
 
 // TestStatusCmd_Integration is an example of how integration testing would work
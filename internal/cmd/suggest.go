@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+)
+
+// maxSuggestions is how many "did you mean" names a not-found error offers.
+const maxSuggestions = 3
+
+// suggestNames returns up to maxSuggestions candidates closest to name by
+// edit distance, ignoring case. Only candidates within a third of name's
+// length (at least 2 edits) count, so a typo suggests the intended name but
+// an unrelated word suggests nothing. Ties keep the candidates' order.
+func suggestNames(name string, candidates []string) []string {
+	name = strings.ToLower(name)
+	limit := max(2, len([]rune(name))/3)
+
+	type scored struct {
+		name     string
+		distance int
+	}
+	var close []scored
+	seen := make(map[string]bool)
+	for _, c := range candidates {
+		if seen[c] {
+			continue
+		}
+		seen[c] = true
+		if d := levenshtein(name, strings.ToLower(c)); d <= limit {
+			close = append(close, scored{c, d})
+		}
+	}
+	slices.SortStableFunc(close, func(a, b scored) int { return a.distance - b.distance })
+
+	var names []string
+	for _, s := range close[:min(len(close), maxSuggestions)] {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+// didYouMean formats suggestions for the end of an error message.
+func didYouMean(suggestions []string) string {
+	return "Did you mean: " + strings.Join(suggestions, ", ") + "?"
+}
+
+// levenshtein returns the number of single-rune insertions, deletions and
+// substitutions needed to turn a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
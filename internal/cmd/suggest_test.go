@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"groceries", "groceries", 0},
+		{"grocries", "groceries", 1},
+		{"kitten", "sitting", 3},
+		{"café", "cafe", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestNames(t *testing.T) {
+	names := []string{"Groceries", "Gas", "Dining Out", "Rent", "Gifts", "Grooming"}
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"Grocries", []string{"Groceries"}},
+		{"dinning out", []string{"Dining Out"}},
+		{"gis", []string{"Gas", "Gifts"}},
+		{"Vacation", nil},
+	}
+	for _, tt := range tests {
+		if got := suggestNames(tt.name, names); !slices.Equal(got, tt.want) {
+			t.Errorf("suggestNames(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMatchSuggestsOnTypo(t *testing.T) {
+	groups := []*api.CategoryGroup{{
+		ID: "grp-1", Name: "Everyday",
+		Categories: []*api.Category{{ID: "cat-1", Name: "Groceries"}, {ID: "cat-2", Name: "Gas"}},
+	}}
+	_, _, err := matchCategory(groups, "Grocries")
+	if err == nil || !strings.Contains(err.Error(), "Did you mean: Groceries?") {
+		t.Errorf("category error = %v, want a Groceries suggestion", err)
+	}
+
+	accounts := []*api.Account{{ID: "acc-1", Name: "Checking"}, {ID: "acc-2", Name: "Savings"}}
+	_, err = matchAccount(accounts, "Chekcing")
+	if err == nil || !strings.Contains(err.Error(), "Did you mean: Checking?") {
+		t.Errorf("account error = %v, want a Checking suggestion", err)
+	}
+	// Nothing close: fall back to listing the accounts
+	_, err = matchAccount(accounts, "Brokerage")
+	if err == nil || !strings.Contains(err.Error(), "Available accounts: Checking, Savings") {
+		t.Errorf("account error = %v, want the account list", err)
+	}
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+// SweepOptions holds the parameters for the sweep command.
+type SweepOptions struct {
+	To        string   // target category name
+	FromGroup string   // only sweep categories in this group
+	Min       int64    // only sweep balances above this amount (milliunits)
+	Exclude   []string // category names to leave alone
+	Month     string   // YYYY-MM or YYYY-MM-DD (default: current month)
+	DryRun    bool
+}
+
+// SweepOutput represents the JSON output for the sweep command.
+type SweepOutput struct {
+	BudgetID     string           `json:"budget_id"`
+	Month        string           `json:"month"`
+	DryRun       bool             `json:"dry_run"`
+	To           MoveCategoryInfo `json:"to"`
+	Swept        []SweepItem      `json:"swept"`
+	Total        int64            `json:"total"`
+	TotalDisplay string           `json:"total_display"`
+}
+
+// SweepItem represents a single category emptied by the sweep.
+type SweepItem struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	GroupName      string `json:"group_name"`
+	Amount         int64  `json:"amount"`
+	AmountDisplay  string `json:"amount_display"`
+	BudgetedBefore int64  `json:"budgeted_before"`
+	BudgetedAfter  int64  `json:"budgeted_after"`
+}
+
+// sweepSkipGroups are groups whose balances must never be swept: the internal
+// group holds Ready to Assign, and credit card payment categories hold money
+// already earmarked for paying cards.
+var sweepSkipGroups = map[string]bool{
+	"Internal Master Category": true,
+	"Credit Card Payments":     true,
+}
+
+// SweepCmd moves every positive leftover category balance into a target category.
+func SweepCmd(client *api.Client, opts SweepOptions, jsonOutput bool) error {
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	month := opts.Month
+	if month == "" {
+		now := time.Now()
+		month = fmt.Sprintf("%04d-%02d-01", now.Year(), now.Month())
+	} else if len(month) == 7 {
+		month += "-01"
+	}
+
+	groups, err := client.GetCategories(budgetID)
+	if err != nil {
+		return fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	toID := findCategoryID(groups, opts.To)
+	if toID == "" {
+		return fmt.Errorf("no category found matching '%s'", opts.To)
+	}
+
+	if opts.FromGroup != "" {
+		groupName := findGroupName(groups, opts.FromGroup)
+		if groupName == "" {
+			return fmt.Errorf("no category group found matching '%s'", opts.FromGroup)
+		}
+		opts.FromGroup = groupName
+	}
+
+	excludeIDs := make(map[string]bool)
+	for _, name := range opts.Exclude {
+		id := findCategoryID(groups, name)
+		if id == "" {
+			return fmt.Errorf("no category found matching '%s'", name)
+		}
+		excludeIDs[id] = true
+	}
+
+	monthData, err := client.GetMonth(budgetID, month)
+	if err != nil {
+		return fmt.Errorf("failed to get month data: %w", err)
+	}
+
+	plan := planSweep(groups, monthData.Categories, toID, opts, excludeIDs)
+
+	var toBudgeted int64
+	for _, c := range monthData.Categories {
+		if c.ID == toID {
+			toBudgeted = c.Budgeted
+		}
+	}
+
+	// Empty each source first, then credit the target once with whatever
+	// was actually taken so a failure part-way never loses money.
+	var swept []SweepItem
+	var total int64
+	var sweepErr error
+	for _, item := range plan {
+		if !opts.DryRun {
+			if _, err := client.UpdateCategoryBudget(item.ID, item.BudgetedAfter, month, budgetID); err != nil {
+				sweepErr = fmt.Errorf("failed to update category '%s': %w", item.Name, err)
+				break
+			}
+		}
+		swept = append(swept, item)
+		total += item.Amount
+	}
+
+	newToBudgeted := toBudgeted + total
+	if !opts.DryRun && total != 0 {
+		if _, err := client.UpdateCategoryBudget(toID, newToBudgeted, month, budgetID); err != nil {
+			return fmt.Errorf("failed to update target category (swept %s out of %d categories but could not assign it): %w",
+				transform.FormatCurrency(total), len(swept), err)
+		}
+	}
+	if sweepErr != nil {
+		return fmt.Errorf("%w (moved %s from %d categories before stopping)", sweepErr, transform.FormatCurrency(total), len(swept))
+	}
+
+	toName := findCategoryName(groups, toID)
+
+	if jsonOutput {
+		output := SweepOutput{
+			BudgetID: budgetID,
+			Month:    month[:7],
+			DryRun:   opts.DryRun,
+			To: MoveCategoryInfo{
+				ID:             toID,
+				Name:           toName,
+				BudgetedBefore: toBudgeted,
+				BudgetedAfter:  newToBudgeted,
+			},
+			Swept:        make([]SweepItem, 0, len(swept)),
+			Total:        total,
+			TotalDisplay: transform.FormatCurrency(total),
+		}
+		output.Swept = append(output.Swept, swept...)
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	if len(swept) == 0 {
+		fmt.Printf("Nothing to sweep into '%s' for %s.\n", toName, month[:7])
+		return nil
+	}
+
+	if opts.DryRun {
+		fmt.Printf("Would sweep into '%s' (%s):\n\n", toName, month[:7])
+	} else {
+		fmt.Printf("Swept into '%s' (%s):\n\n", toName, month[:7])
+	}
+
+	maxName := 20
+	for _, item := range swept {
+		if len(item.Name) > maxName {
+			maxName = len(item.Name)
+		}
+	}
+	for _, item := range swept {
+		fmt.Printf("  %-*s  %12s\n", maxName, item.Name, transform.FormatCurrency(item.Amount))
+	}
+	fmt.Printf("  %s\n", strings.Repeat("-", maxName+2+12))
+	fmt.Printf("  %-*s  %12s\n\n", maxName, "Total", transform.FormatCurrency(total))
+	fmt.Printf("  %s: %s -> %s\n", toName,
+		transform.FormatCurrency(toBudgeted), transform.FormatCurrency(newToBudgeted))
+
+	return nil
+}
+
+// planSweep selects the categories to empty into toID. Month categories carry
+// the balances; groups supply names, visibility and group membership.
+func planSweep(groups []*api.CategoryGroup, monthCategories []*api.Category, toID string, opts SweepOptions, excludeIDs map[string]bool) []SweepItem {
+	balances := make(map[string]*api.Category, len(monthCategories))
+	for _, c := range monthCategories {
+		balances[c.ID] = c
+	}
+
+	var plan []SweepItem
+	for _, g := range groups {
+		if g.Hidden || g.Deleted || sweepSkipGroups[g.Name] {
+			continue
+		}
+		if opts.FromGroup != "" && !strings.EqualFold(g.Name, opts.FromGroup) {
+			continue
+		}
+		for _, c := range g.Categories {
+			// Never sweep the target into itself
+			if c.ID == toID || c.Hidden || c.Deleted || excludeIDs[c.ID] {
+				continue
+			}
+			mc, ok := balances[c.ID]
+			if !ok || mc.Balance <= 0 || mc.Balance <= opts.Min {
+				continue
+			}
+			plan = append(plan, SweepItem{
+				ID:             c.ID,
+				Name:           c.Name,
+				GroupName:      g.Name,
+				Amount:         mc.Balance,
+				AmountDisplay:  transform.FormatCurrency(mc.Balance),
+				BudgetedBefore: mc.Budgeted,
+				BudgetedAfter:  mc.Budgeted - mc.Balance,
+			})
+		}
+	}
+	return plan
+}
+
+// findGroupName finds a category group by name (exact, then partial match).
+func findGroupName(groups []*api.CategoryGroup, filter string) string {
+	for _, g := range groups {
+		if strings.EqualFold(g.Name, filter) {
+			return g.Name
+		}
+	}
+	lower := strings.ToLower(filter)
+	for _, g := range groups {
+		if strings.Contains(strings.ToLower(g.Name), lower) {
+			return g.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+// TestPlanSweep tests which categories are selected for a sweep.
+func TestPlanSweep(t *testing.T) {
+	groups := []*api.CategoryGroup{
+		{ID: "g0", Name: "Internal Master Category", Categories: []*api.Category{
+			{ID: "rta", Name: "Inflow: Ready to Assign"},
+		}},
+		{ID: "g1", Name: "Credit Card Payments", Categories: []*api.Category{
+			{ID: "cc", Name: "Visa"},
+		}},
+		{ID: "g2", Name: "Everyday", Categories: []*api.Category{
+			{ID: "groceries", Name: "Groceries"},
+			{ID: "dining", Name: "Dining"},
+			{ID: "fun", Name: "Fun Money"},
+			{ID: "hidden", Name: "Old", Hidden: true},
+		}},
+		{ID: "g3", Name: "Goals", Categories: []*api.Category{
+			{ID: "savings", Name: "Savings"},
+			{ID: "vacation", Name: "Vacation"},
+		}},
+	}
+	month := []*api.Category{
+		{ID: "rta", Balance: 500000},
+		{ID: "cc", Balance: 300000},
+		{ID: "groceries", Budgeted: 400000, Balance: 25000},
+		{ID: "dining", Budgeted: 100000, Balance: 500},
+		{ID: "fun", Budgeted: 50000, Balance: -10000},
+		{ID: "hidden", Balance: 90000},
+		{ID: "savings", Budgeted: 200000, Balance: 1000000},
+		{ID: "vacation", Budgeted: 0, Balance: 75000},
+	}
+
+	tests := []struct {
+		name     string
+		opts     SweepOptions
+		exclude  map[string]bool
+		expected []string
+	}{
+		{"all positive", SweepOptions{}, nil, []string{"groceries", "dining", "vacation"}},
+		{"min threshold", SweepOptions{Min: 1000}, nil, []string{"groceries", "vacation"}},
+		{"from group", SweepOptions{FromGroup: "Everyday"}, nil, []string{"groceries", "dining"}},
+		{"exclusions", SweepOptions{}, map[string]bool{"vacation": true}, []string{"groceries", "dining"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := planSweep(groups, month, "savings", tt.opts, tt.exclude)
+			if len(plan) != len(tt.expected) {
+				t.Fatalf("expected %d categories, got %d: %+v", len(tt.expected), len(plan), plan)
+			}
+			for i, id := range tt.expected {
+				if plan[i].ID != id {
+					t.Errorf("plan[%d] = %s, want %s", i, plan[i].ID, id)
+				}
+			}
+		})
+	}
+
+	t.Run("empties each balance", func(t *testing.T) {
+		plan := planSweep(groups, month, "savings", SweepOptions{}, nil)
+		if plan[0].Amount != 25000 || plan[0].BudgetedAfter != 375000 {
+			t.Errorf("expected groceries to move 25000 leaving 375000 budgeted, got %+v", plan[0])
+		}
+	})
+}
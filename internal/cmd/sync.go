@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/storage"
+)
+
+// SyncOutput represents the JSON output for the sync command.
+type SyncOutput struct {
+	BudgetID          string          `json:"budget_id"`
+	Full              bool            `json:"full"` // whether everything was fetched
+	PreviousKnowledge int64           `json:"previous_knowledge"`
+	ServerKnowledge   int64           `json:"server_knowledge"`
+	Accounts          storage.Changes `json:"accounts"`
+	Categories        storage.Changes `json:"categories"`
+	Transactions      storage.Changes `json:"transactions"`
+	CachePath         string          `json:"cache_path"`
+}
+
+// SyncCmd updates the local cache of the default budget. After the first
+// sync only entities changed since the stored server knowledge are fetched;
+// full discards the cache and fetches everything.
+func SyncCmd(client *api.Client, full bool, jsonOutput bool) error {
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	snap := storage.NewSnapshot(budgetID)
+	if !full {
+		snap, err = storage.Load(budgetID)
+		if err != nil {
+			return err
+		}
+	}
+	previous := snap.ServerKnowledge
+
+	detail, err := client.GetBudget(budgetID, previous)
+	if err != nil {
+		return fmt.Errorf("failed to get budget: %w", err)
+	}
+
+	result := snap.Apply(detail)
+	snap.SyncedAt = time.Now()
+	if err := storage.Save(snap); err != nil {
+		return err
+	}
+	// Renamed or new accounts and categories show up in lookups right away
+	if err := storage.InvalidateLookup(budgetID); err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		output := SyncOutput{
+			BudgetID:          budgetID,
+			Full:              previous == 0,
+			PreviousKnowledge: previous,
+			ServerKnowledge:   snap.ServerKnowledge,
+			Accounts:          result.Accounts,
+			Categories:        result.Categories,
+			Transactions:      result.Transactions,
+			CachePath:         storage.Path(budgetID),
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	if previous == 0 {
+		fmt.Printf("Full sync (server knowledge %d)\n\n", snap.ServerKnowledge)
+	} else {
+		fmt.Printf("Synced changes since server knowledge %d (now %d)\n\n", previous, snap.ServerKnowledge)
+	}
+
+	tbl := table{columns: []tableColumn{
+		{Header: "", MinWidth: 12},
+		{Header: "Added", Right: true},
+		{Header: "Updated", Right: true},
+		{Header: "Deleted", Right: true},
+		{Header: "Cached", Right: true},
+	}}
+	addSyncRow(&tbl, "Accounts", result.Accounts, len(snap.Accounts))
+	addSyncRow(&tbl, "Categories", result.Categories, len(snap.Categories))
+	addSyncRow(&tbl, "Transactions", result.Transactions, len(snap.Transactions))
+	tbl.render(os.Stdout)
+
+	fmt.Printf("\nCache: %s\n", storage.Path(budgetID))
+	return nil
+}
+
+// addSyncRow adds one entity type's changes to the sync summary table.
+func addSyncRow(tbl *table, name string, c storage.Changes, cached int) {
+	tbl.addRow(name, strconv.Itoa(c.Added), strconv.Itoa(c.Updated), strconv.Itoa(c.Deleted), strconv.Itoa(cached))
+}
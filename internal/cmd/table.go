@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Table styles accepted by --table-style.
+const (
+	TableStylePlain    = "plain"
+	TableStyleBox      = "box"
+	TableStyleMarkdown = "markdown"
+)
+
+// tableStyle is the style human-readable tables are rendered in. Set once at
+// startup from --table-style.
+var tableStyle = TableStylePlain
+
+// SetTableStyle selects the style used for human-readable tables.
+func SetTableStyle(style string) error {
+	switch style {
+	case TableStylePlain, TableStyleBox, TableStyleMarkdown:
+		tableStyle = style
+		return nil
+	}
+	return fmt.Errorf("invalid table style: %s (expected plain, box or markdown)", style)
+}
+
+// tableColumn describes one column of a table.
+type tableColumn struct {
+	Header   string
+	Right    bool // right-align, used for amounts and counts
+	MinWidth int
+	MaxWidth int // cells wider than this don't widen the column and are truncated (0 = no limit)
+}
+
+// table is a column-aligned table rendered in the configured style. Footer
+// rows (totals) are set off from the body by a rule.
+type table struct {
+	columns []tableColumn
+	rows    [][]string
+	footer  [][]string
+	indent  string // prefix for every line; ignored by markdown
+	// hideHeader leaves out the header row in plain style, for tables whose
+	// columns are introduced by a heading. Box and markdown always show it.
+	hideHeader bool
+}
+
+// addRow appends a body row.
+func (t *table) addRow(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// addFooter appends a footer row.
+func (t *table) addFooter(cells ...string) {
+	t.footer = append(t.footer, cells)
+}
+
+// widths returns the display width of each column. A cell over the column's
+// MaxWidth doesn't count, so one long name can't stretch the whole table.
+func (t *table) widths() []int {
+	widths := make([]int, len(t.columns))
+	for i, col := range t.columns {
+		widths[i] = col.MinWidth
+		if w := displayWidth(col.Header); w > widths[i] {
+			widths[i] = w
+		}
+	}
+	for _, row := range append(append([][]string{}, t.rows...), t.footer...) {
+		for i, cell := range row {
+			if i >= len(widths) {
+				break
+			}
+			w := displayWidth(cell)
+			if limit := t.columns[i].MaxWidth; limit > 0 && w > limit {
+				continue
+			}
+			if w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	return widths
+}
+
+// render writes the table to w in the configured style.
+func (t *table) render(w io.Writer) {
+	widths := t.widths()
+	switch tableStyle {
+	case TableStyleBox:
+		t.renderBox(w, widths)
+	case TableStyleMarkdown:
+		t.renderMarkdown(w, widths)
+	default:
+		t.renderPlain(w, widths)
+	}
+}
+
+// cells pads (and if needed truncates) row to the column widths.
+func (t *table) cells(row []string, widths []int) []string {
+	out := make([]string, len(widths))
+	for i := range widths {
+		cell := ""
+		if i < len(row) {
+			cell = row[i]
+		}
+		out[i] = padCell(truncateWidth(cell, widths[i]), widths[i], t.columns[i].Right)
+	}
+	return out
+}
+
+// renderPlain writes the two-space separated layout the commands have always
+// used. Trailing padding is trimmed so an empty last column leaves no spaces.
+func (t *table) renderPlain(w io.Writer, widths []int) {
+	ruleWidth := 2 * (len(widths) - 1)
+	for _, width := range widths {
+		ruleWidth += width
+	}
+	rule := t.indent + strings.Repeat("-", ruleWidth)
+
+	line := func(row []string) {
+		fmt.Fprintf(w, "%s%s\n", t.indent, strings.TrimRight(strings.Join(t.cells(row, widths), "  "), " "))
+	}
+
+	if !t.hideHeader {
+		line(t.headers())
+		fmt.Fprintln(w, rule)
+	}
+	for _, row := range t.rows {
+		line(row)
+	}
+	if len(t.footer) > 0 {
+		fmt.Fprintln(w, rule)
+		for _, row := range t.footer {
+			line(row)
+		}
+	}
+}
+
+// renderBox draws the table with box-drawing characters.
+func (t *table) renderBox(w io.Writer, widths []int) {
+	border := func(left, mid, right string) {
+		segments := make([]string, len(widths))
+		for i, width := range widths {
+			segments[i] = strings.Repeat("─", width+2)
+		}
+		fmt.Fprintf(w, "%s%s%s%s\n", t.indent, left, strings.Join(segments, mid), right)
+	}
+	line := func(row []string) {
+		fmt.Fprintf(w, "%s│ %s │\n", t.indent, strings.Join(t.cells(row, widths), " │ "))
+	}
+
+	border("┌", "┬", "┐")
+	line(t.headers())
+	border("├", "┼", "┤")
+	for _, row := range t.rows {
+		line(row)
+	}
+	if len(t.footer) > 0 {
+		border("├", "┼", "┤")
+		for _, row := range t.footer {
+			line(row)
+		}
+	}
+	border("└", "┴", "┘")
+}
+
+// renderMarkdown writes a GitHub-flavored Markdown table. Footer rows are
+// plain rows since Markdown has no table footer.
+func (t *table) renderMarkdown(w io.Writer, widths []int) {
+	line := func(row []string) {
+		escaped := make([]string, len(row))
+		for i, cell := range row {
+			escaped[i] = strings.ReplaceAll(stripANSI(cell), "|", `\|`)
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(t.cells(escaped, widths), " | "))
+	}
+
+	line(t.headers())
+	separators := make([]string, len(widths))
+	for i, width := range widths {
+		if width < 3 {
+			width = 3
+		}
+		if t.columns[i].Right {
+			separators[i] = strings.Repeat("-", width-1) + ":"
+		} else {
+			separators[i] = strings.Repeat("-", width)
+		}
+	}
+	fmt.Fprintf(w, "| %s |\n", strings.Join(separators, " | "))
+	for _, row := range append(append([][]string{}, t.rows...), t.footer...) {
+		line(row)
+	}
+}
+
+// headers returns the header row.
+func (t *table) headers() []string {
+	headers := make([]string, len(t.columns))
+	for i, col := range t.columns {
+		headers[i] = col.Header
+	}
+	return headers
+}
+
+// padCell pads s with spaces to width display columns.
+func padCell(s string, width int, right bool) string {
+	gap := width - displayWidth(s)
+	if gap <= 0 {
+		return s
+	}
+	if right {
+		return strings.Repeat(" ", gap) + s
+	}
+	return s + strings.Repeat(" ", gap)
+}
+
+// truncateWidth shortens s to at most width display columns, marking the cut
+// with "~". Color escapes are kept, and reset after the cut.
+func truncateWidth(s string, width int) string {
+	if displayWidth(s) <= width {
+		return s
+	}
+	var b strings.Builder
+	used := 0
+	for i := 0; i < len(s); {
+		if n := ansiLen(s[i:]); n > 0 {
+			b.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		rw := runeWidth(r)
+		if used+rw > width-1 {
+			break
+		}
+		b.WriteRune(r)
+		used += rw
+		i += size
+	}
+	if strings.Contains(s, "\x1b") {
+		return b.String() + "~" + ansiReset
+	}
+	return b.String() + "~"
+}
+
+// displayWidth returns the number of terminal columns s occupies. Wide East
+// Asian characters and emoji take two columns; combining marks, zero-width
+// joiners and color escapes take none. Multi-rune emoji sequences are
+// counted per rune, which overstates them but keeps the columns consistent.
+func displayWidth(s string) int {
+	width := 0
+	for i := 0; i < len(s); {
+		if n := ansiLen(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		width += runeWidth(r)
+		i += size
+	}
+	return width
+}
+
+// wideRanges are the East Asian Wide and Fullwidth blocks plus the emoji
+// blocks terminals render double-width.
+var wideRanges = []struct{ lo, hi rune }{
+	{0x1100, 0x115F},   // Hangul Jamo initials
+	{0x231A, 0x231B},   // watch, hourglass
+	{0x23E9, 0x23EC},   // media controls
+	{0x23F0, 0x23F3},   // clocks
+	{0x25FD, 0x25FE},   // small squares
+	{0x2614, 0x2615},   // umbrella, hot beverage
+	{0x2648, 0x2653},   // zodiac
+	{0x26A1, 0x26A1},   // high voltage
+	{0x26AA, 0x26AB},   // circles
+	{0x26BD, 0x26BE},   // soccer, baseball
+	{0x26C4, 0x26C5},   // snowman, sun behind cloud
+	{0x26D4, 0x26D4},   // no entry
+	{0x26EA, 0x26EA},   // church
+	{0x26F2, 0x26F5},   // fountain .. sailboat
+	{0x26FA, 0x26FD},   // tent .. fuel pump
+	{0x2705, 0x2705},   // check mark
+	{0x270A, 0x270B},   // raised fists
+	{0x2728, 0x2728},   // sparkles
+	{0x274C, 0x274E},   // cross marks
+	{0x2753, 0x2757},   // question and exclamation marks
+	{0x2795, 0x2797},   // plus, minus, divide
+	{0x27B0, 0x27BF},   // curly loops
+	{0x2B1B, 0x2B1C},   // large squares
+	{0x2B50, 0x2B55},   // star, circle
+	{0x2E80, 0x303E},   // CJK radicals, punctuation
+	{0x3041, 0x33FF},   // kana, CJK compatibility
+	{0x3400, 0x4DBF},   // CJK extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // fullwidth forms
+	{0xFFE0, 0xFFE6},   // fullwidth signs
+	{0x1F000, 0x1FAFF}, // emoji and pictographs
+	{0x20000, 0x3FFFD}, // CJK extensions B and beyond
+}
+
+// runeWidth returns the number of terminal columns r occupies.
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	for _, rg := range wideRanges {
+		if r < rg.lo {
+			break
+		}
+		if r <= rg.hi {
+			return 2
+		}
+	}
+	return 1
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func renderTable(t *testing.T, style string, tbl table) string {
+	t.Helper()
+	if err := SetTableStyle(style); err != nil {
+		t.Fatal(err)
+	}
+	defer SetTableStyle(TableStylePlain)
+
+	var buf bytes.Buffer
+	tbl.render(&buf)
+	return buf.String()
+}
+
+func sampleTable() table {
+	tbl := table{columns: []tableColumn{
+		{Header: "Payee", MinWidth: 6},
+		{Header: "Amount", Right: true},
+	}}
+	tbl.addRow("Grocer", "$12.50")
+	tbl.addRow("カフェ", "$4.00")
+	tbl.addFooter("Total", "$16.50")
+	return tbl
+}
+
+func TestTable_Plain(t *testing.T) {
+	got := renderTable(t, TableStylePlain, sampleTable())
+	want := "" +
+		"Payee   Amount\n" +
+		"--------------\n" +
+		"Grocer  $12.50\n" +
+		"カフェ   $4.00\n" +
+		"--------------\n" +
+		"Total   $16.50\n"
+	if got != want {
+		t.Errorf("plain table:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTable_Box(t *testing.T) {
+	got := renderTable(t, TableStyleBox, sampleTable())
+	want := "" +
+		"┌────────┬────────┐\n" +
+		"│ Payee  │ Amount │\n" +
+		"├────────┼────────┤\n" +
+		"│ Grocer │ $12.50 │\n" +
+		"│ カフェ │  $4.00 │\n" +
+		"├────────┼────────┤\n" +
+		"│ Total  │ $16.50 │\n" +
+		"└────────┴────────┘\n"
+	if got != want {
+		t.Errorf("box table:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTable_Markdown(t *testing.T) {
+	tbl := sampleTable()
+	tbl.addRow("A|B", "$0.00")
+	got := renderTable(t, TableStyleMarkdown, tbl)
+
+	for _, want := range []string{
+		"| Payee  | Amount |\n",
+		"| ------ | -----: |\n",
+		"| カフェ |  $4.00 |\n",
+		"| Total  | $16.50 |\n",
+		`| A\|B   |  $0.00 |`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown table missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestTable_HideHeaderAndTruncate(t *testing.T) {
+	tbl := table{
+		columns: []tableColumn{{Header: "Name", MinWidth: 4, MaxWidth: 6}},
+		indent:  "  ",
+		// Plain style skips the header; the long cell is cut to the column
+		hideHeader: true,
+	}
+	tbl.addRow("Short")
+	tbl.addRow("Much too long")
+
+	got := renderTable(t, TableStylePlain, tbl)
+	want := "  Short\n  Much~\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"Grocer", 6},
+		{"Café", 4},
+		{"Cafe\u0301", 4}, // combining accent
+		{"東京", 4},
+		{"한국", 4},
+		{"Pizza 🍕", 8},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := displayWidth(tt.s); got != tt.want {
+			t.Errorf("displayWidth(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+
+	if got := truncateWidth("東京タワー", 5); got != "東京~" {
+		t.Errorf("truncateWidth = %q, want 東京~", got)
+	}
+}
+
+func TestSetTableStyle(t *testing.T) {
+	defer SetTableStyle(TableStylePlain)
+	if err := SetTableStyle("fancy"); err == nil {
+		t.Error("expected an error for an unknown style")
+	}
+	if tableStyle != TableStylePlain {
+		t.Errorf("invalid style changed the current style to %q", tableStyle)
+	}
+}
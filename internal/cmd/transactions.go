@@ -1,203 +1,1393 @@
 package cmd
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/joeyhipolito/ynab-cli/internal/api"
 	"github.com/joeyhipolito/ynab-cli/internal/transform"
 )
 
+// scheduledLookaheadDays is how far ahead --include-scheduled projects
+// upcoming scheduled transactions.
+const scheduledLookaheadDays = 30
+
+// defaultSinceWindow is the built-in transactions window used when neither
+// --since nor the default_since config key is set.
+const defaultSinceWindow = "30d"
+
+// TransactionsOptions holds the filters and display options for TransactionsCmd.
+type TransactionsOptions struct {
+	SinceDate        string // YYYY-MM-DD, today, Nd, Nw or Nm (default: DefaultSince, then 30d)
+	UntilDate        string // YYYY-MM-DD or relative like SinceDate, inclusive; empty for no end
+	DefaultSince     string // default_since from config
+	Account          string // Account name filter
+	Category         string // Category name filter
+	Payee            string // Payee name substring filter
+	Limit            int    // Max actual transactions shown (0 = no limit)
+	IncludeScheduled bool   // Interleave projected scheduled transactions
+	GroupBy          string // "account" or "extracted" summarizes; "day", "week", "month" or "Nd" lists by period
+	WeekStart        string // "monday" (default, ISO weeks) or "sunday" for --group-by week
+	IncludeTransfers bool   // Count transfers between accounts in group totals
+	MemoGrep         string // Regexp; only transactions whose memo matches
+	Extract          string // Regexp whose first capture group is pulled from each memo
+	Approval         string // "approved" or "unapproved" keeps only those (default: both)
+	MinAmount        *int64 // Smallest amount kept, in milliunits, compared by size (nil = no bound)
+	MaxAmount        *int64 // Largest amount kept, in milliunits, compared by size (nil = no bound)
+	RunningBalance   bool   // Show the account balance after each transaction (needs Account)
+	JSONL            bool   // one JSON object per transaction, per line
+	NoTransfers      bool   // Leave out transfers between accounts
+	Sort             string // "date", "amount", "abs-amount" or "payee"; Limit then keeps the first rows (default: API order)
+	Desc             bool   // Reverse the Sort order
+
+	Watch    bool          // Keep polling and print only transactions not seen before
+	Interval time.Duration // Time between polls with Watch (default: DefaultWatchInterval)
+}
+
 // TransactionsOutput represents the JSON output for the transactions command.
 type TransactionsOutput struct {
-	Transactions []TransactionItem `json:"transactions"`
-	Count        int               `json:"count"`
+	BudgetID       string            `json:"budget_id"`
+	AccountID      string            `json:"account_id,omitempty"`
+	CategoryID     string            `json:"category_id,omitempty"`
+	Transactions   []TransactionItem `json:"transactions"`
+	Count          int               `json:"count"`
+	ScheduledCount int               `json:"scheduled_count,omitempty"`
+	Total          int64             `json:"total"`
+	ProjectedTotal int64             `json:"projected_total,omitempty"`
 }
 
 // TransactionItem represents a single transaction in the output.
 type TransactionItem struct {
-	ID           string `json:"id"`
-	Date         string `json:"date"`
-	Amount       int64  `json:"amount"`
+	BudgetID      string `json:"budget_id,omitempty"` // set for single-transaction outputs
+	ID            string `json:"id"`
+	Date          string `json:"date"`
+	Amount        int64  `json:"amount"`
 	AmountDisplay string `json:"amount_display"`
-	PayeeName    string `json:"payee_name"`
-	CategoryName string `json:"category_name"`
-	AccountName  string `json:"account_name"`
-	Memo         string `json:"memo,omitempty"`
-	Cleared      string `json:"cleared"`
-	Approved     bool   `json:"approved"`
+	PayeeName     string `json:"payee_name"`
+	CategoryName  string `json:"category_name"`
+	AccountName   string `json:"account_name"`
+	AccountID     string `json:"account_id,omitempty"`
+	Memo          string `json:"memo,omitempty"`
+	FlagColor     string `json:"flag_color,omitempty"`
+	Cleared       string `json:"cleared"`
+	Approved      bool   `json:"approved"`
+	Scheduled     bool   `json:"scheduled,omitempty"`
+	Extracted     string `json:"extracted,omitempty"`
+
+	// IsTransfer is set for a transfer between accounts; TransferAccountID
+	// is the account on the other side
+	IsTransfer        bool   `json:"is_transfer"`
+	TransferAccountID string `json:"transfer_account_id,omitempty"`
+
+	// MatchedFields lists the fields a search query matched (search only)
+	MatchedFields []string `json:"matched_fields,omitempty"`
+
+	// Matched is set when YNAB linked this transaction to an imported one
+	Matched              bool   `json:"matched,omitempty"`
+	MatchedTransactionID string `json:"matched_transaction_id,omitempty"`
+
+	// RunningBalance is the account balance after this transaction
+	// (transactions --running-balance only)
+	RunningBalance        *int64 `json:"running_balance,omitempty"`
+	RunningBalanceDisplay string `json:"running_balance_display,omitempty"`
+}
+
+// AccountSummaryOutput represents the JSON output for --group-by account.
+type AccountSummaryOutput struct {
+	BudgetID         string           `json:"budget_id"`
+	SinceDate        string           `json:"since_date"`
+	UntilDate        string           `json:"until_date,omitempty"`
+	IncludeTransfers bool             `json:"include_transfers"`
+	Accounts         []AccountSummary `json:"accounts"`
+}
+
+// AccountSummary represents one account's totals over the window.
+// Outflow is negative, following YNAB's sign convention.
+type AccountSummary struct {
+	Account   string `json:"account"`
+	AccountID string `json:"account_id"`
+	Count     int    `json:"count"`
+	Inflow    int64  `json:"inflow"`
+	Outflow   int64  `json:"outflow"`
+	Net       int64  `json:"net"`
+}
+
+// PeriodSummaryOutput represents the JSON output for --group-by day, week,
+// month or Nd.
+type PeriodSummaryOutput struct {
+	BudgetID  string          `json:"budget_id"`
+	SinceDate string          `json:"since_date"`
+	UntilDate string          `json:"until_date,omitempty"`
+	GroupBy   string          `json:"group_by"`
+	WeekStart string          `json:"week_start,omitempty"`
+	Periods   []PeriodSummary `json:"periods"`
+	Count     int             `json:"count"`
+	Total     int64           `json:"total"`
+}
+
+// PeriodSummary is one period's transactions and their subtotal. Start and
+// End are inclusive.
+type PeriodSummary struct {
+	Label        string            `json:"label"`
+	Start        string            `json:"start"`
+	End          string            `json:"end"`
+	Count        int               `json:"count"`
+	Subtotal     int64             `json:"subtotal"`
+	Transactions []TransactionItem `json:"transactions"`
+}
+
+// newTransactionItem converts an API transaction to its output form.
+func newTransactionItem(t *api.Transaction) TransactionItem {
+	return TransactionItem{
+		ID:                   t.ID,
+		Date:                 t.Date,
+		Amount:               t.Amount,
+		AmountDisplay:        transform.FormatCurrency(t.Amount),
+		PayeeName:            t.PayeeName,
+		CategoryName:         t.CategoryName,
+		AccountName:          t.AccountName,
+		AccountID:            t.AccountID,
+		Memo:                 t.Memo,
+		FlagColor:            t.FlagColor,
+		Cleared:              t.Cleared,
+		Approved:             t.Approved,
+		Matched:              t.MatchedTransactionID != "",
+		MatchedTransactionID: t.MatchedTransactionID,
+		IsTransfer:           t.TransferAccountID != "",
+		TransferAccountID:    t.TransferAccountID,
+	}
+}
+
+// ExtractedSummaryOutput represents the JSON output for --group-by extracted.
+type ExtractedSummaryOutput struct {
+	BudgetID         string             `json:"budget_id"`
+	SinceDate        string             `json:"since_date"`
+	UntilDate        string             `json:"until_date,omitempty"`
+	Pattern          string             `json:"pattern"`
+	IncludeTransfers bool               `json:"include_transfers"`
+	Groups           []ExtractedSummary `json:"groups"`
+}
+
+// ExtractedSummary represents the totals for one extracted memo value.
+// Value is empty for transactions whose memo didn't match.
+type ExtractedSummary struct {
+	Value   string `json:"value"`
+	Count   int    `json:"count"`
+	Inflow  int64  `json:"inflow"`
+	Outflow int64  `json:"outflow"`
+	Net     int64  `json:"net"`
+}
+
+// transactionRow is a transaction to display, either an actual transaction
+// or a projected occurrence of a scheduled one.
+type transactionRow struct {
+	*api.Transaction
+	Scheduled bool
 }
 
 // TransactionsCmd lists transactions with optional filters.
-func TransactionsCmd(client *api.Client, sinceDate, accountFilter, categoryFilter, payeeFilter string, limit int, jsonOutput bool) error {
+func TransactionsCmd(client *api.Client, opts TransactionsOptions, jsonOutput bool) error {
 	budgetID, err := client.GetDefaultBudgetID()
 	if err != nil {
 		return err
 	}
 
-	// Default since date: 30 days ago
-	if sinceDate == "" {
-		sinceDate = time.Now().AddDate(0, 0, -30).Format("2006-01-02")
+	// "--account all" is the same as no account filter
+	if strings.EqualFold(opts.Account, "all") {
+		opts.Account = ""
 	}
 
-	var transactions []*api.Transaction
+	now := time.Now()
+	sinceDate, err := resolveSinceDate(opts.SinceDate, opts.DefaultSince, now)
+	if err != nil {
+		return err
+	}
+	if opts.UntilDate != "" {
+		until, err := transform.ParseRelativeDate(opts.UntilDate, now)
+		if err != nil {
+			return fmt.Errorf("invalid --until value: %s (expected YYYY-MM-DD, today, or a time ago like 7d)", opts.UntilDate)
+		}
+		opts.UntilDate = until
+	}
+	if err := validateUntilDate(opts.UntilDate, sinceDate); err != nil {
+		return err
+	}
 
-	// If account filter, resolve account ID and use account-specific endpoint
-	if accountFilter != "" {
+	var memoGrep, extract *regexp.Regexp
+	if opts.MemoGrep != "" {
+		if memoGrep, err = regexp.Compile(opts.MemoGrep); err != nil {
+			return fmt.Errorf("invalid --memo-grep pattern: %w", err)
+		}
+	}
+	if opts.Extract != "" {
+		if extract, err = regexp.Compile(opts.Extract); err != nil {
+			return fmt.Errorf("invalid --extract pattern: %w", err)
+		}
+		if extract.NumSubexp() < 1 {
+			return fmt.Errorf("--extract pattern needs a capture group, e.g. 'proj:(\\w+)'")
+		}
+	}
+	if opts.GroupBy == "extracted" && extract == nil {
+		return fmt.Errorf("--group-by extracted requires --extract")
+	}
+	var byPeriod *period
+	switch opts.GroupBy {
+	case "", "account", "extracted":
+		if opts.WeekStart != "" {
+			return fmt.Errorf("--week-start only applies to --group-by week")
+		}
+	default:
+		if byPeriod, err = parsePeriod(opts.GroupBy, opts.WeekStart, transform.ParseDate(sinceDate)); err != nil {
+			return err
+		}
+	}
+	if opts.Approval != "" && opts.IncludeScheduled {
+		return fmt.Errorf("--%s can't be combined with --include-scheduled: scheduled transactions aren't approved or unapproved until they are entered", opts.Approval)
+	}
+	if opts.MinAmount != nil && opts.MaxAmount != nil && *opts.MinAmount > *opts.MaxAmount {
+		return fmt.Errorf("--min %s is more than --max %s",
+			transform.FormatCurrency(*opts.MinAmount), transform.FormatCurrency(*opts.MaxAmount))
+	}
+	if opts.GroupBy != "" && csvOutput {
+		return fmt.Errorf("--csv lists transactions; it can't be combined with --group-by")
+	}
+	if err := checkJSONLines(opts.JSONL); err != nil {
+		return err
+	}
+	if opts.NoTransfers && opts.IncludeTransfers {
+		return fmt.Errorf("choose one of --no-transfers and --include-transfers")
+	}
+	if opts.GroupBy != "" && opts.JSONL {
+		return fmt.Errorf("--jsonl lists transactions; it can't be combined with --group-by")
+	}
+	if opts.Sort != "" && !slices.Contains(transactionSortKeys, opts.Sort) {
+		return fmt.Errorf("invalid --sort value: %s (expected date, amount, abs-amount or payee)", opts.Sort)
+	}
+	if opts.Desc && opts.Sort == "" {
+		return fmt.Errorf("--desc needs --sort")
+	}
+	if opts.Sort != "" && opts.GroupBy != "" {
+		return fmt.Errorf("--sort orders listed transactions; it can't be combined with --group-by")
+	}
+	if opts.Watch {
+		switch {
+		case opts.GroupBy != "":
+			return fmt.Errorf("--watch lists new transactions; it can't be combined with --group-by")
+		case opts.IncludeScheduled:
+			return fmt.Errorf("--watch can't be combined with --include-scheduled")
+		case opts.RunningBalance:
+			return fmt.Errorf("--watch can't be combined with --running-balance")
+		case csvOutput:
+			return fmt.Errorf("--watch can't be combined with --csv")
+		}
+	}
+	if opts.RunningBalance {
+		switch {
+		case opts.Account == "":
+			return fmt.Errorf("--running-balance needs a single --account; a balance across accounts is meaningless")
+		case opts.GroupBy != "":
+			return fmt.Errorf("--running-balance can't be combined with --group-by")
+		case opts.IncludeScheduled:
+			return fmt.Errorf("--running-balance can't be combined with --include-scheduled")
+		case csvOutput:
+			return fmt.Errorf("--running-balance can't be combined with --csv")
+		}
+	}
+
+	var accountID, categoryID string
+	var accountBalance int64
+
+	// Resolve filters up front so they can be combined
+	if opts.Account != "" {
 		accounts, err := client.GetAccounts(budgetID)
 		if err != nil {
 			return fmt.Errorf("failed to get accounts: %w", err)
 		}
-		accountID := findAccountID(accounts, accountFilter)
+		accountID, err = findAccountID(accounts, opts.Account)
+		if err != nil {
+			return err
+		}
 		if accountID == "" {
-			return fmt.Errorf("no account found matching '%s'", accountFilter)
+			return fmt.Errorf("no account found matching '%s'", opts.Account)
 		}
-		transactions, err = client.GetTransactionsByAccount(budgetID, accountID, sinceDate)
-		if err != nil {
-			return fmt.Errorf("failed to get transactions: %w", err)
+		for _, a := range accounts {
+			if a.ID == accountID {
+				accountBalance = a.Balance
+			}
 		}
-	} else if categoryFilter != "" {
-		// Resolve category ID
+	}
+	if opts.Category != "" {
 		groups, err := client.GetCategories(budgetID)
 		if err != nil {
 			return fmt.Errorf("failed to get categories: %w", err)
 		}
-		categoryID := findCategoryID(groups, categoryFilter)
+		categoryID = findCategoryID(groups, opts.Category)
 		if categoryID == "" {
-			return fmt.Errorf("no category found matching '%s'", categoryFilter)
+			return fmt.Errorf("no category found matching '%s'", opts.Category)
 		}
-		transactions, err = client.GetTransactionsByCategory(budgetID, categoryID, sinceDate)
-		if err != nil {
-			return fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	// Fetch via the most selective endpoint; the category endpoint is usually
+	// narrower than the account one, and the other filters apply client-side.
+	// A running balance needs every transaction in the account.
+	fetch := func() ([]*api.Transaction, error) {
+		switch {
+		case categoryID != "" && !opts.RunningBalance:
+			return client.GetTransactionsByCategory(budgetID, categoryID, sinceDate)
+		case accountID != "":
+			return client.GetTransactionsByAccount(budgetID, accountID, sinceDate)
+		default:
+			return client.GetTransactions(budgetID, sinceDate)
 		}
-	} else {
-		transactions, err = client.GetTransactions(budgetID, sinceDate)
-		if err != nil {
-			return fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	// The API only takes a start date, so the end of the range is applied here
+	filter := func(transactions []*api.Transaction) []*api.Transaction {
+		filtered := filterTransactions(transactions, accountID, categoryID, opts.Payee)
+		if opts.UntilDate != "" {
+			filtered = filterUntil(filtered, opts.UntilDate)
+		}
+		if memoGrep != nil {
+			filtered = filterByMemo(filtered, memoGrep)
+		}
+		if opts.Approval != "" {
+			filtered = filterByApproval(filtered, opts.Approval == "approved")
 		}
+		if opts.MinAmount != nil || opts.MaxAmount != nil {
+			filtered = filterByAmount(filtered, opts.MinAmount, opts.MaxAmount)
+		}
+		if opts.NoTransfers {
+			filtered = withoutTransfers(filtered)
+		}
+		return filtered
 	}
 
-	// Filter deleted
-	var filtered []*api.Transaction
-	for _, t := range transactions {
-		if t.Deleted {
-			continue
+	if opts.Watch {
+		poll := func() ([]*api.Transaction, error) {
+			transactions, err := fetch()
+			if err != nil {
+				return nil, err
+			}
+			return filter(transactions), nil
 		}
-		// Client-side payee filter
-		if payeeFilter != "" {
-			if !strings.Contains(strings.ToLower(t.PayeeName), strings.ToLower(payeeFilter)) {
+		return watchTransactions(client.Context(), opts, poll, jsonOutput || opts.JSONL)
+	}
+
+	// Plain --jsonl across the budget writes each transaction as it is
+	// decoded, so the list is never held in memory
+	if opts.JSONL && accountID == "" && categoryID == "" && opts.Sort == "" && opts.Limit == 0 && !opts.IncludeScheduled {
+		return streamTransactionLines(client, budgetID, sinceDate, filter, extract)
+	}
+
+	transactions, err := fetch()
+	if err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
+	}
+	filtered := filter(transactions)
+
+	// The balances are worked out over the whole account before the other
+	// filters and --limit, so each shown row still has its true balance
+	var balances map[string]int64
+	if opts.RunningBalance {
+		balances = runningBalances(filterTransactions(transactions, accountID, "", ""), accountBalance)
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].Date < filtered[j].Date
+		})
+	}
+
+	// Grouped views summarize the whole window, so they ignore --limit
+	switch opts.GroupBy {
+	case "account":
+		summaries := summarizeByAccount(filtered, opts.IncludeTransfers)
+		return printAccountSummaries(budgetID, sinceDate, opts.UntilDate, summaries, opts.IncludeTransfers, jsonOutput)
+	case "extracted":
+		summaries := summarizeByExtracted(filtered, extract, opts.IncludeTransfers)
+		return printExtractedSummaries(budgetID, sinceDate, opts.UntilDate, opts.Extract, summaries, opts.IncludeTransfers, jsonOutput)
+	}
+	if byPeriod != nil {
+		return printPeriodSummaries(budgetID, sinceDate, opts, groupByPeriod(filtered, *byPeriod), jsonOutput)
+	}
+
+	// Apply limit. In API order that keeps the most recent; sorted, it
+	// keeps the first rows, so --sort amount --limit 5 is the top five
+	var order func(a, b *api.Transaction) int
+	if opts.Sort != "" {
+		order = transactionOrder(opts.Sort, opts.Desc)
+		slices.SortStableFunc(filtered, order)
+	}
+	if opts.Limit > 0 && len(filtered) > opts.Limit {
+		if order != nil {
+			filtered = filtered[:opts.Limit]
+		} else {
+			filtered = filtered[len(filtered)-opts.Limit:]
+		}
+	}
+
+	rows := make([]transactionRow, 0, len(filtered))
+	for _, t := range filtered {
+		rows = append(rows, transactionRow{Transaction: t})
+	}
+
+	// Interleave projected scheduled transactions
+	if opts.IncludeScheduled {
+		scheduled, err := client.GetScheduledTransactions(budgetID)
+		if err != nil {
+			return fmt.Errorf("failed to get scheduled transactions: %w", err)
+		}
+		today := transform.ParseDate(transform.FormatDate(time.Now()))
+		until := today.AddDate(0, 0, scheduledLookaheadDays)
+		if opts.UntilDate != "" {
+			if end := transform.ParseDate(opts.UntilDate); end.Before(until) {
+				until = end
+			}
+		}
+		for _, s := range scheduled {
+			if s.Deleted {
+				continue
+			}
+			if accountID != "" && s.AccountID != accountID {
+				continue
+			}
+			if categoryID != "" && s.CategoryID != categoryID {
+				continue
+			}
+			if !matchesPayee(s.PayeeName, opts.Payee) {
+				continue
+			}
+			if memoGrep != nil && !memoGrep.MatchString(s.Memo) {
 				continue
 			}
+			if !amountInRange(s.Amount, opts.MinAmount, opts.MaxAmount) {
+				continue
+			}
+			if opts.NoTransfers && s.TransferAccountID != "" {
+				continue
+			}
+			rows = append(rows, projectScheduled(s, today, until)...)
 		}
-		filtered = append(filtered, t)
+		if order != nil {
+			slices.SortStableFunc(rows, func(a, b transactionRow) int {
+				return order(a.Transaction, b.Transaction)
+			})
+		} else {
+			sort.SliceStable(rows, func(i, j int) bool {
+				return rows[i].Date < rows[j].Date
+			})
+		}
+	}
+
+	var total, projectedTotal int64
+	scheduledCount := 0
+	for _, r := range rows {
+		if r.Scheduled {
+			projectedTotal += r.Amount
+			scheduledCount++
+		} else {
+			total += r.Amount
+		}
+	}
+
+	itemFor := func(t transactionRow) TransactionItem {
+		item := newTransactionItem(t.Transaction)
+		item.Scheduled = t.Scheduled
+		item.Extracted = extractMemo(extract, t.Memo)
+		if balance, ok := balances[t.ID]; ok {
+			item.RunningBalance = &balance
+			item.RunningBalanceDisplay = transform.FormatCurrency(balance)
+		}
+		return item
 	}
 
-	// Apply limit
-	if limit > 0 && len(filtered) > limit {
-		filtered = filtered[len(filtered)-limit:]
+	if opts.JSONL {
+		lines := newJSONLines(os.Stdout)
+		for _, t := range rows {
+			if err := lines.write(itemFor(t)); err != nil {
+				return err
+			}
+		}
+		return lines.flush()
 	}
 
 	if jsonOutput {
 		output := TransactionsOutput{
-			Transactions: make([]TransactionItem, 0, len(filtered)),
-			Count:        len(filtered),
-		}
-		for _, t := range filtered {
-			output.Transactions = append(output.Transactions, TransactionItem{
-				ID:            t.ID,
-				Date:          t.Date,
-				Amount:        t.Amount,
-				AmountDisplay: transform.FormatCurrency(t.Amount),
-				PayeeName:     t.PayeeName,
-				CategoryName:  t.CategoryName,
-				AccountName:   t.AccountName,
-				Memo:          t.Memo,
-				Cleared:       t.Cleared,
-				Approved:      t.Approved,
-			})
+			BudgetID:       budgetID,
+			AccountID:      accountID,
+			CategoryID:     categoryID,
+			Transactions:   make([]TransactionItem, 0, len(rows)),
+			Count:          len(rows),
+			ScheduledCount: scheduledCount,
+			Total:          total,
+			ProjectedTotal: projectedTotal,
+		}
+		for _, t := range rows {
+			output.Transactions = append(output.Transactions, itemFor(t))
 		}
 		encoder := json.NewEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		return encoder.Encode(output)
 	}
 
-	if len(filtered) == 0 {
+	if csvOutput {
+		return writeTransactionsCSV(os.Stdout, rows)
+	}
+
+	if len(rows) == 0 {
 		fmt.Println("No transactions found.")
 		return nil
 	}
 
 	// Human-readable output
-	fmt.Printf("Transactions (since %s):\n\n", sinceDate)
+	window := describeWindow(sinceDate, opts.UntilDate)
+	switch opts.Approval {
+	case "unapproved":
+		fmt.Printf("Unapproved transactions (%s):\n\n", window)
+	case "approved":
+		fmt.Printf("Approved transactions (%s):\n\n", window)
+	default:
+		fmt.Printf("Transactions (%s):\n\n", window)
+	}
 
-	// Calculate column widths
-	maxPayee := 15
-	maxCategory := 12
-	maxAccount := 10
-	for _, t := range filtered {
-		if len(t.PayeeName) > maxPayee && len(t.PayeeName) <= 30 {
-			maxPayee = len(t.PayeeName)
+	columns := []tableColumn{
+		{Header: "Date", MinWidth: 12},
+		{Header: "Payee", MinWidth: 15, MaxWidth: 30},
+		{Header: "Category", MinWidth: 12, MaxWidth: 20},
+		{Header: "Amount", Right: true, MinWidth: 12},
+		{Header: "Account", MinWidth: 10, MaxWidth: 15},
+	}
+	if balances != nil {
+		columns = append(columns, tableColumn{Header: "Balance", Right: true, MinWidth: 12})
+	}
+	if extract != nil {
+		columns = append(columns, tableColumn{Header: "Extracted"})
+	}
+
+	// Scheduled, matched and (when reviewing) unapproved rows are flagged in
+	// an unlabelled last column, which is only added when some row needs it
+	markers := make([]string, len(rows))
+	hasMarkers := false
+	for i, t := range rows {
+		var flags []string
+		if opts.Approval == "unapproved" {
+			flags = append(flags, "(unapproved)")
 		}
-		if len(t.CategoryName) > maxCategory && len(t.CategoryName) <= 20 {
-			maxCategory = len(t.CategoryName)
+		if t.Scheduled {
+			flags = append(flags, "(scheduled)")
 		}
-		if len(t.AccountName) > maxAccount && len(t.AccountName) <= 15 {
-			maxAccount = len(t.AccountName)
+		if t.MatchedTransactionID != "" {
+			flags = append(flags, "(matched)")
 		}
+		markers[i] = strings.Join(flags, "  ")
+		hasMarkers = hasMarkers || len(flags) > 0
+	}
+	if hasMarkers {
+		columns = append(columns, tableColumn{})
 	}
 
-	fmt.Printf("%-12s  %-*s  %-*s  %12s  %-*s\n",
-		"Date", maxPayee, "Payee", maxCategory, "Category", "Amount", maxAccount, "Account")
-	fmt.Printf("%s\n", strings.Repeat("-", 12+maxPayee+maxCategory+12+maxAccount+8))
+	var accountNames map[string]string
+	for _, t := range rows {
+		if t.TransferAccountID != "" {
+			accountNames = transferAccountNames(client, budgetID)
+			break
+		}
+	}
 
-	for _, t := range filtered {
-		payee := t.PayeeName
-		if len(payee) > maxPayee {
-			payee = payee[:maxPayee-1] + "~"
+	tbl := table{columns: columns}
+	for i, t := range rows {
+		category := t.CategoryName
+		if t.TransferAccountID != "" {
+			category = transferLabel(t.Transaction, accountNames)
+		}
+		cells := []string{t.Date, t.PayeeName, category,
+			formatAmount(t.Amount), t.AccountName}
+		if balances != nil {
+			cells = append(cells, formatAmount(balances[t.ID]))
+		}
+		if extract != nil {
+			cells = append(cells, extractMemo(extract, t.Memo))
+		}
+		if hasMarkers {
+			cells = append(cells, markers[i])
+		}
+		tbl.addRow(cells...)
+	}
+	tbl.render(os.Stdout)
+
+	if opts.IncludeScheduled {
+		fmt.Printf("\n%d transaction(s), %d scheduled\n", len(rows)-scheduledCount, scheduledCount)
+		fmt.Printf("Total:           %s\n", formatAmount(total))
+		fmt.Printf("Projected total: %s (next %d days)\n", formatAmount(projectedTotal), scheduledLookaheadDays)
+		return nil
+	}
+
+	if opts.Approval == "unapproved" {
+		fmt.Printf("\n%d unapproved transaction(s) to review\n", len(rows))
+		return nil
+	}
+	fmt.Printf("\n%d transaction(s)\n", len(rows))
+	return nil
+}
+
+// transactionSortKeys are the values --sort accepts for transactions.
+var transactionSortKeys = []string{"date", "amount", "abs-amount", "payee"}
+
+// transactionOrder returns the comparison for a --sort key, reversed when
+// desc is set. amount keeps the sign, so outflows come first ascending;
+// abs-amount compares sizes. Payees compare case-insensitively.
+// streamTransactionLines writes --jsonl output for the budget's transactions
+// as the response arrives, applying filter to each one.
+func streamTransactionLines(client *api.Client, budgetID, sinceDate string, filter func([]*api.Transaction) []*api.Transaction, extract *regexp.Regexp) error {
+	lines := newJSONLines(os.Stdout)
+	var writeErr error
+	err := client.GetTransactionsFunc(budgetID, sinceDate, func(t *api.Transaction) error {
+		for _, t := range filter([]*api.Transaction{t}) {
+			item := newTransactionItem(t)
+			item.Extracted = extractMemo(extract, t.Memo)
+			if writeErr = lines.write(item); writeErr != nil {
+				return writeErr
+			}
+		}
+		return nil
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
+	}
+	return lines.flush()
+}
+
+func transactionOrder(key string, desc bool) func(a, b *api.Transaction) int {
+	var order func(a, b *api.Transaction) int
+	switch key {
+	case "amount":
+		order = func(a, b *api.Transaction) int { return cmp.Compare(a.Amount, b.Amount) }
+	case "abs-amount":
+		order = func(a, b *api.Transaction) int { return cmp.Compare(absAmount(a.Amount), absAmount(b.Amount)) }
+	case "payee":
+		order = func(a, b *api.Transaction) int {
+			return strings.Compare(strings.ToLower(a.PayeeName), strings.ToLower(b.PayeeName))
+		}
+	default:
+		order = func(a, b *api.Transaction) int { return strings.Compare(a.Date, b.Date) }
+	}
+	if desc {
+		return func(a, b *api.Transaction) int { return order(b, a) }
+	}
+	return order
+}
+
+func absAmount(amount int64) int64 {
+	if amount < 0 {
+		return -amount
+	}
+	return amount
+}
+
+// withoutTransfers drops transfers between accounts.
+func withoutTransfers(transactions []*api.Transaction) []*api.Transaction {
+	var kept []*api.Transaction
+	for _, t := range transactions {
+		if t.TransferAccountID == "" {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+// transferAccountNames maps account IDs to names for labelling transfers.
+// The labels are cosmetic, so a failed lookup just leaves the map empty.
+func transferAccountNames(client *api.Client, budgetID string) map[string]string {
+	names := make(map[string]string)
+	accounts, err := lookupAccounts(client, budgetID)
+	if err != nil {
+		return names
+	}
+	for _, a := range accounts {
+		names[a.ID] = a.Name
+	}
+	return names
+}
+
+// transferLabel fills the category column of a transfer, which has no
+// category: "Transfer → Savings" for money sent to Savings, "Transfer ←
+// Savings" for money received from it. Without a name for the account it
+// falls back to the payee, which YNAB names "Transfer : Savings".
+func transferLabel(t *api.Transaction, accountNames map[string]string) string {
+	name := accountNames[t.TransferAccountID]
+	if name == "" {
+		name = strings.TrimPrefix(t.PayeeName, "Transfer : ")
+	}
+	if t.Amount > 0 {
+		return "Transfer ← " + name
+	}
+	return "Transfer → " + name
+}
+
+// runningBalances returns the balance after each of an account's
+// transactions, keyed by transaction ID. transactions must be every
+// transaction in the account from some date on; the balance before the first
+// of them is current (the account's balance today) minus their sum. Same-day
+// transactions keep the order they were given in.
+func runningBalances(transactions []*api.Transaction, current int64) map[string]int64 {
+	sorted := slices.Clone(transactions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date < sorted[j].Date
+	})
+
+	balance := current
+	for _, t := range sorted {
+		balance -= t.Amount
+	}
+	balances := make(map[string]int64, len(sorted))
+	for _, t := range sorted {
+		balance += t.Amount
+		balances[t.ID] = balance
+	}
+	return balances
+}
+
+// summarizeByAccount totals transactions per account, sorted by outflow
+// (largest spending first). Transfers between accounts only move money
+// around, so they are left out unless includeTransfers is set.
+func summarizeByAccount(transactions []*api.Transaction, includeTransfers bool) []AccountSummary {
+	byID := make(map[string]*AccountSummary)
+	var order []string
+	for _, t := range transactions {
+		if t.TransferAccountID != "" && !includeTransfers {
+			continue
+		}
+		s, ok := byID[t.AccountID]
+		if !ok {
+			s = &AccountSummary{Account: t.AccountName, AccountID: t.AccountID}
+			byID[t.AccountID] = s
+			order = append(order, t.AccountID)
+		}
+		s.Count++
+		if t.Amount < 0 {
+			s.Outflow += t.Amount
+		} else {
+			s.Inflow += t.Amount
+		}
+		s.Net += t.Amount
+	}
+
+	summaries := make([]AccountSummary, 0, len(order))
+	for _, id := range order {
+		summaries = append(summaries, *byID[id])
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Outflow < summaries[j].Outflow
+	})
+	return summaries
+}
+
+// printAccountSummaries renders the --group-by account view.
+func printAccountSummaries(budgetID, sinceDate, untilDate string, summaries []AccountSummary, includeTransfers, jsonOutput bool) error {
+	if jsonOutput {
+		output := AccountSummaryOutput{
+			BudgetID:         budgetID,
+			SinceDate:        sinceDate,
+			UntilDate:        untilDate,
+			IncludeTransfers: includeTransfers,
+			Accounts:         make([]AccountSummary, 0, len(summaries)),
 		}
-		cat := t.CategoryName
-		if len(cat) > maxCategory {
-			cat = cat[:maxCategory-1] + "~"
+		output.Accounts = append(output.Accounts, summaries...)
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	if len(summaries) == 0 {
+		fmt.Println("No transactions found.")
+		return nil
+	}
+
+	fmt.Printf("Spending by account (%s):\n\n", describeWindow(sinceDate, untilDate))
+
+	tbl := table{columns: []tableColumn{
+		{Header: "Account", MinWidth: 15, MaxWidth: 30},
+		{Header: "Count", Right: true, MinWidth: 6},
+		{Header: "Inflow", Right: true, MinWidth: 12},
+		{Header: "Outflow", Right: true, MinWidth: 12},
+		{Header: "Net", Right: true, MinWidth: 12},
+	}}
+
+	var inflow, outflow, net int64
+	count := 0
+	for _, s := range summaries {
+		tbl.addRow(s.Account, strconv.Itoa(s.Count),
+			formatAmount(s.Inflow), formatAmount(s.Outflow), formatAmount(s.Net))
+		inflow += s.Inflow
+		outflow += s.Outflow
+		net += s.Net
+		count += s.Count
+	}
+
+	tbl.addFooter("Total", strconv.Itoa(count),
+		formatAmount(inflow), formatAmount(outflow), formatAmount(net))
+	tbl.render(os.Stdout)
+
+	if !includeTransfers {
+		fmt.Println("\nTransfers between accounts are excluded (use --include-transfers).")
+	}
+	return nil
+}
+
+// filterByMemo keeps the transactions whose memo matches re.
+func filterByMemo(transactions []*api.Transaction, re *regexp.Regexp) []*api.Transaction {
+	var filtered []*api.Transaction
+	for _, t := range transactions {
+		if re.MatchString(t.Memo) {
+			filtered = append(filtered, t)
 		}
-		acct := t.AccountName
-		if len(acct) > maxAccount {
-			acct = acct[:maxAccount-1] + "~"
+	}
+	return filtered
+}
+
+// filterByApproval keeps the transactions whose approval state is approved.
+func filterByApproval(transactions []*api.Transaction, approved bool) []*api.Transaction {
+	var filtered []*api.Transaction
+	for _, t := range transactions {
+		if t.Approved == approved {
+			filtered = append(filtered, t)
 		}
+	}
+	return filtered
+}
 
-		fmt.Printf("%-12s  %-*s  %-*s  %12s  %-*s\n",
-			t.Date, maxPayee, payee, maxCategory, cat,
-			transform.FormatCurrency(t.Amount), maxAccount, acct)
+// filterByAmount keeps the transactions whose amount is between minAmount
+// and maxAmount, inclusive. Amounts are compared by size, so a $120 expense
+// and a $120 refund both match --min 100; a nil bound is open.
+func filterByAmount(transactions []*api.Transaction, minAmount, maxAmount *int64) []*api.Transaction {
+	var filtered []*api.Transaction
+	for _, t := range transactions {
+		if amountInRange(t.Amount, minAmount, maxAmount) {
+			filtered = append(filtered, t)
+		}
 	}
+	return filtered
+}
 
-	fmt.Printf("\n%d transaction(s)\n", len(filtered))
+// amountInRange reports whether the size of amount is within the bounds.
+func amountInRange(amount int64, minAmount, maxAmount *int64) bool {
+	amount = absAmount(amount)
+	return (minAmount == nil || amount >= *minAmount) && (maxAmount == nil || amount <= *maxAmount)
+}
+
+// filterUntil keeps the transactions dated on or before until (YYYY-MM-DD).
+func filterUntil(transactions []*api.Transaction, until string) []*api.Transaction {
+	var filtered []*api.Transaction
+	for _, t := range transactions {
+		if t.Date <= until {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// extractMemo returns the first capture group of re in memo, or "" if re is
+// nil or doesn't match.
+func extractMemo(re *regexp.Regexp, memo string) string {
+	if re == nil {
+		return ""
+	}
+	m := re.FindStringSubmatch(memo)
+	if len(m) < 2 {
+		return ""
+	}
+	return m[1]
+}
+
+// summarizeByExtracted totals transactions per extracted memo value, sorted
+// by outflow (largest spending first). Transfers are left out unless
+// includeTransfers is set, as with --group-by account.
+func summarizeByExtracted(transactions []*api.Transaction, re *regexp.Regexp, includeTransfers bool) []ExtractedSummary {
+	byValue := make(map[string]*ExtractedSummary)
+	var order []string
+	for _, t := range transactions {
+		if t.TransferAccountID != "" && !includeTransfers {
+			continue
+		}
+		value := extractMemo(re, t.Memo)
+		s, ok := byValue[value]
+		if !ok {
+			s = &ExtractedSummary{Value: value}
+			byValue[value] = s
+			order = append(order, value)
+		}
+		s.Count++
+		if t.Amount < 0 {
+			s.Outflow += t.Amount
+		} else {
+			s.Inflow += t.Amount
+		}
+		s.Net += t.Amount
+	}
+
+	summaries := make([]ExtractedSummary, 0, len(order))
+	for _, v := range order {
+		summaries = append(summaries, *byValue[v])
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Outflow < summaries[j].Outflow
+	})
+	return summaries
+}
+
+// printExtractedSummaries renders the --group-by extracted view.
+func printExtractedSummaries(budgetID, sinceDate, untilDate, pattern string, summaries []ExtractedSummary, includeTransfers, jsonOutput bool) error {
+	if jsonOutput {
+		output := ExtractedSummaryOutput{
+			BudgetID:         budgetID,
+			SinceDate:        sinceDate,
+			UntilDate:        untilDate,
+			Pattern:          pattern,
+			IncludeTransfers: includeTransfers,
+			Groups:           make([]ExtractedSummary, 0, len(summaries)),
+		}
+		output.Groups = append(output.Groups, summaries...)
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	if len(summaries) == 0 {
+		fmt.Println("No transactions found.")
+		return nil
+	}
+
+	fmt.Printf("Spending by extracted memo value (%s):\n\n", describeWindow(sinceDate, untilDate))
+
+	tbl := table{columns: []tableColumn{
+		{Header: "Value", MinWidth: 15, MaxWidth: 30},
+		{Header: "Count", Right: true, MinWidth: 6},
+		{Header: "Inflow", Right: true, MinWidth: 12},
+		{Header: "Outflow", Right: true, MinWidth: 12},
+		{Header: "Net", Right: true, MinWidth: 12},
+	}}
+
+	var inflow, outflow, net int64
+	count := 0
+	for _, s := range summaries {
+		value := s.Value
+		if value == "" {
+			value = "(no match)"
+		}
+		tbl.addRow(value, strconv.Itoa(s.Count),
+			formatAmount(s.Inflow), formatAmount(s.Outflow), formatAmount(s.Net))
+		inflow += s.Inflow
+		outflow += s.Outflow
+		net += s.Net
+		count += s.Count
+	}
+
+	tbl.addFooter("Total", strconv.Itoa(count),
+		formatAmount(inflow), formatAmount(outflow), formatAmount(net))
+	tbl.render(os.Stdout)
+
+	if !includeTransfers {
+		fmt.Println("\nTransfers between accounts are excluded (use --include-transfers).")
+	}
 	return nil
 }
 
-// findAccountID finds an account ID by name (case-insensitive partial match).
-func findAccountID(accounts []*api.Account, filter string) string {
+// period is how --group-by day, week, month or Nd buckets dates.
+type period struct {
+	unit      string       // "day", "week", "month" or "days"
+	days      int          // bucket length for "days"
+	weekStart time.Weekday // first day of a week
+	origin    time.Time    // start of the first "days" bucket
+}
+
+// parsePeriod parses a --group-by period. Weeks start on Monday, as in ISO
+// 8601, unless weekStart is "sunday". Nd buckets run N days at a time from
+// origin, the start of the window.
+func parsePeriod(groupBy, weekStart string, origin time.Time) (*period, error) {
+	p := &period{unit: groupBy, weekStart: time.Monday, origin: origin}
+	switch groupBy {
+	case "day", "week", "month":
+	default:
+		n, err := strconv.Atoi(strings.TrimSuffix(groupBy, "d"))
+		if !strings.HasSuffix(groupBy, "d") || err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid --group-by value: %s (expected account, extracted, day, week, month or Nd)", groupBy)
+		}
+		p.unit, p.days = "days", n
+	}
+
+	switch strings.ToLower(weekStart) {
+	case "", "monday":
+	case "sunday":
+		p.weekStart = time.Sunday
+	default:
+		return nil, fmt.Errorf("invalid --week-start value: %s (expected monday or sunday)", weekStart)
+	}
+	if weekStart != "" && p.unit != "week" {
+		return nil, fmt.Errorf("--week-start only applies to --group-by week")
+	}
+	return p, nil
+}
+
+// bounds returns the first and last day of the period holding date.
+func (p period) bounds(date time.Time) (start, end time.Time) {
+	switch p.unit {
+	case "week":
+		start = date.AddDate(0, 0, -((int(date.Weekday()) - int(p.weekStart) + 7) % 7))
+		return start, start.AddDate(0, 0, 6)
+	case "month":
+		start = time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, -1)
+	case "days":
+		elapsed := int(date.Sub(p.origin).Hours() / 24)
+		offset := elapsed - elapsed%p.days
+		if elapsed < 0 && elapsed%p.days != 0 {
+			offset -= p.days
+		}
+		start = p.origin.AddDate(0, 0, offset)
+		return start, start.AddDate(0, 0, p.days-1)
+	default:
+		return date, date
+	}
+}
+
+// label names the period starting at start: the date for days, the ISO
+// week (2025-W02) for Monday weeks, the month (2025-01), or the date range.
+func (p period) label(start, end time.Time) string {
+	switch {
+	case p.unit == "day":
+		return transform.FormatDate(start)
+	case p.unit == "week" && p.weekStart == time.Monday:
+		year, week := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case p.unit == "month":
+		return transform.FormatMonth(start.Year(), int(start.Month()))
+	default:
+		return transform.FormatDate(start) + ".." + transform.FormatDate(end)
+	}
+}
+
+// groupByPeriod buckets transactions by period, oldest period first, with
+// transactions oldest first inside each. Periods without transactions are
+// left out.
+func groupByPeriod(transactions []*api.Transaction, p period) []PeriodSummary {
+	sorted := slices.Clone(transactions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date < sorted[j].Date
+	})
+
+	var periods []PeriodSummary
+	for _, t := range sorted {
+		start, end := p.bounds(transform.ParseDate(t.Date))
+		if n := len(periods); n == 0 || periods[n-1].Start != transform.FormatDate(start) {
+			periods = append(periods, PeriodSummary{
+				Label: p.label(start, end),
+				Start: transform.FormatDate(start),
+				End:   transform.FormatDate(end),
+			})
+		}
+		current := &periods[len(periods)-1]
+		current.Transactions = append(current.Transactions, newTransactionItem(t))
+		current.Count++
+		current.Subtotal += t.Amount
+	}
+	return periods
+}
+
+// printPeriodSummaries prints transactions under a heading per period, each
+// with its subtotal, followed by the grand total.
+func printPeriodSummaries(budgetID, sinceDate string, opts TransactionsOptions, periods []PeriodSummary, jsonOutput bool) error {
+	var count int
+	var total int64
+	for _, p := range periods {
+		count += p.Count
+		total += p.Subtotal
+	}
+
+	if jsonOutput {
+		output := PeriodSummaryOutput{
+			BudgetID:  budgetID,
+			SinceDate: sinceDate,
+			UntilDate: opts.UntilDate,
+			GroupBy:   opts.GroupBy,
+			Periods:   make([]PeriodSummary, 0, len(periods)),
+			Count:     count,
+			Total:     total,
+		}
+		if opts.GroupBy == "week" {
+			output.WeekStart = "monday"
+			if strings.EqualFold(opts.WeekStart, "sunday") {
+				output.WeekStart = "sunday"
+			}
+		}
+		output.Periods = append(output.Periods, periods...)
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	if len(periods) == 0 {
+		fmt.Println("No transactions found.")
+		return nil
+	}
+
+	fmt.Printf("Transactions by %s (%s):\n\n", opts.GroupBy, describeWindow(sinceDate, opts.UntilDate))
+	for _, p := range periods {
+		heading := p.Label
+		if p.Start != p.End && !strings.Contains(heading, "..") {
+			heading += fmt.Sprintf(" (%s to %s)", p.Start, p.End)
+		}
+		fmt.Println(heading)
+		fmt.Println(strings.Repeat("-", displayWidth(heading)))
+
+		tbl := table{
+			columns: []tableColumn{
+				{Header: "Date", MinWidth: 12},
+				{Header: "Payee", MinWidth: 15, MaxWidth: 30},
+				{Header: "Category", MinWidth: 12, MaxWidth: 20},
+				{Header: "Amount", Right: true, MinWidth: 12},
+				{Header: "Account", MinWidth: 10, MaxWidth: 15},
+			},
+			indent: "  ",
+		}
+		for _, t := range p.Transactions {
+			tbl.addRow(t.Date, t.PayeeName, t.CategoryName, formatAmount(t.Amount), t.AccountName)
+		}
+		tbl.addFooter("Subtotal", "", "", formatAmount(p.Subtotal), "")
+		tbl.render(os.Stdout)
+		fmt.Println()
+	}
+
+	fmt.Printf("Total: %s (%d transaction(s))\n", formatAmount(total), count)
+	return nil
+}
+
+// filterTransactions drops deleted transactions and those not matching every
+// given filter. Empty filters match everything.
+func filterTransactions(transactions []*api.Transaction, accountID, categoryID, payee string) []*api.Transaction {
+	var filtered []*api.Transaction
+	for _, t := range transactions {
+		if t.Deleted {
+			continue
+		}
+		if accountID != "" && t.AccountID != accountID {
+			continue
+		}
+		if categoryID != "" && t.CategoryID != categoryID {
+			continue
+		}
+		if !matchesPayee(t.PayeeName, payee) {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+	return filtered
+}
+
+// matchesPayee reports whether a payee name contains the filter (case-insensitive).
+// An empty filter matches everything.
+func matchesPayee(payeeName, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(payeeName), strings.ToLower(expandAlias(filter)))
+}
+
+// projectScheduled expands a scheduled transaction into display rows for each
+// occurrence between from and until (inclusive).
+func projectScheduled(s *api.ScheduledTransaction, from, until time.Time) []transactionRow {
+	var rows []transactionRow
+	for _, d := range transform.ProjectOccurrences(transform.ParseDate(s.DateNext), s.Frequency, until, 0) {
+		if d.Before(from) {
+			continue
+		}
+		rows = append(rows, transactionRow{
+			Transaction: &api.Transaction{
+				ID:                s.ID,
+				Date:              transform.FormatDate(d),
+				Amount:            s.Amount,
+				Memo:              s.Memo,
+				Cleared:           "uncleared",
+				FlagColor:         s.FlagColor,
+				AccountID:         s.AccountID,
+				AccountName:       s.AccountName,
+				PayeeID:           s.PayeeID,
+				PayeeName:         s.PayeeName,
+				CategoryID:        s.CategoryID,
+				CategoryName:      s.CategoryName,
+				TransferAccountID: s.TransferAccountID,
+			},
+			Scheduled: true,
+		})
+	}
+	return rows
+}
+
+// findAccountID finds an account ID by name (case-insensitive).
+//
+// Open accounts (on-budget, not closed) are preferred at each level, so
+// "check" finds an open "Checking 2" rather than a closed "Checking". An
+// exact name still wins over a partial one: "checking" finds the closed
+// "Checking". Preference order:
+//
+//  1. exact match on an open account
+//  2. exact match on any account
+//  3. partial match on an open account
+//  4. partial match on any account
+//  5. word-suffix or acronym match (see looseAccountMatches), which must be
+//     unambiguous
+//
+// Aliases are expanded first, and an account ID matches directly.
+// Deleted accounts never match. It returns "" and no error when nothing
+// matches, and an error when only an ambiguous loose match is found.
+func findAccountID(accounts []*api.Account, filter string) (string, error) {
+	filter = expandAlias(filter)
 	lower := strings.ToLower(filter)
+	exact := func(a *api.Account) bool { return strings.EqualFold(a.Name, filter) }
+	partial := func(a *api.Account) bool { return strings.Contains(strings.ToLower(a.Name), lower) }
+
+	passes := []struct {
+		match    func(*api.Account) bool
+		openOnly bool
+	}{
+		{exact, true},
+		{exact, false},
+		{partial, true},
+		{partial, false},
+	}
+
+	var live []*api.Account
 	for _, a := range accounts {
-		if strings.EqualFold(a.Name, filter) {
-			return a.ID
+		if a.Deleted {
+			continue
+		}
+		if a.ID == filter {
+			return a.ID, nil
+		}
+		live = append(live, a)
+	}
+
+	for _, pass := range passes {
+		for _, a := range live {
+			if pass.openOnly && !isOpenAccount(a) {
+				continue
+			}
+			if pass.match(a) {
+				return a.ID, nil
+			}
 		}
 	}
+
+	matches := looseAccountMatches(live, filter)
+	var open []*api.Account
+	for _, a := range matches {
+		if isOpenAccount(a) {
+			open = append(open, a)
+		}
+	}
+	if len(open) > 0 {
+		matches = open
+	}
+	switch len(matches) {
+	case 0:
+		return "", nil
+	case 1:
+		return matches[0].ID, nil
+	default:
+		return "", ambiguousAccountError(filter, matches)
+	}
+}
+
+// looseAccountMatches is the fallback for verbose account names that
+// substring matching can't reach. It returns the accounts whose name ends
+// with the filter's words ("checking ally" matches "Joint Checking - Ally")
+// or, only if there are none, whose initials spell the filter ("JCA").
+// Case and punctuation are ignored.
+func looseAccountMatches(accounts []*api.Account, filter string) []*api.Account {
+	want := nameWords(filter)
+	if len(want) == 0 {
+		return nil
+	}
+
+	var suffix, acronym []*api.Account
 	for _, a := range accounts {
-		if strings.Contains(strings.ToLower(a.Name), lower) {
-			return a.ID
+		words := nameWords(a.Name)
+		if hasWordSuffix(words, want) {
+			suffix = append(suffix, a)
+			continue
+		}
+		if len(want) == 1 && len(words) > 1 && initials(words) == want[0] {
+			acronym = append(acronym, a)
 		}
 	}
-	return ""
+
+	if len(suffix) > 0 {
+		return suffix
+	}
+	return acronym
+}
+
+// nameWords splits a name into lowercase words, dropping punctuation.
+// Apostrophes are removed rather than split on, so "Jane's" is one word.
+func nameWords(name string) []string {
+	name = strings.NewReplacer("'", "", "’", "").Replace(strings.ToLower(name))
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// hasWordSuffix reports whether words ends with suffix.
+func hasWordSuffix(words, suffix []string) bool {
+	if len(suffix) > len(words) {
+		return false
+	}
+	offset := len(words) - len(suffix)
+	for i, w := range suffix {
+		if words[offset+i] != w {
+			return false
+		}
+	}
+	return true
+}
+
+// initials returns the first letter of each word.
+func initials(words []string) string {
+	var b strings.Builder
+	for _, w := range words {
+		r, _ := utf8.DecodeRuneInString(w)
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ambiguousAccountError lists the accounts a name could refer to.
+func ambiguousAccountError(filter string, matches []*api.Account) error {
+	var names []string
+	for _, a := range matches {
+		names = append(names, a.Name)
+	}
+	return fmt.Errorf("multiple accounts match '%s': %s\nPlease be more specific",
+		filter, strings.Join(names, ", "))
 }
 
 // findCategoryID finds a category ID by name (case-insensitive partial match).
+// Aliases are expanded first, and a category ID matches directly.
 func findCategoryID(groups []*api.CategoryGroup, filter string) string {
+	filter = expandAlias(filter)
 	lower := strings.ToLower(filter)
 	// Exact match first
 	for _, g := range groups {
 		for _, c := range g.Categories {
-			if strings.EqualFold(c.Name, filter) {
+			if c.ID == filter || strings.EqualFold(c.Name, filter) {
 				return c.ID
 			}
 		}
@@ -212,3 +1402,48 @@ func findCategoryID(groups []*api.CategoryGroup, filter string) string {
 	}
 	return ""
 }
+
+// resolveSinceDate picks the start of the transactions window: the --since
+// flag wins, then the default_since config key, then the built-in 30 days.
+// The chosen value may be an absolute YYYY-MM-DD date or relative, such as
+// "today", "60d", "2w" or "1m", which transform.ParseRelativeDate counts
+// back from now.
+func resolveSinceDate(flag, configDefault string, now time.Time) (string, error) {
+	value, source := flag, "--since"
+	if value == "" {
+		value, source = configDefault, "default_since"
+	}
+	if value == "" {
+		value, source = defaultSinceWindow, "default window"
+	}
+
+	date, err := transform.ParseRelativeDate(value, now)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s value: %s (expected YYYY-MM-DD, today, or a time ago like 60d, 2w or 1m)", source, value)
+	}
+	return date, nil
+}
+
+// validateUntilDate checks an --until date: it must be YYYY-MM-DD and not
+// before the resolved start date. An empty value means no end.
+func validateUntilDate(until, sinceDate string) error {
+	if until == "" {
+		return nil
+	}
+	if _, err := time.Parse("2006-01-02", until); err != nil {
+		return fmt.Errorf("invalid --until value: %s (expected YYYY-MM-DD)", until)
+	}
+	if until < sinceDate {
+		return fmt.Errorf("--until %s is before the start date %s", until, sinceDate)
+	}
+	return nil
+}
+
+// describeWindow renders a date range for headings: "since 2025-01-01" or,
+// with an end, "2025-01-01 to 2025-01-31".
+func describeWindow(sinceDate, untilDate string) string {
+	if untilDate == "" {
+		return "since " + sinceDate
+	}
+	return sinceDate + " to " + untilDate
+}
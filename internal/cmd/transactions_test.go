@@ -0,0 +1,615 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestFindAccountID_PrefersOpenAccounts(t *testing.T) {
+	accounts := []*api.Account{
+		{ID: "closed-checking", Name: "Checking", OnBudget: true, Closed: true},
+		{ID: "open-checking", Name: "Checking 2", OnBudget: true},
+		{ID: "tracking", Name: "Brokerage", OnBudget: false},
+		{ID: "closed-savings", Name: "Savings", OnBudget: true, Closed: true},
+		{ID: "deleted-cash", Name: "Cash", OnBudget: true, Deleted: true},
+		{ID: "old-visa", Name: "Visa", OnBudget: true, Closed: true},
+		{ID: "visa", Name: "visa", OnBudget: true},
+	}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   string
+	}{
+		{"exact open beats exact closed", "VISA", "visa"},
+		{"exact closed beats partial open", "checking", "closed-checking"},
+		{"partial open beats partial closed", "check", "open-checking"},
+		{"partial closed when nothing open matches", "sav", "closed-savings"},
+		{"off-budget exact match", "brokerage", "tracking"},
+		{"deleted never matches", "cash", ""},
+		{"no match", "investment", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findAccountID(accounts, tt.filter)
+			if err != nil {
+				t.Fatalf("findAccountID(%q): %v", tt.filter, err)
+			}
+			if got != tt.want {
+				t.Errorf("findAccountID(%q) = %q, want %q", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindAccountID_SuffixAndAcronym(t *testing.T) {
+	accounts := []*api.Account{
+		{ID: "joint", Name: "Joint Checking - Ally", OnBudget: true},
+		{ID: "card", Name: "Chase Sapphire Preferred", OnBudget: true},
+		{ID: "old-card", Name: "Citi Simplicity Platinum", OnBudget: true, Closed: true},
+		{ID: "hsa", Name: "Health-Savings (HSA)", OnBudget: false},
+	}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   string
+	}{
+		{"word suffix across punctuation", "checking ally", "joint"},
+		{"acronym", "JCA", "joint"},
+		{"acronym is case-insensitive", "csp", "card"},
+		{"substring still wins", "ally", "joint"},
+		{"suffix with punctuation in filter", "savings hsa", "hsa"},
+		{"acronym must cover every word", "jc", ""},
+		{"no match", "xyz", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findAccountID(accounts, tt.filter)
+			if err != nil {
+				t.Fatalf("findAccountID(%q): %v", tt.filter, err)
+			}
+			if got != tt.want {
+				t.Errorf("findAccountID(%q) = %q, want %q", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindAccountID_AmbiguousAcronym(t *testing.T) {
+	accounts := []*api.Account{
+		{ID: "a", Name: "Joint Checking Ally", OnBudget: true},
+		{ID: "b", Name: "Jane's Cash Account", OnBudget: true},
+		{ID: "c", Name: "Junk Credit Archive", OnBudget: true, Closed: true},
+	}
+
+	got, err := findAccountID(accounts, "JCA")
+	if err == nil {
+		t.Fatalf("expected ambiguity error, got %q", got)
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "Joint Checking Ally") || !strings.Contains(msg, "Jane's Cash Account") {
+		t.Errorf("error should list the open candidates: %v", err)
+	}
+	if strings.Contains(msg, "Junk Credit Archive") {
+		t.Errorf("closed account should not be offered when open ones match: %v", err)
+	}
+
+	// Closing one of them leaves a single open match
+	accounts[1].Closed = true
+	got, err = findAccountID(accounts, "JCA")
+	if err != nil || got != "a" {
+		t.Errorf("findAccountID(JCA) = %q, %v; want a", got, err)
+	}
+}
+
+// TestFilterTransactions_CombinedFilters tests that --account and --category
+// are both honored when supplied together.
+func TestFilterTransactions_CombinedFilters(t *testing.T) {
+	transactions := []*api.Transaction{
+		{ID: "t1", AccountID: "checking", CategoryID: "groceries", PayeeName: "Market"},
+		{ID: "t2", AccountID: "credit", CategoryID: "groceries", PayeeName: "Market"},
+		{ID: "t3", AccountID: "checking", CategoryID: "dining", PayeeName: "Cafe"},
+		{ID: "t4", AccountID: "checking", CategoryID: "groceries", PayeeName: "Corner Shop", Deleted: true},
+		{ID: "t5", AccountID: "checking", CategoryID: "groceries", PayeeName: "Corner Shop"},
+	}
+
+	tests := []struct {
+		name       string
+		accountID  string
+		categoryID string
+		payee      string
+		expected   []string
+	}{
+		{"no filters", "", "", "", []string{"t1", "t2", "t3", "t5"}},
+		{"account only", "checking", "", "", []string{"t1", "t3", "t5"}},
+		{"category only", "", "groceries", "", []string{"t1", "t2", "t5"}},
+		{"account and category", "checking", "groceries", "", []string{"t1", "t5"}},
+		{"account, category and payee", "checking", "groceries", "market", []string{"t1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterTransactions(transactions, tt.accountID, tt.categoryID, tt.payee)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %d transactions, got %d", len(tt.expected), len(got))
+			}
+			for i, id := range tt.expected {
+				if got[i].ID != id {
+					t.Errorf("got[%d] = %s, want %s", i, got[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+// TestSummarizeByAccount tests per-account totals and transfer exclusion.
+func TestSummarizeByAccount(t *testing.T) {
+	transactions := []*api.Transaction{
+		{AccountID: "checking", AccountName: "Checking", Amount: 2000000},
+		{AccountID: "checking", AccountName: "Checking", Amount: -150000},
+		{AccountID: "credit", AccountName: "Visa", Amount: -400000},
+		{AccountID: "credit", AccountName: "Visa", Amount: -100000},
+		{AccountID: "checking", AccountName: "Checking", Amount: -500000, TransferAccountID: "credit"},
+		{AccountID: "credit", AccountName: "Visa", Amount: 500000, TransferAccountID: "checking"},
+	}
+
+	t.Run("transfers excluded", func(t *testing.T) {
+		got := summarizeByAccount(transactions, false)
+		if len(got) != 2 {
+			t.Fatalf("expected 2 accounts, got %d", len(got))
+		}
+		// Sorted by outflow: Visa spent more
+		visa, checking := got[0], got[1]
+		if visa.AccountID != "credit" {
+			t.Fatalf("expected Visa first (largest outflow), got %s", visa.AccountID)
+		}
+		if visa.Count != 2 || visa.Outflow != -500000 || visa.Inflow != 0 || visa.Net != -500000 {
+			t.Errorf("unexpected Visa summary: %+v", visa)
+		}
+		if checking.Count != 2 || checking.Inflow != 2000000 || checking.Outflow != -150000 || checking.Net != 1850000 {
+			t.Errorf("unexpected Checking summary: %+v", checking)
+		}
+	})
+
+	t.Run("transfers included", func(t *testing.T) {
+		got := summarizeByAccount(transactions, true)
+		if got[0].AccountID != "checking" || got[0].Outflow != -650000 || got[0].Count != 3 {
+			t.Errorf("unexpected Checking summary: %+v", got[0])
+		}
+		if got[1].Inflow != 500000 || got[1].Net != 0 {
+			t.Errorf("unexpected Visa summary: %+v", got[1])
+		}
+	})
+}
+
+func TestMemoGrepAndExtract(t *testing.T) {
+	transactions := []*api.Transaction{
+		{ID: "t1", Amount: -20000, Memo: "lumber proj:deck"},
+		{ID: "t2", Amount: -5000, Memo: "screws PROJ:deck"},
+		{ID: "t3", Amount: -80000, Memo: "proj:kitchen tiles"},
+		{ID: "t4", Amount: 10000, Memo: "refund proj:kitchen"},
+		{ID: "t5", Amount: -3000, Memo: "coffee"},
+		{ID: "t6", Amount: -1000},
+	}
+	re := regexp.MustCompile(`(?i)proj:(\w+)`)
+
+	filtered := filterByMemo(transactions, re)
+	if len(filtered) != 4 {
+		t.Fatalf("expected 4 memo matches, got %d", len(filtered))
+	}
+
+	if got := extractMemo(re, "screws PROJ:deck"); got != "deck" {
+		t.Errorf("extractMemo = %q, want deck", got)
+	}
+	if got := extractMemo(re, "coffee"); got != "" {
+		t.Errorf("extractMemo on non-match = %q, want empty", got)
+	}
+	if got := extractMemo(nil, "proj:deck"); got != "" {
+		t.Errorf("extractMemo with no pattern = %q, want empty", got)
+	}
+
+	summaries := summarizeByExtracted(transactions, re, false)
+	if len(summaries) != 3 {
+		t.Fatalf("expected 3 groups, got %d: %+v", len(summaries), summaries)
+	}
+	kitchen, deck, none := summaries[0], summaries[1], summaries[2]
+	if kitchen.Value != "kitchen" || kitchen.Count != 2 || kitchen.Outflow != -80000 || kitchen.Inflow != 10000 || kitchen.Net != -70000 {
+		t.Errorf("unexpected kitchen summary: %+v", kitchen)
+	}
+	if deck.Value != "deck" || deck.Count != 2 || deck.Net != -25000 {
+		t.Errorf("unexpected deck summary: %+v", deck)
+	}
+	if none.Value != "" || none.Count != 2 || none.Net != -4000 {
+		t.Errorf("unexpected no-match summary: %+v", none)
+	}
+}
+
+func TestFilterByApproval(t *testing.T) {
+	transactions := []*api.Transaction{
+		{ID: "entered", Approved: true},
+		{ID: "imported-1", Approved: false},
+		{ID: "imported-2", Approved: false},
+	}
+
+	unapproved := filterByApproval(transactions, false)
+	if len(unapproved) != 2 || unapproved[0].ID != "imported-1" || unapproved[1].ID != "imported-2" {
+		t.Errorf("unexpected unapproved transactions: %+v", unapproved)
+	}
+
+	approved := filterByApproval(transactions, true)
+	if len(approved) != 1 || approved[0].ID != "entered" {
+		t.Errorf("unexpected approved transactions: %+v", approved)
+	}
+}
+
+func TestNewTransactionItem_Matched(t *testing.T) {
+	matched := newTransactionItem(&api.Transaction{
+		ID:                   "manual-1",
+		Amount:               -42000,
+		MatchedTransactionID: "imported-1",
+	})
+	if !matched.Matched || matched.MatchedTransactionID != "imported-1" {
+		t.Errorf("expected matched item, got %+v", matched)
+	}
+
+	data, err := json.Marshal(matched)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"matched":true`) || !strings.Contains(string(data), `"matched_transaction_id":"imported-1"`) {
+		t.Errorf("matched fields missing from JSON: %s", data)
+	}
+
+	plain := newTransactionItem(&api.Transaction{ID: "manual-2", Amount: -1000})
+	data, _ = json.Marshal(plain)
+	if strings.Contains(string(data), "matched") {
+		t.Errorf("unmatched transaction should omit matched fields: %s", data)
+	}
+}
+
+func TestResolveSinceDate(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		flag          string
+		configDefault string
+		want          string
+	}{
+		{"built-in 30 days", "", "", "2025-03-01"},
+		{"config default applied when flag absent", "", "60d", "2025-01-30"},
+		{"config absolute date", "", "2025-01-01", "2025-01-01"},
+		{"flag beats config", "2025-03-15", "60d", "2025-03-15"},
+		{"relative flag", "7d", "60d", "2025-03-24"},
+		{"zero days is today", "0d", "", "2025-03-31"},
+		{"weeks", "2w", "", "2025-03-17"},
+		{"months clamp to a shorter month", "1m", "", "2025-02-28"},
+		{"config today", "", "today", "2025-03-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSinceDate(tt.flag, tt.configDefault, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveSinceDate(%q, %q) = %s, want %s", tt.flag, tt.configDefault, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveSinceDate_Invalid(t *testing.T) {
+	now := time.Now()
+
+	_, err := resolveSinceDate("", "two months", now)
+	if err == nil || !strings.Contains(err.Error(), "default_since") {
+		t.Errorf("expected error naming default_since, got %v", err)
+	}
+
+	_, err = resolveSinceDate("-5d", "", now)
+	if err == nil || !strings.Contains(err.Error(), "--since") {
+		t.Errorf("expected error naming --since, got %v", err)
+	}
+
+	_, err = resolveSinceDate("2025-13-01", "", now)
+	if err == nil {
+		t.Error("expected error for invalid date")
+	}
+}
+
+func TestFilterUntil(t *testing.T) {
+	transactions := []*api.Transaction{
+		{ID: "dec", Date: "2024-12-31"},
+		{ID: "jan", Date: "2025-01-31"},
+		{ID: "feb", Date: "2025-02-01"},
+	}
+
+	got := filterUntil(transactions, "2025-01-31")
+	if len(got) != 2 || got[0].ID != "dec" || got[1].ID != "jan" {
+		t.Errorf("filterUntil kept %v, want dec and jan", got)
+	}
+}
+
+func TestValidateUntilDate(t *testing.T) {
+	tests := []struct {
+		until   string
+		wantErr bool
+	}{
+		{"", false},
+		{"2025-01-31", false},
+		{"2025-01-01", false}, // same day as --since
+		{"2024-12-31", true},
+		{"2025-02-30", true},
+		{"30d", true},
+	}
+
+	for _, tt := range tests {
+		err := validateUntilDate(tt.until, "2025-01-01")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateUntilDate(%q) error = %v, wantErr %v", tt.until, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRunningBalances(t *testing.T) {
+	// Out of order on purpose; the account is at 700.00 today
+	transactions := []*api.Transaction{
+		{ID: "rent", Date: "2025-01-05", Amount: -800000},
+		{ID: "paycheck", Date: "2025-01-01", Amount: 1500000},
+		{ID: "coffee", Date: "2025-01-05", Amount: -5000},
+		{ID: "groceries", Date: "2025-01-10", Amount: -95000},
+	}
+
+	got := runningBalances(transactions, 700000)
+	want := map[string]int64{
+		"paycheck":  1600000, // opening balance 700.00 - 600.00 = 100.00
+		"rent":      800000,
+		"coffee":    795000,
+		"groceries": 700000, // the last one ends at today's balance
+	}
+	for id, balance := range want {
+		if got[id] != balance {
+			t.Errorf("balance after %s = %d, want %d", id, got[id], balance)
+		}
+	}
+	if transactions[0].ID != "rent" {
+		t.Error("runningBalances reordered its input")
+	}
+}
+
+func TestGroupByPeriod(t *testing.T) {
+	// 2025-01-05 is a Sunday, 2025-01-06 a Monday
+	transactions := []*api.Transaction{
+		{ID: "mon", Date: "2025-01-06", Amount: -20000},
+		{ID: "sun", Date: "2025-01-05", Amount: -10000},
+		{ID: "sat", Date: "2025-01-11", Amount: -5000},
+		{ID: "feb", Date: "2025-02-03", Amount: 100000},
+	}
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		groupBy, weekStart string
+		want               []string // "label start end count subtotal"
+	}{
+		{"week", "", []string{
+			"2025-W01 2024-12-30 2025-01-05 1 -10000",
+			"2025-W02 2025-01-06 2025-01-12 2 -25000",
+			"2025-W06 2025-02-03 2025-02-09 1 100000",
+		}},
+		{"week", "sunday", []string{
+			"2025-01-05..2025-01-11 2025-01-05 2025-01-11 3 -35000",
+			"2025-02-02..2025-02-08 2025-02-02 2025-02-08 1 100000",
+		}},
+		{"month", "", []string{
+			"2025-01 2025-01-01 2025-01-31 3 -35000",
+			"2025-02 2025-02-01 2025-02-28 1 100000",
+		}},
+		{"day", "", []string{
+			"2025-01-05 2025-01-05 2025-01-05 1 -10000",
+			"2025-01-06 2025-01-06 2025-01-06 1 -20000",
+			"2025-01-11 2025-01-11 2025-01-11 1 -5000",
+			"2025-02-03 2025-02-03 2025-02-03 1 100000",
+		}},
+		{"10d", "", []string{
+			"2025-01-01..2025-01-10 2025-01-01 2025-01-10 2 -30000",
+			"2025-01-11..2025-01-20 2025-01-11 2025-01-20 1 -5000",
+			"2025-01-31..2025-02-09 2025-01-31 2025-02-09 1 100000",
+		}},
+	}
+
+	for _, tt := range tests {
+		p, err := parsePeriod(tt.groupBy, tt.weekStart, since)
+		if err != nil {
+			t.Fatalf("parsePeriod(%q, %q): %v", tt.groupBy, tt.weekStart, err)
+		}
+		var got []string
+		for _, s := range groupByPeriod(transactions, *p) {
+			got = append(got, fmt.Sprintf("%s %s %s %d %d", s.Label, s.Start, s.End, s.Count, s.Subtotal))
+		}
+		if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("--group-by %s --week-start %q:\n got %q\nwant %q", tt.groupBy, tt.weekStart, got, tt.want)
+		}
+	}
+}
+
+func TestParsePeriod_Invalid(t *testing.T) {
+	tests := []struct{ groupBy, weekStart string }{
+		{"fortnight", ""},
+		{"0d", ""},
+		{"d", ""},
+		{"-7d", ""},
+		{"week", "tuesday"},
+		{"month", "sunday"}, // --week-start only applies to weeks
+	}
+	for _, tt := range tests {
+		if _, err := parsePeriod(tt.groupBy, tt.weekStart, time.Time{}); err == nil {
+			t.Errorf("parsePeriod(%q, %q) succeeded", tt.groupBy, tt.weekStart)
+		}
+	}
+}
+
+func TestFilterByAmount(t *testing.T) {
+	transactions := []*api.Transaction{
+		{ID: "coffee", Amount: -4500},
+		{ID: "groceries", Amount: -100000},
+		{ID: "refund", Amount: 250000},
+		{ID: "rent", Amount: -1500000},
+	}
+	amount := func(dollars int64) *int64 {
+		m := dollars * 1000
+		return &m
+	}
+	ids := func(ts []*api.Transaction) string {
+		var s []string
+		for _, t := range ts {
+			s = append(s, t.ID)
+		}
+		return strings.Join(s, ",")
+	}
+
+	tests := []struct {
+		name     string
+		min, max *int64
+		want     string
+	}{
+		{"inclusive on both ends", amount(100), amount(250), "groceries,refund"},
+		{"only min", amount(200), nil, "refund,rent"},
+		{"only max", nil, amount(100), "coffee,groceries"},
+	}
+	for _, tt := range tests {
+		if got := ids(filterByAmount(transactions, tt.min, tt.max)); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTransactionOrder(t *testing.T) {
+	transactions := []*api.Transaction{
+		{ID: "coffee", Date: "2026-03-02", Amount: -4500, PayeeName: "coffee shop"},
+		{ID: "refund", Date: "2026-03-01", Amount: 250000, PayeeName: "Amazon"},
+		{ID: "rent", Date: "2026-03-01", Amount: -1500000, PayeeName: "Landlord"},
+		{ID: "bakery", Date: "2026-03-03", Amount: -4500, PayeeName: "Bakery"},
+	}
+	ids := func(ts []*api.Transaction) string {
+		var s []string
+		for _, t := range ts {
+			s = append(s, t.ID)
+		}
+		return strings.Join(s, ",")
+	}
+
+	tests := []struct {
+		key  string
+		desc bool
+		want string
+	}{
+		{"date", false, "refund,rent,coffee,bakery"},
+		{"date", true, "bakery,coffee,refund,rent"},
+		{"amount", false, "rent,coffee,bakery,refund"},
+		{"amount", true, "refund,coffee,bakery,rent"},
+		{"abs-amount", true, "rent,refund,coffee,bakery"},
+		{"payee", false, "refund,bakery,coffee,rent"},
+	}
+	for _, tt := range tests {
+		sorted := slices.Clone(transactions)
+		slices.SortStableFunc(sorted, transactionOrder(tt.key, tt.desc))
+		if got := ids(sorted); got != tt.want {
+			t.Errorf("%s desc=%v: got %s, want %s", tt.key, tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestTransferLabel(t *testing.T) {
+	names := map[string]string{"acc-sav": "Savings"}
+	tests := []struct {
+		name string
+		txn  api.Transaction
+		want string
+	}{
+		{"outflow", api.Transaction{Amount: -50000, TransferAccountID: "acc-sav", PayeeName: "Transfer : Savings"}, "Transfer → Savings"},
+		{"inflow", api.Transaction{Amount: 50000, TransferAccountID: "acc-sav", PayeeName: "Transfer : Savings"}, "Transfer ← Savings"},
+		{"unknown account falls back to payee", api.Transaction{Amount: -1000, TransferAccountID: "acc-new", PayeeName: "Transfer : Brokerage"}, "Transfer → Brokerage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transferLabel(&tt.txn, names); got != tt.want {
+				t.Errorf("transferLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithoutTransfers(t *testing.T) {
+	transactions := []*api.Transaction{
+		{ID: "groceries", Amount: -80000},
+		{ID: "to-savings", Amount: -50000, TransferAccountID: "acc-sav"},
+		{ID: "paycheck", Amount: 2000000},
+	}
+	var ids []string
+	for _, txn := range withoutTransfers(transactions) {
+		ids = append(ids, txn.ID)
+	}
+	if strings.Join(ids, ",") != "groceries,paycheck" {
+		t.Errorf("withoutTransfers kept %v", ids)
+	}
+
+	item := newTransactionItem(transactions[1])
+	if !item.IsTransfer || item.TransferAccountID != "acc-sav" {
+		t.Errorf("transfer item = %+v, want is_transfer and the other account", item)
+	}
+	data, _ := json.Marshal(newTransactionItem(transactions[0]))
+	if !strings.Contains(string(data), `"is_transfer":false`) {
+		t.Errorf("non-transfer should still say is_transfer false: %s", data)
+	}
+}
+
+// TestTransactionsCmd_JSONLStream tests that plain --jsonl, written as the
+// response is decoded, still applies the client-side filters.
+func TestTransactionsCmd_JSONLStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/budgets/b1/transactions" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"data":{"transactions":[
+			{"id":"t1","date":"2025-01-02","amount":-4500,"payee_name":"Coffee Shop"},
+			{"id":"t2","date":"2025-01-03","amount":-90000,"payee_name":"Grocer"},
+			{"id":"t3","date":"2025-01-04","amount":-3000,"payee_name":"Coffee Shop","deleted":true},
+			{"id":"t4","date":"2025-01-05","amount":-5200,"payee_name":"Coffee Cart"}
+		],"server_knowledge":1}}`))
+	}))
+	defer server.Close()
+	client := createTestClient(t, server)
+	client.SetDefaultBudgetID("b1")
+
+	out, err := captureStdout(t, func() error {
+		return TransactionsCmd(client, TransactionsOptions{SinceDate: "2025-01-01", Payee: "coffee", JSONL: true}, false)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var item TransactionItem
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			t.Fatalf("bad line %q: %v", line, err)
+		}
+		ids = append(ids, item.ID)
+	}
+	if strings.Join(ids, ",") != "t1,t4" {
+		t.Errorf("got %v, want t1,t4", ids)
+	}
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+// TransferOutput represents the JSON output for the transfer command.
+type TransferOutput struct {
+	BudgetID             string              `json:"budget_id"`
+	Date                 string              `json:"date"`
+	Amount               int64               `json:"amount"`
+	AmountDisplay        string              `json:"amount_display"`
+	From                 TransferAccountInfo `json:"from"`
+	To                   TransferAccountInfo `json:"to"`
+	OutflowTransactionID string              `json:"outflow_transaction_id"`
+	InflowTransactionID  string              `json:"inflow_transaction_id"`
+	Category             string              `json:"category,omitempty"`
+	Memo                 string              `json:"memo,omitempty"`
+	Approved             bool                `json:"approved"`
+}
+
+// TransferAccountInfo represents one side of a transfer.
+type TransferAccountInfo struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	OnBudget bool   `json:"on_budget"`
+}
+
+// TransferOptions holds the parameters for the transfer command.
+type TransferOptions struct {
+	Amount   string // Dollar amount; the sign is ignored, money always leaves From
+	From     string // Source account name, alias or ID
+	To       string // Destination account name, alias or ID
+	Date     string // ISO date YYYY-MM-DD (optional - uses today if empty)
+	Memo     string // Transaction memo (optional)
+	Category string // Only for transfers between on-budget and off-budget accounts
+	Approved bool   // false creates the transfer unapproved, for review in YNAB
+}
+
+// transferPlan says which side of a transfer to create. YNAB creates the
+// other side itself.
+type transferPlan struct {
+	Account *api.Account // account the transaction is created in
+	Payee   *api.Account // account whose transfer payee is used
+	Amount  int64        // milliunits, signed for Account
+	Outflow bool         // whether the created transaction is the outflow
+}
+
+// TransferCmd records a transfer between two accounts.
+//
+// A transfer between an on-budget and an off-budget account is created on the
+// on-budget side, since that is the side a category belongs to.
+func TransferCmd(client *api.Client, opts TransferOptions, jsonOutput bool) error {
+	if opts.Amount == "" {
+		return fmt.Errorf("amount is required")
+	}
+	if opts.From == "" || opts.To == "" {
+		return fmt.Errorf("both --from and --to are required")
+	}
+
+	amountMilliunits, err := transform.ParseDollarsToMilliunits(opts.Amount)
+	if err != nil {
+		return err
+	}
+	if amountMilliunits < 0 {
+		amountMilliunits = -amountMilliunits
+	}
+	if amountMilliunits == 0 {
+		return fmt.Errorf("transfer amount must not be zero")
+	}
+
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	if opts.Date == "" {
+		opts.Date = transform.FormatDate(time.Now())
+	}
+	if transform.ParseDate(opts.Date).IsZero() {
+		return fmt.Errorf("invalid date format: %s (expected YYYY-MM-DD)", opts.Date)
+	}
+
+	accounts, err := client.GetAccounts(budgetID)
+	if err != nil {
+		return fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	// Off-budget accounts can take part in a transfer, closed ones can't
+	var candidates []*api.Account
+	for _, acc := range accounts {
+		if !acc.Closed && !acc.Deleted {
+			candidates = append(candidates, acc)
+		}
+	}
+
+	from, err := matchAccount(candidates, opts.From)
+	if err != nil {
+		return err
+	}
+	to, err := matchAccount(candidates, opts.To)
+	if err != nil {
+		return err
+	}
+
+	plan, err := planTransfer(from, to, amountMilliunits)
+	if err != nil {
+		return err
+	}
+
+	crossesBudget := from.OnBudget != to.OnBudget
+	var categoryID, categoryName string
+	if opts.Category != "" {
+		if !crossesBudget {
+			return fmt.Errorf("--category only applies to transfers between an on-budget and an off-budget account")
+		}
+		categoryID, categoryName, err = findCategory(client, budgetID, opts.Category)
+		if err != nil {
+			return err
+		}
+	}
+
+	txn, err := client.CreateTransaction(&api.TransactionRequest{
+		BudgetID:   budgetID,
+		AccountID:  plan.Account.ID,
+		Date:       opts.Date,
+		Amount:     plan.Amount,
+		PayeeID:    plan.Payee.TransferPayeeID,
+		CategoryID: categoryID,
+		Memo:       opts.Memo,
+		Cleared:    "uncleared",
+		Approved:   opts.Approved,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create transfer: %w", err)
+	}
+
+	outflowID, inflowID := txn.ID, txn.TransferTransactionID
+	if !plan.Outflow {
+		outflowID, inflowID = inflowID, outflowID
+	}
+
+	if jsonOutput {
+		output := TransferOutput{
+			BudgetID:             budgetID,
+			Date:                 txn.Date,
+			Amount:               amountMilliunits,
+			AmountDisplay:        transform.FormatCurrency(amountMilliunits),
+			From:                 TransferAccountInfo{ID: from.ID, Name: from.Name, OnBudget: from.OnBudget},
+			To:                   TransferAccountInfo{ID: to.ID, Name: to.Name, OnBudget: to.OnBudget},
+			OutflowTransactionID: outflowID,
+			InflowTransactionID:  inflowID,
+			Category:             categoryName,
+			Memo:                 txn.Memo,
+			Approved:             txn.Approved,
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	}
+
+	fmt.Printf("Transfer created successfully!\n\n")
+	fmt.Printf("Date:     %s\n", formatDateHuman(txn.Date))
+	fmt.Printf("Amount:   %s\n", transform.FormatCurrency(amountMilliunits))
+	fmt.Printf("From:     %s%s\n", from.Name, offBudgetSuffix(from))
+	fmt.Printf("To:       %s%s\n", to.Name, offBudgetSuffix(to))
+
+	if categoryName != "" {
+		fmt.Printf("Category: %s\n", categoryName)
+	} else if crossesBudget {
+		fmt.Printf("Category: Uncategorized (set one on the %s side in YNAB)\n", plan.Account.Name)
+	}
+
+	if txn.Memo != "" {
+		fmt.Printf("Memo:     %s\n", txn.Memo)
+	}
+
+	if !txn.Approved {
+		fmt.Printf("Approved: no (review it in YNAB)\n")
+	}
+
+	fmt.Printf("\nOutflow transaction ID: %s\n", outflowID)
+	if inflowID != "" {
+		fmt.Printf("Inflow transaction ID:  %s\n", inflowID)
+	}
+
+	return nil
+}
+
+// planTransfer decides which side of a transfer of amount milliunits from
+// one account to another to create. The outflow side is created unless only
+// the destination is on-budget, so a category can always go on the created
+// transaction.
+func planTransfer(from, to *api.Account, amount int64) (transferPlan, error) {
+	if from.ID == to.ID {
+		return transferPlan{}, fmt.Errorf("--from and --to both resolve to '%s'; a transfer needs two different accounts", from.Name)
+	}
+
+	plan := transferPlan{Account: from, Payee: to, Amount: -amount, Outflow: true}
+	if !from.OnBudget && to.OnBudget {
+		plan = transferPlan{Account: to, Payee: from, Amount: amount, Outflow: false}
+	}
+
+	if plan.Payee.TransferPayeeID == "" {
+		return transferPlan{}, fmt.Errorf("account '%s' has no transfer payee", plan.Payee.Name)
+	}
+	return plan, nil
+}
+
+// offBudgetSuffix marks off-budget accounts in human output.
+func offBudgetSuffix(acc *api.Account) string {
+	if acc.OnBudget {
+		return ""
+	}
+	return " (off-budget)"
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestPlanTransfer(t *testing.T) {
+	checking := &api.Account{ID: "chk", Name: "Checking", OnBudget: true, TransferPayeeID: "payee-chk"}
+	savings := &api.Account{ID: "sav", Name: "Savings", OnBudget: true, TransferPayeeID: "payee-sav"}
+	mortgage := &api.Account{ID: "mtg", Name: "Mortgage", OnBudget: false, TransferPayeeID: "payee-mtg"}
+	brokerage := &api.Account{ID: "brk", Name: "Brokerage", OnBudget: false, TransferPayeeID: "payee-brk"}
+
+	tests := []struct {
+		name        string
+		from, to    *api.Account
+		wantAccount string
+		wantPayee   string
+		wantAmount  int64
+		wantOutflow bool
+	}{
+		{"on-budget to on-budget", checking, savings, "chk", "payee-sav", -50000, true},
+		{"on-budget to off-budget", checking, mortgage, "chk", "payee-mtg", -50000, true},
+		{"off-budget to on-budget", brokerage, checking, "chk", "payee-brk", 50000, false},
+		{"off-budget to off-budget", brokerage, mortgage, "brk", "payee-mtg", -50000, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := planTransfer(tt.from, tt.to, 50000)
+			if err != nil {
+				t.Fatalf("planTransfer: %v", err)
+			}
+			if plan.Account.ID != tt.wantAccount || plan.Payee.TransferPayeeID != tt.wantPayee {
+				t.Errorf("created in %s with payee %s, want %s with %s",
+					plan.Account.ID, plan.Payee.TransferPayeeID, tt.wantAccount, tt.wantPayee)
+			}
+			if plan.Amount != tt.wantAmount || plan.Outflow != tt.wantOutflow {
+				t.Errorf("amount %d (outflow %v), want %d (outflow %v)",
+					plan.Amount, plan.Outflow, tt.wantAmount, tt.wantOutflow)
+			}
+		})
+	}
+
+	if _, err := planTransfer(checking, checking, 50000); err == nil {
+		t.Error("expected an error for a transfer to the same account")
+	}
+	if _, err := planTransfer(checking, &api.Account{ID: "x", Name: "X", OnBudget: true}, 50000); err == nil {
+		t.Error("expected an error when the destination has no transfer payee")
+	}
+}
+
+// TestTransferCmd_InvalidAmount tests that amounts ParseFloat would accept
+// but that aren't money are refused before any request is made.
+func TestTransferCmd_InvalidAmount(t *testing.T) {
+	for _, amount := range []string{"NaN", "Inf", "1e30", "12.3456"} {
+		opts := TransferOptions{Amount: amount, From: "Checking", To: "Savings"}
+		if err := TransferCmd(nil, opts, false); err == nil {
+			t.Errorf("transfer %s: expected an error", amount)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/storage"
+)
+
+// UndoOutput represents the JSON output for the undo command.
+type UndoOutput struct {
+	BudgetID string       `json:"budget_id"`
+	Undone   []ActionItem `json:"undone"`
+}
+
+// UndoListOutput represents the JSON output for undo --list.
+type UndoListOutput struct {
+	BudgetID string       `json:"budget_id"`
+	Actions  []ActionItem `json:"actions"`
+}
+
+// ActionItem is one logged action.
+type ActionItem struct {
+	ID      string `json:"id"`
+	At      string `json:"at"`
+	Kind    string `json:"kind"`
+	Summary string `json:"summary"`
+}
+
+func newActionItem(a storage.Action) ActionItem {
+	return ActionItem{ID: a.ID, At: a.At.Format(time.RFC3339), Kind: a.Kind, Summary: a.Summary}
+}
+
+// recordAction appends a to the action log for `ynab undo`. The change has
+// already been made, so a failure only warns.
+func recordAction(a storage.Action) {
+	if err := storage.AppendHistory(a); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: done, but could not record it for undo: %v\n", err)
+	}
+}
+
+// UndoCmd reverses the default budget's last steps actions, most recent
+// first. It stops at the first one that fails; those already reversed stay
+// reversed.
+func UndoCmd(client *api.Client, steps int, jsonOutput bool) error {
+	if steps < 1 {
+		return fmt.Errorf("--steps must be at least 1")
+	}
+
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	actions, err := storage.LoadHistory()
+	if err != nil {
+		return err
+	}
+	reversible := storage.Reversible(actions, budgetID)
+	if len(reversible) == 0 {
+		return fmt.Errorf("nothing to undo")
+	}
+	if steps > len(reversible) {
+		return fmt.Errorf("only %d action(s) can be undone\n\nRun 'ynab undo --list' to see them", len(reversible))
+	}
+
+	undone := make([]ActionItem, 0, steps)
+	for _, a := range reversible[:steps] {
+		if err := reverseAction(client, a); err != nil {
+			if len(undone) > 0 {
+				return fmt.Errorf("undid %d action(s), then failed to undo %q: %w", len(undone), a.Summary, err)
+			}
+			return fmt.Errorf("failed to undo %q: %w", a.Summary, err)
+		}
+		recordAction(storage.Action{BudgetID: budgetID, Kind: storage.KindUndo, Summary: "undo " + a.Summary, Undoes: a.ID})
+		undone = append(undone, newActionItem(a))
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(UndoOutput{BudgetID: budgetID, Undone: undone})
+	}
+
+	for _, item := range undone {
+		fmt.Printf("Undone: %s\n", item.Summary)
+	}
+	return nil
+}
+
+// UndoListCmd lists the default budget's actions that can be undone, most
+// recent first.
+func UndoListCmd(client *api.Client, jsonOutput bool) error {
+	budgetID, err := client.GetDefaultBudgetID()
+	if err != nil {
+		return err
+	}
+
+	actions, err := storage.LoadHistory()
+	if err != nil {
+		return err
+	}
+	items := make([]ActionItem, 0)
+	for _, a := range storage.Reversible(actions, budgetID) {
+		items = append(items, newActionItem(a))
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(UndoListOutput{BudgetID: budgetID, Actions: items})
+	}
+
+	if len(items) == 0 {
+		fmt.Println("Nothing to undo.")
+		return nil
+	}
+
+	tbl := table{columns: []tableColumn{
+		{Header: "#", Right: true},
+		{Header: "When", MinWidth: 16},
+		{Header: "Action", MinWidth: 6},
+		{Header: "Summary"},
+	}}
+	for i, item := range items {
+		at, _ := time.Parse(time.RFC3339, item.At)
+		tbl.addRow(fmt.Sprint(i+1), at.Local().Format("2006-01-02 15:04"), item.Kind, item.Summary)
+	}
+	tbl.render(os.Stdout)
+	fmt.Println("\nUndo the last n with: ynab undo --steps n")
+	return nil
+}
+
+// reverseAction makes the API calls that put back what a changed. Values
+// are restored as they were before the action, so a later change to the
+// same transaction or category made in YNAB itself is overwritten.
+func reverseAction(client *api.Client, a storage.Action) error {
+	switch a.Kind {
+	case storage.KindAdd:
+		_, err := client.DeleteTransaction(a.BudgetID, a.Created)
+		return err
+	case storage.KindEdit:
+		if a.Before == nil {
+			return fmt.Errorf("the log has no copy of the transaction")
+		}
+		_, err := client.UpdateTransaction(a.BudgetID, a.Before.ID, revertUpdates(a.Before))
+		return err
+	case storage.KindMove, storage.KindAssign:
+		for _, b := range a.Budgeted {
+			if _, err := client.UpdateCategoryBudget(b.CategoryID, b.Budgeted, b.Month, a.BudgetID); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("don't know how to undo %q", a.Kind)
+	}
+}
+
+// revertUpdates is the update that sets every field `edit` can change back
+// to its value in before.
+func revertUpdates(before *api.Transaction) map[string]interface{} {
+	updates := map[string]interface{}{
+		"account_id":  before.AccountID,
+		"date":        before.Date,
+		"amount":      before.Amount,
+		"memo":        before.Memo,
+		"cleared":     before.Cleared,
+		"approved":    before.Approved,
+		"category_id": nil,
+		"flag_color":  nil,
+	}
+	if before.PayeeID != "" {
+		updates["payee_id"] = before.PayeeID
+	} else {
+		updates["payee_name"] = before.PayeeName
+	}
+	if before.CategoryID != "" {
+		updates["category_id"] = before.CategoryID
+	}
+	if before.FlagColor != "" {
+		updates["flag_color"] = before.FlagColor
+	}
+	return updates
+}
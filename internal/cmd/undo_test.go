@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/storage"
+)
+
+func TestUndoCmd(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodDelete:
+			w.Write([]byte(`{"data":{"transaction":{"id":"txn-1","deleted":true}}}`))
+		case http.MethodPatch:
+			w.Write([]byte(`{"data":{"category":{"id":"cat-1","budgeted":50000}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := createTestClient(t, server)
+	client.SetDefaultBudgetID("budget-1")
+
+	for _, a := range []storage.Action{
+		{BudgetID: "budget-1", Kind: storage.KindAdd, Summary: "add coffee", Created: "txn-1"},
+		{BudgetID: "budget-1", Kind: storage.KindAssign, Summary: "assign groceries",
+			Budgeted: []storage.BudgetedBefore{{CategoryID: "cat-1", Month: "2025-03-01", Budgeted: 50000}}},
+	} {
+		if err := storage.AppendHistory(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := captureStdout(t, func() error { return UndoCmd(client, 3, false) }); err == nil {
+		t.Error("undoing more steps than logged succeeded")
+	}
+	if len(requests) != 0 {
+		t.Fatalf("a refused undo sent %v", requests)
+	}
+
+	out, err := captureStdout(t, func() error { return UndoCmd(client, 2, false) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"PATCH /budgets/budget-1/months/2025-03-01/categories/cat-1",
+		"DELETE /budgets/budget-1/transactions/txn-1",
+	}
+	if len(requests) != len(want) || requests[0] != want[0] || requests[1] != want[1] {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+	if out != "Undone: assign groceries\nUndone: add coffee\n" {
+		t.Errorf("output = %q", out)
+	}
+
+	// Both are logged as undone, so there's nothing left
+	if _, err := captureStdout(t, func() error { return UndoCmd(client, 1, false) }); err == nil {
+		t.Error("second undo succeeded with nothing left to undo")
+	}
+	if _, err := os.Stat(storage.HistoryPath()); err != nil {
+		t.Errorf("history file: %v", err)
+	}
+}
+
+func TestRevertUpdates(t *testing.T) {
+	before := &api.Transaction{
+		ID: "txn-1", AccountID: "acc-1", Date: "2025-03-01", Amount: -42000,
+		PayeeID: "payee-1", PayeeName: "Cafe", CategoryID: "cat-1",
+		Memo: "lunch", Cleared: "cleared", Approved: true,
+	}
+	updates := revertUpdates(before)
+	if updates["payee_id"] != "payee-1" || updates["category_id"] != "cat-1" ||
+		updates["amount"] != int64(-42000) || updates["memo"] != "lunch" || updates["cleared"] != "cleared" {
+		t.Errorf("updates = %v", updates)
+	}
+	// No flag before means the flag an edit set is cleared
+	if v, ok := updates["flag_color"]; !ok || v != nil {
+		t.Errorf("flag_color = %v, %v; want null", v, ok)
+	}
+
+	before.FlagColor = "red"
+	if updates := revertUpdates(before); updates["flag_color"] != "red" {
+		t.Errorf("flag_color = %v, want red", updates["flag_color"])
+	}
+
+	// No category before means the category an edit set is cleared
+	before.CategoryID = ""
+	if v, ok := revertUpdates(before)["category_id"]; !ok || v != nil {
+		t.Errorf("category_id = %v, %v; want null", v, ok)
+	}
+}
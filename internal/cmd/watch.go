@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+// DefaultWatchInterval is how often transactions --watch polls when
+// --interval isn't given.
+const DefaultWatchInterval = 60 * time.Second
+
+// maxWatchBackoff caps how far a rate-limited --watch stretches its interval.
+const maxWatchBackoff = 30 * time.Minute
+
+// watchWait sleeps for d or until ctx is done, reporting whether to poll
+// again. Tests replace it.
+var watchWait = func(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// watchTransactions polls until ctx is done (Ctrl-C), printing the
+// transactions whose IDs no earlier poll returned. The first poll prints
+// what is already there, honouring --sort and --limit like a plain listing.
+// While YNAB or the client-side limiter refuses requests, the wait doubles
+// each poll, up to maxWatchBackoff; any other error ends the watch.
+func watchTransactions(ctx context.Context, opts TransactionsOptions, poll func() ([]*api.Transaction, error), jsonLines bool) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	var order func(a, b *api.Transaction) int
+	if opts.Sort != "" {
+		order = transactionOrder(opts.Sort, opts.Desc)
+	}
+
+	seen := make(map[string]bool)
+	wait := interval
+	for first := true; ; {
+		transactions, err := poll()
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case api.IsRateLimitError(err) || api.IsCircuitOpenError(err):
+			wait = max(interval, min(wait*2, maxWatchBackoff))
+			reason := "rate limited"
+			if api.IsCircuitOpenError(err) {
+				reason = "YNAB keeps failing"
+			}
+			fmt.Fprintf(os.Stderr, "Warning: %s; next poll in %s\n", reason, wait)
+		case err != nil:
+			return fmt.Errorf("failed to get transactions: %w", err)
+		default:
+			wait = interval
+			var fresh []*api.Transaction
+			for _, t := range transactions {
+				if !seen[t.ID] {
+					seen[t.ID] = true
+					fresh = append(fresh, t)
+				}
+			}
+			if order != nil {
+				slices.SortStableFunc(fresh, order)
+			}
+			if first && opts.Limit > 0 && len(fresh) > opts.Limit {
+				if order != nil {
+					fresh = fresh[:opts.Limit]
+				} else {
+					fresh = fresh[len(fresh)-opts.Limit:]
+				}
+			}
+			if err := printWatched(fresh, first, interval, jsonLines); err != nil {
+				return err
+			}
+			first = false
+		}
+
+		if !watchWait(ctx, wait) {
+			return nil
+		}
+	}
+}
+
+// printWatched prints one poll's new transactions: a JSON object per line
+// with jsonLines, otherwise a table headed by the time of the poll. Polls
+// with nothing new print nothing after the first.
+func printWatched(transactions []*api.Transaction, first bool, interval time.Duration, jsonLines bool) error {
+	if jsonLines {
+		lines := newJSONLines(os.Stdout)
+		for _, t := range transactions {
+			if err := lines.write(newTransactionItem(t)); err != nil {
+				return err
+			}
+		}
+		return lines.flush()
+	}
+
+	if first {
+		fmt.Printf("Watching for new transactions every %s (Ctrl-C to stop)\n", interval)
+	}
+	if len(transactions) == 0 {
+		return nil
+	}
+	fmt.Printf("\n%s: %d new transaction(s)\n\n", time.Now().Format("15:04:05"), len(transactions))
+
+	tbl := table{columns: []tableColumn{
+		{Header: "Date", MinWidth: 12},
+		{Header: "Payee", MinWidth: 15, MaxWidth: 30},
+		{Header: "Category", MinWidth: 12, MaxWidth: 20},
+		{Header: "Amount", Right: true, MinWidth: 12},
+		{Header: "Account", MinWidth: 10, MaxWidth: 15},
+	}}
+	for _, t := range transactions {
+		category := t.CategoryName
+		if t.TransferAccountID != "" {
+			category = transferLabel(t, nil)
+		}
+		tbl.addRow(t.Date, t.PayeeName, category, formatAmount(t.Amount), t.AccountName)
+	}
+	tbl.render(os.Stdout)
+	return nil
+}
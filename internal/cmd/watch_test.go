@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestWatchTransactions(t *testing.T) {
+	txn := func(id, date string) *api.Transaction {
+		return &api.Transaction{ID: id, Date: date, Amount: -1000, PayeeName: "Payee " + id}
+	}
+	type pollResult struct {
+		transactions []*api.Transaction
+		err          error
+	}
+	polls := []pollResult{
+		{transactions: []*api.Transaction{txn("a", "2025-01-01"), txn("b", "2025-01-02"), txn("c", "2025-01-03")}},
+		{transactions: []*api.Transaction{txn("a", "2025-01-01"), txn("b", "2025-01-02"), txn("c", "2025-01-03")}},
+		{err: api.NewRateLimitError(60)},
+		{err: api.NewRateLimitError(60)},
+		{transactions: []*api.Transaction{txn("b", "2025-01-02"), txn("c", "2025-01-03"), txn("d", "2025-01-04")}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var waits []time.Duration
+	oldWait := watchWait
+	watchWait = func(_ context.Context, d time.Duration) bool {
+		waits = append(waits, d)
+		return len(waits) < len(polls)
+	}
+	t.Cleanup(func() { watchWait = oldWait })
+
+	n := 0
+	poll := func() ([]*api.Transaction, error) {
+		p := polls[n]
+		n++
+		return p.transactions, p.err
+	}
+
+	opts := TransactionsOptions{Interval: time.Minute, Limit: 2}
+	out, err := captureStdout(t, func() error {
+		return watchTransactions(ctx, opts, poll, true)
+	})
+	if err != nil {
+		t.Fatalf("watchTransactions: %v", err)
+	}
+
+	// The first poll is limited to the two most recent; later polls print
+	// only IDs not seen before, and "a" was seen even though it wasn't shown
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var item TransactionItem
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		ids = append(ids, item.ID)
+	}
+	if got := strings.Join(ids, ","); got != "b,c,d" {
+		t.Errorf("printed %s, want b,c,d", got)
+	}
+
+	want := []time.Duration{time.Minute, time.Minute, 2 * time.Minute, 4 * time.Minute, time.Minute}
+	if len(waits) != len(want) {
+		t.Fatalf("waits = %v, want %v", waits, want)
+	}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Errorf("wait %d = %s, want %s", i, waits[i], want[i])
+		}
+	}
+}
+
+func TestWatchTransactions_Stops(t *testing.T) {
+	oldWait := watchWait
+	watchWait = func(context.Context, time.Duration) bool { return true }
+	t.Cleanup(func() { watchWait = oldWait })
+
+	t.Run("error", func(t *testing.T) {
+		poll := func() ([]*api.Transaction, error) { return nil, errors.New("boom") }
+		err := watchTransactions(context.Background(), TransactionsOptions{}, poll, true)
+		if err == nil || !strings.Contains(err.Error(), "boom") {
+			t.Errorf("expected the poll error, got %v", err)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		poll := func() ([]*api.Transaction, error) {
+			cancel()
+			return nil, errors.New("request canceled: context canceled")
+		}
+		if err := watchTransactions(ctx, TransactionsOptions{}, poll, true); err != nil {
+			t.Errorf("expected a clean stop, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+// WhoamiOutput represents the JSON output for the whoami command. It isn't
+// tied to a budget, so it has no budget_id.
+type WhoamiOutput struct {
+	UserID string `json:"user_id"`
+}
+
+// WhoamiCmd prints the ID of the YNAB user the access token belongs to.
+func WhoamiCmd(client *api.Client, jsonOutput bool) error {
+	user, err := client.GetUser()
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(WhoamiOutput{UserID: user.ID})
+	}
+
+	fmt.Printf("User ID: %s\n", user.ID)
+	return nil
+}
@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -15,13 +18,59 @@ const (
 	ConfigDir = ".ynab"
 	// ConfigFile is the configuration file name.
 	ConfigFile = "config"
+	// AliasPrefix prefixes alias keys in the config file (alias.cc=...).
+	AliasPrefix = "alias."
+	// ProfilePrefix prefixes profile section names ([profile.business]).
+	ProfilePrefix = "profile."
+	// DefaultDuplicateWindowDays is the duplicate_window_days used when the
+	// key is not set.
+	DefaultDuplicateWindowDays = 3
 )
 
 // Config represents the YNAB CLI configuration.
 type Config struct {
 	AccessToken     string
 	DefaultBudgetID string
+	DefaultAccount  string
+	DefaultSince    string
 	APIBaseURL      string
+
+	// ApproveOnAdd controls whether `add` creates approved transactions.
+	// nil means unset, which keeps YNAB's default of approved.
+	ApproveOnAdd *bool
+
+	// DuplicateWindowDays is how many days apart `add` still treats an
+	// existing transaction with the same amount and payee as a likely
+	// duplicate. nil means unset, which uses DefaultDuplicateWindowDays.
+	DuplicateWindowDays *int
+
+	// LookupCacheTTL enables the on-disk cache of account and category
+	// names for this long. Zero (the default) leaves it off.
+	LookupCacheTTL time.Duration
+
+	// TableStyle is the style human-readable tables use when --table-style
+	// and --plain are not given: plain, box or markdown. Empty means plain.
+	TableStyle string
+
+	// Aliases map short names to account, category or payee names (or IDs).
+	// Stored as alias.<name>=<target>; names are lowercase.
+	Aliases map[string]string
+
+	// OAuth refresh support (optional; Personal Access Token users leave these empty)
+	RefreshToken      string
+	OAuthClientID     string
+	OAuthClientSecret string
+
+	// Profiles are named token/budget pairs, stored as [profile.<name>]
+	// sections after the top-level keys.
+	Profiles map[string]*Profile
+}
+
+// Profile is a named token/budget pair selected with --profile. Empty fields
+// fall back to the top-level settings.
+type Profile struct {
+	AccessToken     string
+	DefaultBudgetID string
 }
 
 // Path returns the full path to the config file (~/.ynab/config).
@@ -60,6 +109,11 @@ func Load() (*Config, error) {
 	}
 	defer f.Close()
 
+	// Keys after a [profile.<name>] header belong to that profile; keys
+	// under any other section are ignored
+	var profile *Profile
+	inSection := false
+
 	scanner := bufio.NewScanner(f)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
@@ -69,6 +123,20 @@ func Load() (*Config, error) {
 			continue
 		}
 
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			inSection = true
+			profile = nil
+			section := strings.TrimSpace(line[1 : len(line)-1])
+			if name, ok := strings.CutPrefix(section, ProfilePrefix); ok && name != "" {
+				if cfg.Profiles == nil {
+					cfg.Profiles = make(map[string]*Profile)
+				}
+				profile = &Profile{}
+				cfg.Profiles[name] = profile
+			}
+			continue
+		}
+
 		// Parse key=value
 		parts := strings.SplitN(line, "=", 2)
 		if len(parts) != 2 {
@@ -78,13 +146,56 @@ func Load() (*Config, error) {
 		key := strings.TrimSpace(parts[0])
 		value := strings.TrimSpace(parts[1])
 
+		if inSection {
+			if profile != nil {
+				switch key {
+				case "access_token":
+					profile.AccessToken = value
+				case "default_budget_id":
+					profile.DefaultBudgetID = value
+				}
+			}
+			continue
+		}
+
 		switch key {
 		case "access_token":
 			cfg.AccessToken = value
 		case "default_budget_id":
 			cfg.DefaultBudgetID = value
+		case "default_account":
+			cfg.DefaultAccount = value
+		case "default_since":
+			cfg.DefaultSince = value
+		case "approve_on_add":
+			if b, err := strconv.ParseBool(value); err == nil {
+				cfg.ApproveOnAdd = &b
+			}
+		case "duplicate_window_days":
+			if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+				cfg.DuplicateWindowDays = &n
+			}
+		case "lookup_cache_ttl":
+			if d, err := time.ParseDuration(value); err == nil && d > 0 {
+				cfg.LookupCacheTTL = d
+			}
+		case "table_style":
+			cfg.TableStyle = value
 		case "api_base_url":
 			cfg.APIBaseURL = value
+		case "refresh_token":
+			cfg.RefreshToken = value
+		case "oauth_client_id":
+			cfg.OAuthClientID = value
+		case "oauth_client_secret":
+			cfg.OAuthClientSecret = value
+		default:
+			if name, ok := strings.CutPrefix(key, AliasPrefix); ok && name != "" {
+				if cfg.Aliases == nil {
+					cfg.Aliases = make(map[string]string)
+				}
+				cfg.Aliases[strings.ToLower(name)] = value
+			}
 		}
 	}
 
@@ -120,6 +231,36 @@ func Save(cfg *Config) error {
 	b.WriteString("\n")
 	b.WriteString("# Default budget ID\n")
 	fmt.Fprintf(&b, "default_budget_id=%s\n", cfg.DefaultBudgetID)
+	if cfg.DefaultAccount != "" {
+		b.WriteString("\n")
+		b.WriteString("# Default account name for new transactions\n")
+		fmt.Fprintf(&b, "default_account=%s\n", cfg.DefaultAccount)
+	}
+	if cfg.DefaultSince != "" {
+		b.WriteString("\n")
+		b.WriteString("# Default transactions window when --since is not given (e.g. 60d)\n")
+		fmt.Fprintf(&b, "default_since=%s\n", cfg.DefaultSince)
+	}
+	if cfg.ApproveOnAdd != nil {
+		b.WriteString("\n")
+		b.WriteString("# Create transactions from 'ynab add' as approved (false = leave for review)\n")
+		fmt.Fprintf(&b, "approve_on_add=%t\n", *cfg.ApproveOnAdd)
+	}
+	if cfg.DuplicateWindowDays != nil {
+		b.WriteString("\n")
+		b.WriteString("# Days apart 'ynab add' still warns about a matching transaction (0 = same day only)\n")
+		fmt.Fprintf(&b, "duplicate_window_days=%d\n", *cfg.DuplicateWindowDays)
+	}
+	if cfg.LookupCacheTTL > 0 {
+		b.WriteString("\n")
+		b.WriteString("# Cache account and category names on disk for this long (e.g. 10m)\n")
+		fmt.Fprintf(&b, "lookup_cache_ttl=%s\n", cfg.LookupCacheTTL)
+	}
+	if cfg.TableStyle != "" {
+		b.WriteString("\n")
+		b.WriteString("# Table style when --table-style is not given: plain, box or markdown\n")
+		fmt.Fprintf(&b, "table_style=%s\n", cfg.TableStyle)
+	}
 	b.WriteString("\n")
 	b.WriteString("# API base URL\n")
 	if cfg.APIBaseURL != "" {
@@ -128,6 +269,48 @@ func Save(cfg *Config) error {
 		b.WriteString("api_base_url=https://api.youneedabudget.com/v1\n")
 	}
 
+	if len(cfg.Aliases) > 0 {
+		names := make([]string, 0, len(cfg.Aliases))
+		for name := range cfg.Aliases {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		b.WriteString("\n")
+		b.WriteString("# Aliases for account, category and payee names (managed by 'ynab alias')\n")
+		for _, name := range names {
+			fmt.Fprintf(&b, "%s%s=%s\n", AliasPrefix, name, cfg.Aliases[name])
+		}
+	}
+
+	if cfg.RefreshToken != "" {
+		b.WriteString("\n")
+		b.WriteString("# OAuth refresh (access_token is renewed automatically on 401)\n")
+		fmt.Fprintf(&b, "refresh_token=%s\n", cfg.RefreshToken)
+		fmt.Fprintf(&b, "oauth_client_id=%s\n", cfg.OAuthClientID)
+		fmt.Fprintf(&b, "oauth_client_secret=%s\n", cfg.OAuthClientSecret)
+	}
+
+	// Sections go last: every key after a header belongs to it
+	if len(cfg.Profiles) > 0 {
+		names := make([]string, 0, len(cfg.Profiles))
+		for name := range cfg.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		b.WriteString("\n")
+		b.WriteString("# Profiles, selected with --profile <name>; unset keys fall back to the values above\n")
+		for _, name := range names {
+			p := cfg.Profiles[name]
+			fmt.Fprintf(&b, "[%s%s]\n", ProfilePrefix, name)
+			if p.AccessToken != "" {
+				fmt.Fprintf(&b, "access_token=%s\n", p.AccessToken)
+			}
+			if p.DefaultBudgetID != "" {
+				fmt.Fprintf(&b, "default_budget_id=%s\n", p.DefaultBudgetID)
+			}
+		}
+	}
+
 	// Write file with 600 permissions
 	if err := os.WriteFile(path, []byte(b.String()), 0600); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
@@ -159,21 +342,216 @@ func Permissions() (os.FileMode, error) {
 	return info.Mode().Perm(), nil
 }
 
-// ResolveToken returns the access token using config priority:
-// config file > environment variable.
-func ResolveToken() string {
+// FixPermissions restricts the config file to its owner (0600).
+func FixPermissions() error {
+	path := Path()
+	if path == "" {
+		return fmt.Errorf("cannot determine config path")
+	}
+	return os.Chmod(path, 0600)
+}
+
+// Profile returns the effective token/budget pair for the named profile, with
+// unset fields filled from the top-level settings, which an --env-file
+// overrides. An empty name returns the top-level settings.
+func (c *Config) Profile(name string) (*Profile, error) {
+	effective := &Profile{AccessToken: c.AccessToken, DefaultBudgetID: c.DefaultBudgetID}
+	if v := EnvFileValue("YNAB_ACCESS_TOKEN"); v != "" {
+		effective.AccessToken = v
+	}
+	if v := EnvFileValue("YNAB_DEFAULT_BUDGET_ID"); v != "" {
+		effective.DefaultBudgetID = v
+	}
+	if name == "" {
+		return effective, nil
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return nil, fmt.Errorf("unknown profile '%s': no profiles are configured", name)
+		}
+		return nil, fmt.Errorf("unknown profile '%s' (configured: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+	if p.AccessToken != "" {
+		effective.AccessToken = p.AccessToken
+	}
+	if p.DefaultBudgetID != "" {
+		effective.DefaultBudgetID = p.DefaultBudgetID
+	}
+	return effective, nil
+}
+
+// ProfileNames returns the configured profile names, sorted.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveProfile returns the profile to use: the --profile flag if given,
+// otherwise the YNAB_PROFILE environment variable. Empty means top-level.
+func ResolveProfile(flag string) string {
+	if flag != "" {
+		return flag
+	}
+	return os.Getenv("YNAB_PROFILE")
+}
+
+// CheckProfile returns an error if profile is set but not configured.
+func CheckProfile(profile string) error {
+	if profile == "" {
+		return nil
+	}
 	cfg, err := Load()
-	if err == nil && cfg.AccessToken != "" {
-		return cfg.AccessToken
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	_, err = cfg.Profile(profile)
+	return err
+}
+
+// ResolveToken returns the access token for profile ("" for the top-level
+// settings) using config priority: profile > env file > config file >
+// environment variable.
+func ResolveToken(profile string) string {
+	cfg, err := Load()
+	if err == nil {
+		if p, err := cfg.Profile(profile); err == nil && p.AccessToken != "" {
+			return p.AccessToken
+		}
 	}
 	return os.Getenv("YNAB_ACCESS_TOKEN")
 }
 
-// ResolveBudgetID returns the default budget ID from config or environment.
-func ResolveBudgetID() string {
+// ResolveBudgetID returns the default budget ID for profile ("" for the
+// top-level settings) from config or environment.
+func ResolveBudgetID(profile string) string {
 	cfg, err := Load()
-	if err == nil && cfg.DefaultBudgetID != "" {
-		return cfg.DefaultBudgetID
+	if err == nil {
+		if p, err := cfg.Profile(profile); err == nil && p.DefaultBudgetID != "" {
+			return p.DefaultBudgetID
+		}
 	}
 	return os.Getenv("YNAB_DEFAULT_BUDGET_ID")
 }
+
+// ResolveBudgetByName picks the budget a --budget value names from budgets,
+// which maps budget IDs to names. An ID or a case-insensitive exact name wins;
+// otherwise the value must be part of exactly one name. No match or several
+// is an error listing the budget names.
+func ResolveBudgetByName(name string, budgets map[string]string) (string, error) {
+	if _, ok := budgets[name]; ok {
+		return name, nil
+	}
+
+	var exact, partial []string
+	want := strings.ToLower(name)
+	for id, budgetName := range budgets {
+		switch lower := strings.ToLower(budgetName); {
+		case lower == want:
+			exact = append(exact, id)
+		case strings.Contains(lower, want):
+			partial = append(partial, id)
+		}
+	}
+	matches := exact
+	if len(matches) == 0 {
+		matches = partial
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+
+	var names []string
+	if len(matches) == 0 {
+		for _, budgetName := range budgets {
+			names = append(names, budgetName)
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("no budget matches %q; budgets: %s", name, strings.Join(names, ", "))
+	}
+	for _, id := range matches {
+		names = append(names, budgets[id])
+	}
+	sort.Strings(names)
+	return "", fmt.Errorf("budget %q is ambiguous; it matches: %s", name, strings.Join(names, ", "))
+}
+
+// ResolveDefaultSince returns the configured default --since value, or ""
+// if none is set.
+func ResolveDefaultSince() string {
+	cfg, err := Load()
+	if err != nil {
+		return ""
+	}
+	return cfg.DefaultSince
+}
+
+// ResolveDefaultAccount returns the account `add` uses when --account is
+// omitted, or "" if none is configured.
+func ResolveDefaultAccount() string {
+	cfg, err := Load()
+	if err != nil {
+		return ""
+	}
+	return cfg.DefaultAccount
+}
+
+// ResolveApproveOnAdd returns whether `add` should create approved
+// transactions: the approve_on_add config key if set, otherwise true.
+func ResolveApproveOnAdd() bool {
+	cfg, err := Load()
+	if err != nil || cfg.ApproveOnAdd == nil {
+		return true
+	}
+	return *cfg.ApproveOnAdd
+}
+
+// ResolveDuplicateWindowDays returns how many days apart `add` looks for a
+// likely duplicate: the duplicate_window_days config key if set, otherwise
+// DefaultDuplicateWindowDays.
+func ResolveDuplicateWindowDays() int {
+	cfg, err := Load()
+	if err != nil || cfg.DuplicateWindowDays == nil {
+		return DefaultDuplicateWindowDays
+	}
+	return *cfg.DuplicateWindowDays
+}
+
+// ResolveLookupCacheTTL returns how long account and category lookups may
+// be served from disk, or 0 if the cache is off.
+func ResolveLookupCacheTTL() time.Duration {
+	cfg, err := Load()
+	if err != nil {
+		return 0
+	}
+	return cfg.LookupCacheTTL
+}
+
+// ResolveTableStyle returns the configured table style, or "" if none is set.
+func ResolveTableStyle() string {
+	cfg, err := Load()
+	if err != nil {
+		return ""
+	}
+	return cfg.TableStyle
+}
+
+// ResolveAliases returns the configured aliases, or nil if there are none.
+func ResolveAliases() map[string]string {
+	cfg, err := Load()
+	if err != nil {
+		return nil
+	}
+	return cfg.Aliases
+}
+
+// HasOAuthRefresh returns true if the config carries everything needed to
+// refresh an expired OAuth access token.
+func (c *Config) HasOAuthRefresh() bool {
+	return c.RefreshToken != "" && c.OAuthClientID != "" && c.OAuthClientSecret != ""
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProfiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("YNAB_ACCESS_TOKEN", "")
+	t.Setenv("YNAB_DEFAULT_BUDGET_ID", "")
+
+	dir := filepath.Join(home, ConfigDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	content := `access_token=personal-token
+default_budget_id=personal-budget
+default_since=60d
+table_style=box
+
+[profile.business]
+access_token=business-token
+default_budget_id=business-budget
+
+[profile.shared]
+default_budget_id=shared-budget
+
+[unrelated]
+access_token=ignored
+`
+	if err := os.WriteFile(filepath.Join(dir, ConfigFile), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		profile    string
+		wantToken  string
+		wantBudget string
+	}{
+		{"", "personal-token", "personal-budget"},
+		{"business", "business-token", "business-budget"},
+		{"shared", "personal-token", "shared-budget"},
+	}
+	for _, tt := range tests {
+		if got := ResolveToken(tt.profile); got != tt.wantToken {
+			t.Errorf("ResolveToken(%q) = %q, want %q", tt.profile, got, tt.wantToken)
+		}
+		if got := ResolveBudgetID(tt.profile); got != tt.wantBudget {
+			t.Errorf("ResolveBudgetID(%q) = %q, want %q", tt.profile, got, tt.wantBudget)
+		}
+	}
+
+	if err := CheckProfile("missing"); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+
+	// Profiles survive a save/load round trip, and top-level keys after
+	// them are not swallowed by the last section
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DefaultSince != "60d" {
+		t.Errorf("DefaultSince = %q, want 60d", cfg.DefaultSince)
+	}
+	if err := Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ResolveTableStyle(); got != "box" {
+		t.Errorf("ResolveTableStyle() = %q, want box", got)
+	}
+	if reloaded.AccessToken != "personal-token" || reloaded.DefaultSince != "60d" || reloaded.TableStyle != "box" {
+		t.Errorf("top-level keys changed after save: %+v", reloaded)
+	}
+	if len(reloaded.Profiles) != 2 || reloaded.Profiles["business"].AccessToken != "business-token" ||
+		reloaded.Profiles["shared"].DefaultBudgetID != "shared-budget" {
+		t.Errorf("profiles changed after save: %v", reloaded.ProfileNames())
+	}
+}
+
+func TestResolveBudgetByName(t *testing.T) {
+	budgets := map[string]string{
+		"b-home":     "Home",
+		"b-home-old": "Home (2019)",
+		"b-biz":      "Business",
+		"b-vacation": "Vacation Fund",
+	}
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr string
+	}{
+		{name: "b-biz", want: "b-biz"},
+		{name: "home", want: "b-home"},
+		{name: "vacation", want: "b-vacation"},
+		{name: "BUSI", want: "b-biz"},
+		{name: "o", wantErr: `budget "o" is ambiguous; it matches: Home, Home (2019), Vacation Fund`},
+		{name: "groceries", wantErr: `no budget matches "groceries"; budgets: Business, Home, Home (2019), Vacation Fund`},
+	}
+	for _, tt := range tests {
+		got, err := ResolveBudgetByName(tt.name, budgets)
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ResolveBudgetByName(%q) error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveBudgetByName(%q) = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("YNAB_ACCESS_TOKEN", "shell-token")
+	t.Setenv("YNAB_DEFAULT_BUDGET_ID", "shell-budget")
+	t.Cleanup(func() { envFile.path, envFile.values = "", nil })
+
+	dir := filepath.Join(home, ConfigDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	content := `access_token=config-token
+default_budget_id=config-budget
+
+[profile.business]
+access_token=business-token
+`
+	if err := os.WriteFile(filepath.Join(dir, ConfigFile), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), ".env.ynab")
+	env := `# project budget
+export YNAB_ACCESS_TOKEN="file-token"
+
+YNAB_DEFAULT_BUDGET_ID = 'file-budget'
+`
+	if err := os.WriteFile(path, []byte(env), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadEnvFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := os.Getenv("YNAB_ACCESS_TOKEN"); got != "file-token" {
+		t.Errorf("YNAB_ACCESS_TOKEN = %q, want file-token", got)
+	}
+	if got := ResolveToken(""); got != "file-token" {
+		t.Errorf("ResolveToken(\"\") = %q, want file-token", got)
+	}
+	if got := ResolveBudgetID(""); got != "file-budget" {
+		t.Errorf("ResolveBudgetID(\"\") = %q, want file-budget", got)
+	}
+	if got := ResolveToken("business"); got != "business-token" {
+		t.Errorf("ResolveToken(\"business\") = %q, want business-token", got)
+	}
+	if got := ResolveBudgetID("business"); got != "file-budget" {
+		t.Errorf("ResolveBudgetID(\"business\") = %q, want file-budget", got)
+	}
+	if EnvFilePath() != path {
+		t.Errorf("EnvFilePath() = %q, want %q", EnvFilePath(), path)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.env")
+	if err := os.WriteFile(bad, []byte("YNAB_ACCESS_TOKEN\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadEnvFile(bad); err == nil || !strings.Contains(err.Error(), ":1:") {
+		t.Errorf("expected a line 1 error, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// envFile holds the file loaded by LoadEnvFile and the values it set.
+var envFile struct {
+	path   string
+	values map[string]string
+}
+
+// LoadEnvFile reads KEY=VALUE lines from a dotenv file (--env-file) into the
+// environment, replacing variables the shell already set. Blank lines and
+// lines starting with # are skipped, an "export " prefix is allowed, and
+// one pair of matching single or double quotes around a value is removed.
+//
+// YNAB_ACCESS_TOKEN and YNAB_DEFAULT_BUDGET_ID from the file also win over
+// the top-level config keys; a profile's own settings still win over them.
+func LoadEnvFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open env file: %w", err)
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("%s:%d: expected KEY=VALUE", path, n)
+		}
+		values[key] = unquote(strings.TrimSpace(value))
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read env file: %w", err)
+	}
+
+	for key, value := range values {
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("%s: failed to set %s: %w", path, key, err)
+		}
+	}
+	envFile.path, envFile.values = path, values
+	return nil
+}
+
+// EnvFilePath returns the path LoadEnvFile loaded, or "" if none.
+func EnvFilePath() string {
+	return envFile.path
+}
+
+// EnvFileValue returns the value the env file gave key, or "".
+func EnvFileValue(key string) string {
+	return envFile.values[key]
+}
+
+// unquote removes one pair of matching quotes around s.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,167 @@
+// Package ofx reads bank transactions from OFX and QFX statement files.
+//
+// Both OFX 1.x (SGML, where elements such as <TRNAMT>-4.50 have no closing
+// tag) and OFX 2.x (XML) are accepted. Only the <STMTTRN> entries are read;
+// balances, account details and other aggregates are ignored.
+package ofx
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/transform"
+)
+
+// Transaction is one <STMTTRN> entry.
+type Transaction struct {
+	Line   int    // line of the <STMTTRN> tag in the file, counting from 1
+	Type   string // TRNTYPE, e.g. DEBIT, CREDIT, POS
+	Date   string // DTPOSTED as YYYY-MM-DD
+	Amount int64  // TRNAMT in milliunits, negative for outflows
+	FITID  string // the bank's unique ID for the transaction
+	Name   string // NAME, or the NAME inside a PAYEE aggregate
+	Memo   string
+}
+
+// Detect reports whether data looks like an OFX file: an OFX 1.x header,
+// an OFX 2.x processing instruction or an <OFX> root element.
+func Detect(data []byte) bool {
+	head := data[:min(len(data), 1024)]
+	return bytes.Contains(head, []byte("OFXHEADER")) || bytes.Contains(bytes.ToUpper(head), []byte("<OFX>"))
+}
+
+// Parse reads the transactions from an OFX file, in file order. An entry
+// without a date, amount or FITID is an error naming its line.
+func Parse(r io.Reader) ([]Transaction, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OFX: %w", err)
+	}
+	if !Detect(data) {
+		return nil, fmt.Errorf("not an OFX file: no OFX header or <OFX> element")
+	}
+
+	var txns []Transaction
+	var fields map[string]string // fields of the open <STMTTRN>, nil outside one
+	var line int
+
+	finish := func() error {
+		t, err := newTransaction(fields, line)
+		if err != nil {
+			return err
+		}
+		txns = append(txns, t)
+		fields = nil
+		return nil
+	}
+
+	s := string(data)
+	for pos := 0; ; {
+		open := strings.IndexByte(s[pos:], '<')
+		if open < 0 {
+			break
+		}
+		open += pos
+		end := strings.IndexByte(s[open:], '>')
+		if end < 0 {
+			break
+		}
+		end += open
+		tag := strings.ToUpper(strings.TrimSpace(s[open+1 : end]))
+		next := strings.IndexByte(s[end+1:], '<')
+		if next < 0 {
+			next = len(s) - end - 1
+		}
+		text := strings.TrimSpace(s[end+1 : end+1+next])
+		pos = end + 1
+
+		switch {
+		case tag == "STMTTRN":
+			// SGML files are not required to close aggregates either
+			if fields != nil {
+				if err := finish(); err != nil {
+					return nil, err
+				}
+			}
+			fields = make(map[string]string)
+			line = 1 + strings.Count(s[:open], "\n")
+		case tag == "/STMTTRN":
+			if fields != nil {
+				if err := finish(); err != nil {
+					return nil, err
+				}
+			}
+		case fields != nil && text != "" && !strings.HasPrefix(tag, "/"):
+			// Keep the first of a repeated element, such as a NAME inside
+			// PAYEE after the transaction's own NAME
+			if _, seen := fields[tag]; !seen {
+				fields[tag] = html.UnescapeString(text)
+			}
+		}
+	}
+	if fields != nil {
+		if err := finish(); err != nil {
+			return nil, err
+		}
+	}
+	return txns, nil
+}
+
+// newTransaction builds a Transaction from the fields of one <STMTTRN>.
+func newTransaction(fields map[string]string, line int) (Transaction, error) {
+	t := Transaction{
+		Line:  line,
+		Type:  fields["TRNTYPE"],
+		FITID: fields["FITID"],
+		Name:  fields["NAME"],
+		Memo:  fields["MEMO"],
+	}
+	if t.FITID == "" {
+		return t, fmt.Errorf("line %d: transaction has no FITID", line)
+	}
+
+	date, err := parseDate(fields["DTPOSTED"])
+	if err != nil {
+		return t, fmt.Errorf("line %d: %w", line, err)
+	}
+	t.Date = date
+
+	amount, err := parseAmount(fields["TRNAMT"])
+	if err != nil {
+		return t, fmt.Errorf("line %d: %w", line, err)
+	}
+	t.Amount = amount
+	return t, nil
+}
+
+// parseDate reads an OFX datetime such as 20250115, 20250115120000 or
+// 20250115120000.000[-5:EST]. Only the date is kept, as the bank wrote it.
+func parseDate(s string) (string, error) {
+	if len(s) < 8 {
+		return "", fmt.Errorf("invalid DTPOSTED: %q", s)
+	}
+	d, err := time.Parse("20060102", s[:8])
+	if err != nil {
+		return "", fmt.Errorf("invalid DTPOSTED: %q", s)
+	}
+	return transform.FormatDate(d), nil
+}
+
+// parseAmount reads a TRNAMT such as -4.50 or +1200 into milliunits. Some
+// banks write a decimal comma, which is accepted when there is no point.
+func parseAmount(s string) (int64, error) {
+	clean := s
+	if !strings.Contains(clean, ".") {
+		clean = strings.Replace(clean, ",", ".", 1)
+	}
+	f, err := strconv.ParseFloat(clean, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid TRNAMT: %q", s)
+	}
+	return transform.DollarsToMilliunits(f), nil
+}
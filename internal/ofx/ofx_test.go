@@ -0,0 +1,66 @@
+package ofx
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		file string
+		want []Transaction
+	}{
+		{"testdata/checking.ofx", []Transaction{
+			{Line: 35, Type: "POS", Date: "2025-01-15", Amount: -4500, FITID: "202501150001", Name: "COFFEE SHOP #12", Memo: "Card purchase"},
+			{Line: 43, Type: "CREDIT", Date: "2025-01-16", Amount: 1200000, FITID: "202501160001", Name: "EMPLOYER INC & CO"},
+		}},
+		{"testdata/card.qfx", []Transaction{
+			{Line: 15, Type: "DEBIT", Date: "2025-01-10", Amount: -12500, FITID: "FIT-0001", Name: "Hardware Store"},
+			{Line: 25, Type: "CREDIT", Date: "2025-01-12", Amount: 30000, FITID: "FIT-0002", Name: "Refund", Memo: "Returned drill"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			f, err := os.Open(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			got, err := Parse(f)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d transactions, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("transaction %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"not OFX", "Date,Amount,Payee\n2025-01-15,-4.50,Coffee\n", "not an OFX file"},
+		{"missing FITID", "<OFX>\n<STMTTRN>\n<DTPOSTED>20250115\n<TRNAMT>-1.00\n</STMTTRN>\n</OFX>", "line 2: transaction has no FITID"},
+		{"bad date", "<OFX><STMTTRN><DTPOSTED>2025<TRNAMT>-1.00<FITID>1</STMTTRN></OFX>", "invalid DTPOSTED"},
+		{"bad amount", "<OFX><STMTTRN><DTPOSTED>20250115<TRNAMT>ten<FITID>1</STMTTRN></OFX>", "invalid TRNAMT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
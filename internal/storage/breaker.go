@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/config"
+)
+
+// BreakerFile is the file under the config directory holding the circuit
+// breaker's state between invocations.
+const BreakerFile = "breaker.json"
+
+// BreakerPath returns the breaker state file (~/.ynab/breaker.json).
+func BreakerPath() string {
+	dir := config.Dir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, BreakerFile)
+}
+
+// LoadBreaker reads the saved circuit breaker state. A missing or
+// unreadable file is a closed breaker.
+func LoadBreaker() api.BreakerRecord {
+	var r api.BreakerRecord
+	path := BreakerPath()
+	if path == "" {
+		return r
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return r
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return api.BreakerRecord{}
+	}
+	return r
+}
+
+// SaveBreaker writes r to the breaker state file, removing the file when
+// r records a closed breaker.
+func SaveBreaker(r api.BreakerRecord) error {
+	path := BreakerPath()
+	if path == "" {
+		return fmt.Errorf("cannot determine home directory")
+	}
+	if r.IsZero() {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove breaker state: %w", err)
+		}
+		return nil
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode breaker state: %w", err)
+	}
+	return writeFileAtomic(path, data)
+}
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestBreakerRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if r := LoadBreaker(); !r.IsZero() {
+		t.Fatalf("LoadBreaker() with no file = %+v, want zero", r)
+	}
+
+	failed := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	saved := api.BreakerRecord{Failures: []time.Time{failed}, OpenUntil: failed.Add(30 * time.Second)}
+	if err := SaveBreaker(saved); err != nil {
+		t.Fatal(err)
+	}
+	loaded := LoadBreaker()
+	if len(loaded.Failures) != 1 || !loaded.Failures[0].Equal(failed) || !loaded.OpenUntil.Equal(saved.OpenUntil) {
+		t.Errorf("LoadBreaker() = %+v, want %+v", loaded, saved)
+	}
+
+	// Saving a closed breaker removes the file
+	if err := SaveBreaker(api.BreakerRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(BreakerPath()); !os.IsNotExist(err) {
+		t.Errorf("breaker file still exists after saving a closed breaker: %v", err)
+	}
+}
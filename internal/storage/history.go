@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/config"
+)
+
+// HistoryFile is the append-only action log under the config directory, one
+// JSON object per line.
+const HistoryFile = "history.jsonl"
+
+// Kinds of logged action. KindUndo marks an earlier action as reversed.
+const (
+	KindAdd    = "add"
+	KindEdit   = "edit"
+	KindMove   = "move"
+	KindAssign = "assign"
+	KindUndo   = "undo"
+)
+
+// Action is one mutation recorded with what it takes to reverse it.
+type Action struct {
+	ID       string    `json:"id"`
+	BudgetID string    `json:"budget_id"`
+	At       time.Time `json:"at"`
+	Kind     string    `json:"kind"`
+	Summary  string    `json:"summary"`
+
+	// Created is the transaction an add created
+	Created string `json:"created,omitempty"`
+	// Before is the transaction as it was before an edit
+	Before *api.Transaction `json:"before,omitempty"`
+	// Budgeted holds the category amounts from before a move or assign
+	Budgeted []BudgetedBefore `json:"budgeted,omitempty"`
+	// Undoes is the ID of the action an undo reversed
+	Undoes string `json:"undoes,omitempty"`
+}
+
+// BudgetedBefore is a category's budgeted amount for a month before it changed.
+type BudgetedBefore struct {
+	CategoryID string `json:"category_id"`
+	Month      string `json:"month"`
+	Budgeted   int64  `json:"budgeted"`
+}
+
+// HistoryPath returns the action log (~/.ynab/history.jsonl).
+func HistoryPath() string {
+	dir := config.Dir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, HistoryFile)
+}
+
+// AppendHistory adds an action to the end of the log. An action without an
+// ID or time gets them here.
+func AppendHistory(a Action) error {
+	path := HistoryPath()
+	if path == "" {
+		return fmt.Errorf("cannot determine home directory")
+	}
+	if a.At.IsZero() {
+		a.At = time.Now()
+	}
+	if a.ID == "" {
+		a.ID = strconv.FormatInt(a.At.UnixNano(), 36)
+	}
+
+	line, err := json.Marshal(a)
+	if err != nil {
+		return fmt.Errorf("failed to encode history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return f.Close()
+}
+
+// LoadHistory reads the action log, oldest first. A missing log is empty;
+// a line that doesn't parse (say, cut short by a crash) is skipped.
+func LoadHistory() ([]Action, error) {
+	path := HistoryPath()
+	if path == "" {
+		return nil, fmt.Errorf("cannot determine home directory")
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	defer f.Close()
+
+	var actions []Action
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var a Action
+		if err := json.Unmarshal(scanner.Bytes(), &a); err != nil || a.ID == "" {
+			continue
+		}
+		actions = append(actions, a)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return actions, nil
+}
+
+// Reversible returns budgetID's actions that haven't been undone, most
+// recent first.
+func Reversible(actions []Action, budgetID string) []Action {
+	undone := make(map[string]bool)
+	for _, a := range actions {
+		if a.Kind == KindUndo {
+			undone[a.Undoes] = true
+		}
+	}
+
+	var reversible []Action
+	for i := len(actions) - 1; i >= 0; i-- {
+		a := actions[i]
+		if a.BudgetID != budgetID || a.Kind == KindUndo || undone[a.ID] {
+			continue
+		}
+		reversible = append(reversible, a)
+	}
+	return reversible
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	actions, err := LoadHistory()
+	if err != nil || len(actions) != 0 {
+		t.Fatalf("missing history loaded as %v, %v", actions, err)
+	}
+
+	at := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	for i, a := range []Action{
+		{BudgetID: "budget-1", Kind: KindAdd, Created: "txn-1"},
+		{BudgetID: "budget-1", Kind: KindAssign, Budgeted: []BudgetedBefore{{CategoryID: "cat-1", Month: "2025-03-01", Budgeted: 50000}}},
+		{BudgetID: "budget-2", Kind: KindAdd, Created: "txn-2"},
+		{BudgetID: "budget-1", Kind: KindMove},
+	} {
+		a.At = at.Add(time.Duration(i) * time.Minute)
+		if err := AppendHistory(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A line cut short by a crash doesn't hide the rest of the log
+	f, err := os.OpenFile(HistoryPath(), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"id":"broken","kind":"ad` + "\n")
+	f.Close()
+
+	actions, err = LoadHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 4 || actions[0].Created != "txn-1" || actions[1].Budgeted[0].Budgeted != 50000 {
+		t.Fatalf("loaded %+v", actions)
+	}
+
+	reversible := Reversible(actions, "budget-1")
+	if len(reversible) != 3 || reversible[0].Kind != KindMove || reversible[2].Kind != KindAdd {
+		t.Fatalf("reversible = %+v", reversible)
+	}
+
+	// Undoing the move leaves the assign on top
+	if err := AppendHistory(Action{BudgetID: "budget-1", Kind: KindUndo, Undoes: reversible[0].ID}); err != nil {
+		t.Fatal(err)
+	}
+	actions, _ = LoadHistory()
+	reversible = Reversible(actions, "budget-1")
+	if len(reversible) != 2 || reversible[0].Kind != KindAssign {
+		t.Errorf("after undo, reversible = %+v", reversible)
+	}
+}
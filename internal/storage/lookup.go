@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/config"
+)
+
+// Lookup caches the account and category names commands resolve on every
+// run. Only what name matching needs is kept; balances always come from the
+// API. Accounts and categories are fetched, and so expire, separately.
+type Lookup struct {
+	BudgetID       string          `json:"budget_id"`
+	AccountsAt     time.Time       `json:"accounts_at"`
+	Accounts       []LookupAccount `json:"accounts"`
+	CategoriesAt   time.Time       `json:"categories_at"`
+	CategoryGroups []LookupGroup   `json:"category_groups"`
+}
+
+// LookupAccount is the cached part of an account.
+type LookupAccount struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	OnBudget bool   `json:"on_budget"`
+	Closed   bool   `json:"closed"`
+	Deleted  bool   `json:"deleted"`
+}
+
+// LookupGroup is the cached part of a category group.
+type LookupGroup struct {
+	ID         string           `json:"id"`
+	Name       string           `json:"name"`
+	Hidden     bool             `json:"hidden"`
+	Deleted    bool             `json:"deleted"`
+	Categories []LookupCategory `json:"categories"`
+}
+
+// LookupCategory is the cached part of a category.
+type LookupCategory struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Hidden  bool   `json:"hidden"`
+	Deleted bool   `json:"deleted"`
+}
+
+// LookupPath returns the lookup cache file for budgetID.
+func LookupPath(budgetID string) string {
+	dir := config.Dir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, CacheDir, budgetID+".lookup.json")
+}
+
+// LoadLookup reads the lookup cache for budgetID. A missing or unreadable
+// cache returns an empty one, which is simply refetched.
+func LoadLookup(budgetID string) *Lookup {
+	empty := &Lookup{BudgetID: budgetID}
+	path := LookupPath(budgetID)
+	if path == "" {
+		return empty
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return empty
+	}
+	var l Lookup
+	if err := json.Unmarshal(data, &l); err != nil || l.BudgetID != budgetID {
+		return empty
+	}
+	return &l
+}
+
+// SaveLookup writes l to its cache file.
+func SaveLookup(l *Lookup) error {
+	path := LookupPath(l.BudgetID)
+	if path == "" {
+		return fmt.Errorf("cannot determine home directory")
+	}
+	data, err := json.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("failed to encode lookup cache: %w", err)
+	}
+	return writeFileAtomic(path, data)
+}
+
+// InvalidateLookup removes the lookup cache for budgetID, if any.
+func InvalidateLookup(budgetID string) error {
+	path := LookupPath(budgetID)
+	if path == "" {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove lookup cache: %w", err)
+	}
+	return nil
+}
+
+// AccountsFresh reports whether the cached accounts are younger than ttl.
+func (l *Lookup) AccountsFresh(ttl time.Duration, now time.Time) bool {
+	return fresh(l.AccountsAt, ttl, now)
+}
+
+// CategoriesFresh reports whether the cached categories are younger than ttl.
+func (l *Lookup) CategoriesFresh(ttl time.Duration, now time.Time) bool {
+	return fresh(l.CategoriesAt, ttl, now)
+}
+
+func fresh(at time.Time, ttl time.Duration, now time.Time) bool {
+	return !at.IsZero() && ttl > 0 && now.Sub(at) < ttl
+}
+
+// SetAccounts replaces the cached accounts, fetched at now.
+func (l *Lookup) SetAccounts(accounts []*api.Account, now time.Time) {
+	l.AccountsAt = now
+	l.Accounts = make([]LookupAccount, 0, len(accounts))
+	for _, a := range accounts {
+		l.Accounts = append(l.Accounts, LookupAccount{
+			ID: a.ID, Name: a.Name, OnBudget: a.OnBudget, Closed: a.Closed, Deleted: a.Deleted,
+		})
+	}
+}
+
+// SetCategories replaces the cached category groups, fetched at now.
+func (l *Lookup) SetCategories(groups []*api.CategoryGroup, now time.Time) {
+	l.CategoriesAt = now
+	l.CategoryGroups = make([]LookupGroup, 0, len(groups))
+	for _, g := range groups {
+		group := LookupGroup{ID: g.ID, Name: g.Name, Hidden: g.Hidden, Deleted: g.Deleted}
+		for _, c := range g.Categories {
+			group.Categories = append(group.Categories, LookupCategory{
+				ID: c.ID, Name: c.Name, Hidden: c.Hidden, Deleted: c.Deleted,
+			})
+		}
+		l.CategoryGroups = append(l.CategoryGroups, group)
+	}
+}
+
+// APIAccounts returns the cached accounts with only the cached fields set.
+func (l *Lookup) APIAccounts() []*api.Account {
+	accounts := make([]*api.Account, 0, len(l.Accounts))
+	for _, a := range l.Accounts {
+		accounts = append(accounts, &api.Account{
+			ID: a.ID, Name: a.Name, OnBudget: a.OnBudget, Closed: a.Closed, Deleted: a.Deleted,
+		})
+	}
+	return accounts
+}
+
+// APICategories returns the cached category groups with only the cached
+// fields set.
+func (l *Lookup) APICategories() []*api.CategoryGroup {
+	groups := make([]*api.CategoryGroup, 0, len(l.CategoryGroups))
+	for _, g := range l.CategoryGroups {
+		group := &api.CategoryGroup{ID: g.ID, Name: g.Name, Hidden: g.Hidden, Deleted: g.Deleted}
+		for _, c := range g.Categories {
+			group.Categories = append(group.Categories, &api.Category{
+				ID: c.ID, CategoryGroupID: g.ID, Name: c.Name, Hidden: c.Hidden, Deleted: c.Deleted,
+			})
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestLookupTTL(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	fetched := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	l := LoadLookup("budget-1")
+	if l.AccountsFresh(time.Hour, fetched) || l.CategoriesFresh(time.Hour, fetched) {
+		t.Fatal("empty cache reported fresh")
+	}
+
+	l.SetAccounts([]*api.Account{{ID: "acc-1", Name: "Checking", OnBudget: true, Balance: 5000}}, fetched)
+	l.SetCategories([]*api.CategoryGroup{{
+		ID: "grp-1", Name: "Everyday",
+		Categories: []*api.Category{{ID: "cat-1", Name: "Groceries", Balance: 1000}},
+	}}, fetched.Add(-time.Hour))
+	if err := SaveLookup(l); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := LoadLookup("budget-1")
+	ttl := 10 * time.Minute
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"just fetched", fetched, true},
+		{"within ttl", fetched.Add(9 * time.Minute), true},
+		{"at ttl", fetched.Add(ttl), false},
+		{"past ttl", fetched.Add(time.Hour), false},
+	}
+	for _, tt := range tests {
+		if got := loaded.AccountsFresh(ttl, tt.now); got != tt.want {
+			t.Errorf("%s: AccountsFresh = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	// Categories were fetched an hour earlier and expire on their own
+	if loaded.CategoriesFresh(ttl, fetched) {
+		t.Error("categories older than the ttl reported fresh")
+	}
+	if loaded.AccountsFresh(0, fetched) {
+		t.Error("a zero ttl must disable the cache")
+	}
+
+	accounts := loaded.APIAccounts()
+	if len(accounts) != 1 || accounts[0].Name != "Checking" || !accounts[0].OnBudget || accounts[0].Balance != 0 {
+		t.Errorf("accounts loaded as %+v", accounts)
+	}
+	groups := loaded.APICategories()
+	if len(groups) != 1 || len(groups[0].Categories) != 1 || groups[0].Categories[0].CategoryGroupID != "grp-1" {
+		t.Errorf("categories loaded as %+v", groups)
+	}
+
+	// Another budget's cache is separate, and invalidation removes it
+	if other := LoadLookup("budget-2"); len(other.Accounts) != 0 {
+		t.Errorf("budget-2 loaded budget-1's accounts: %+v", other.Accounts)
+	}
+	if err := InvalidateLookup("budget-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := InvalidateLookup("budget-1"); err != nil {
+		t.Errorf("invalidating a missing cache: %v", err)
+	}
+	if after := LoadLookup("budget-1"); after.AccountsFresh(ttl, fetched) {
+		t.Error("invalidated cache still fresh")
+	}
+}
@@ -0,0 +1,174 @@
+// Package storage keeps a local copy of budget data for delta syncs.
+// Each budget is cached in its own JSON file under ~/.ynab/cache, together
+// with the server knowledge it was synced at. A smaller lookup cache of
+// account and category names sits beside it (see Lookup).
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/config"
+)
+
+// CacheDir is the directory under the config directory holding the cache.
+const CacheDir = "cache"
+
+// Snapshot is the cached state of one budget. Entities are keyed by ID;
+// deleted entities are dropped rather than kept as tombstones.
+type Snapshot struct {
+	BudgetID        string                      `json:"budget_id"`
+	ServerKnowledge int64                       `json:"server_knowledge"`
+	SyncedAt        time.Time                   `json:"synced_at"`
+	Accounts        map[string]*api.Account     `json:"accounts"`
+	Categories      map[string]*api.Category    `json:"categories"`
+	Transactions    map[string]*api.Transaction `json:"transactions"`
+}
+
+// Changes counts what a sync did to one entity type.
+type Changes struct {
+	Added   int `json:"added"`
+	Updated int `json:"updated"`
+	Deleted int `json:"deleted"`
+}
+
+// SyncResult reports the changes applied by Snapshot.Apply.
+type SyncResult struct {
+	Accounts     Changes `json:"accounts"`
+	Categories   Changes `json:"categories"`
+	Transactions Changes `json:"transactions"`
+}
+
+// NewSnapshot returns an empty snapshot for budgetID, which syncs from
+// scratch.
+func NewSnapshot(budgetID string) *Snapshot {
+	return &Snapshot{
+		BudgetID:     budgetID,
+		Accounts:     make(map[string]*api.Account),
+		Categories:   make(map[string]*api.Category),
+		Transactions: make(map[string]*api.Transaction),
+	}
+}
+
+// Path returns the cache file for budgetID.
+func Path(budgetID string) string {
+	dir := config.Dir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, CacheDir, budgetID+".json")
+}
+
+// Load reads the cached snapshot for budgetID. A missing cache returns an
+// empty snapshot.
+func Load(budgetID string) (*Snapshot, error) {
+	path := Path(budgetID)
+	if path == "" {
+		return nil, fmt.Errorf("cannot determine home directory")
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewSnapshot(budgetID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	snap := NewSnapshot(budgetID)
+	if err := json.Unmarshal(data, snap); err != nil {
+		return nil, fmt.Errorf("failed to parse cache %s: %w", path, err)
+	}
+	// A cache written for another budget can't be extended with this one's deltas
+	if snap.BudgetID != budgetID {
+		return NewSnapshot(budgetID), nil
+	}
+	return snap, nil
+}
+
+// Save writes snap to its cache file. The file is replaced atomically so an
+// interrupted save never leaves a half-written cache.
+func Save(snap *Snapshot) error {
+	path := Path(snap.BudgetID)
+	if path == "" {
+		return fmt.Errorf("cannot determine home directory")
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic replaces path with data atomically, so an interrupted
+// write never leaves a half-written file.
+func writeFileAtomic(path string, data []byte) error {
+	// Cache and trash hold the same data the token grants access to
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".sync-*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Apply merges a budget response into the snapshot and advances its server
+// knowledge. With a delta response only changed entities are present;
+// entities marked deleted are removed from the snapshot.
+func (s *Snapshot) Apply(detail *api.BudgetDetail) SyncResult {
+	result := SyncResult{
+		Accounts: merge(s.Accounts, detail.Accounts,
+			func(a *api.Account) (string, bool) { return a.ID, a.Deleted }),
+		Categories: merge(s.Categories, detail.Categories,
+			func(c *api.Category) (string, bool) { return c.ID, c.Deleted }),
+		Transactions: merge(s.Transactions, detail.Transactions,
+			func(t *api.Transaction) (string, bool) { return t.ID, t.Deleted }),
+	}
+	s.ServerKnowledge = detail.ServerKnowledge
+	return result
+}
+
+// merge applies items to cached, using key to get each item's ID and
+// deleted flag.
+func merge[T any](cached map[string]*T, items []*T, key func(*T) (string, bool)) Changes {
+	var c Changes
+	for _, item := range items {
+		id, deleted := key(item)
+		_, exists := cached[id]
+		switch {
+		case deleted:
+			if exists {
+				delete(cached, id)
+				c.Deleted++
+			}
+		case exists:
+			cached[id] = item
+			c.Updated++
+		default:
+			cached[id] = item
+			c.Added++
+		}
+	}
+	return c
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestSnapshotApply(t *testing.T) {
+	snap := NewSnapshot("budget-1")
+
+	first := snap.Apply(&api.BudgetDetail{
+		ServerKnowledge: 10,
+		Accounts:        []*api.Account{{ID: "acc-1", Name: "Checking"}},
+		Categories:      []*api.Category{{ID: "cat-1", Name: "Groceries"}, {ID: "cat-2", Name: "Rent"}},
+		Transactions:    []*api.Transaction{{ID: "txn-1", Amount: -5000}, {ID: "old", Deleted: true}},
+	})
+	if first.Categories.Added != 2 || first.Transactions.Added != 1 || first.Transactions.Deleted != 0 {
+		t.Errorf("full sync changes = %+v", first)
+	}
+
+	delta := snap.Apply(&api.BudgetDetail{
+		ServerKnowledge: 12,
+		Categories:      []*api.Category{{ID: "cat-2", Deleted: true}},
+		Transactions:    []*api.Transaction{{ID: "txn-1", Amount: -6000}, {ID: "txn-2", Amount: 1000}},
+	})
+	want := SyncResult{
+		Categories:   Changes{Deleted: 1},
+		Transactions: Changes{Added: 1, Updated: 1},
+	}
+	if delta != want {
+		t.Errorf("delta changes = %+v, want %+v", delta, want)
+	}
+
+	if snap.ServerKnowledge != 12 {
+		t.Errorf("ServerKnowledge = %d, want 12", snap.ServerKnowledge)
+	}
+	if len(snap.Accounts) != 1 || len(snap.Categories) != 1 || len(snap.Transactions) != 2 {
+		t.Errorf("cached %d accounts, %d categories, %d transactions",
+			len(snap.Accounts), len(snap.Categories), len(snap.Transactions))
+	}
+	if snap.Transactions["txn-1"].Amount != -6000 {
+		t.Errorf("txn-1 not updated: %+v", snap.Transactions["txn-1"])
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Budgets are cached separately
+	a := NewSnapshot("budget-a")
+	a.ServerKnowledge = 7
+	a.Accounts["acc-1"] = &api.Account{ID: "acc-1", Name: "Checking"}
+	if err := Save(a); err != nil {
+		t.Fatal(err)
+	}
+	b := NewSnapshot("budget-b")
+	b.ServerKnowledge = 99
+	if err := Save(b); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load("budget-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ServerKnowledge != 7 || loaded.Accounts["acc-1"] == nil {
+		t.Errorf("budget-a loaded as %+v", loaded)
+	}
+
+	missing, err := Load("budget-c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing.ServerKnowledge != 0 || missing.Transactions == nil {
+		t.Errorf("missing cache loaded as %+v", missing)
+	}
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+	"github.com/joeyhipolito/ynab-cli/internal/config"
+)
+
+// TrashFile is the file under the config directory holding recently
+// deleted transactions.
+const TrashFile = "trash"
+
+// MaxTrash is how many deleted transactions the trash keeps; older ones
+// are dropped as new ones arrive.
+const MaxTrash = 50
+
+// TrashEntry is a transaction as it was just before `delete` removed it.
+type TrashEntry struct {
+	BudgetID    string           `json:"budget_id"`
+	DeletedAt   time.Time        `json:"deleted_at"`
+	Transaction *api.Transaction `json:"transaction"`
+}
+
+// TrashPath returns the trash file (~/.ynab/trash).
+func TrashPath() string {
+	dir := config.Dir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, TrashFile)
+}
+
+// LoadTrash reads the trash, newest entry first. A missing trash is empty.
+func LoadTrash() ([]TrashEntry, error) {
+	path := TrashPath()
+	if path == "" {
+		return nil, fmt.Errorf("cannot determine home directory")
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trash: %w", err)
+	}
+
+	var entries []TrashEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse trash %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// SaveTrash writes entries to the trash, keeping at most MaxTrash.
+func SaveTrash(entries []TrashEntry) error {
+	path := TrashPath()
+	if path == "" {
+		return fmt.Errorf("cannot determine home directory")
+	}
+	if len(entries) > MaxTrash {
+		entries = entries[:MaxTrash]
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode trash: %w", err)
+	}
+	return writeFileAtomic(path, data)
+}
+
+// AddToTrash records a deleted transaction at the front of the trash.
+func AddToTrash(entry TrashEntry) error {
+	entries, err := LoadTrash()
+	if err != nil {
+		return err
+	}
+	return SaveTrash(append([]TrashEntry{entry}, entries...))
+}
+
+// FindTrash returns the index of transactionID in budgetID's entries, or -1.
+func FindTrash(entries []TrashEntry, budgetID, transactionID string) int {
+	for i, e := range entries {
+		if e.BudgetID == budgetID && e.Transaction != nil && e.Transaction.ID == transactionID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/joeyhipolito/ynab-cli/internal/api"
+)
+
+func TestTrash(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	entries, err := LoadTrash()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("missing trash loaded as %v, %v", entries, err)
+	}
+
+	deletedAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	for i := range MaxTrash + 2 {
+		if err := AddToTrash(TrashEntry{
+			BudgetID:    "budget-1",
+			DeletedAt:   deletedAt.Add(time.Duration(i) * time.Minute),
+			Transaction: &api.Transaction{ID: fmt.Sprintf("txn-%d", i), Amount: -1000},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := AddToTrash(TrashEntry{BudgetID: "budget-2", Transaction: &api.Transaction{ID: "txn-0"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err = LoadTrash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != MaxTrash {
+		t.Errorf("trash holds %d entries, want %d", len(entries), MaxTrash)
+	}
+	if entries[0].BudgetID != "budget-2" || entries[1].Transaction.ID != fmt.Sprintf("txn-%d", MaxTrash+1) {
+		t.Errorf("newest entries = %+v, %+v", entries[0], entries[1])
+	}
+
+	// The oldest deletions fell off; lookups are per budget
+	if i := FindTrash(entries, "budget-1", "txn-0"); i != -1 {
+		t.Errorf("txn-0 in budget-1 found at %d, want dropped", i)
+	}
+	if i := FindTrash(entries, "budget-2", "txn-0"); i != 0 {
+		t.Errorf("txn-0 in budget-2 found at %d, want 0", i)
+	}
+	if i := FindTrash(entries, "budget-1", "txn-5"); i < 0 || entries[i].Transaction.Amount != -1000 {
+		t.Errorf("txn-5 in budget-1 found at %d", i)
+	}
+}
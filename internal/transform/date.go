@@ -0,0 +1,53 @@
+package transform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseRelativeDate resolves a date as typed on the command line to YNAB
+// format (YYYY-MM-DD), counting back from now. It accepts:
+//
+//	YYYY-MM-DD  passed through unchanged
+//	today       now's date
+//	yesterday   the day before
+//	Nd          N days ago
+//	Nw          N weeks ago
+//	Nm          N calendar months ago, clamped to the end of a shorter month
+//
+// Examples, with now on March 31, 2025:
+//
+//	ParseRelativeDate("2025-01-15", now)  // "2025-01-15"
+//	ParseRelativeDate("7d", now)          // "2025-03-24"
+//	ParseRelativeDate("2w", now)          // "2025-03-17"
+//	ParseRelativeDate("1m", now)          // "2025-02-28"
+func ParseRelativeDate(s string, now time.Time) (string, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch strings.ToLower(s) {
+	case "today":
+		return FormatDate(today), nil
+	case "yesterday":
+		return FormatDate(today.AddDate(0, 0, -1)), nil
+	}
+
+	if len(s) >= 2 {
+		if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && n >= 0 && s[0] != '+' {
+			switch s[len(s)-1] {
+			case 'd':
+				return FormatDate(today.AddDate(0, 0, -n)), nil
+			case 'w':
+				return FormatDate(today.AddDate(0, 0, -7*n)), nil
+			case 'm':
+				return FormatDate(addMonthsClamped(today, -n)), nil
+			}
+		}
+	}
+
+	if ParseDate(s).IsZero() {
+		return "", fmt.Errorf("invalid date: %s (expected YYYY-MM-DD, today, yesterday, or a time ago like 7d, 2w or 1m)", s)
+	}
+	return s, nil
+}
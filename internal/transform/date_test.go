@@ -0,0 +1,60 @@
+package transform
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseRelativeDate tests relative and absolute date arguments.
+func TestParseRelativeDate(t *testing.T) {
+	endOfMarch := time.Date(2025, 3, 31, 18, 30, 0, 0, time.UTC)
+	leapYear := time.Date(2024, 3, 31, 9, 0, 0, 0, time.UTC)
+	newYear := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		now   time.Time
+		want  string
+	}{
+		{"2025-01-15", endOfMarch, "2025-01-15"},
+		{"2030-12-31", endOfMarch, "2030-12-31"},
+		{"today", endOfMarch, "2025-03-31"},
+		{"Today", endOfMarch, "2025-03-31"},
+		{"yesterday", newYear, "2025-01-02"},
+		{"0d", endOfMarch, "2025-03-31"},
+		{"7d", endOfMarch, "2025-03-24"},
+		{"31d", endOfMarch, "2025-02-28"},
+		{"5d", newYear, "2024-12-29"},
+		{"1w", endOfMarch, "2025-03-24"},
+		{"2w", endOfMarch, "2025-03-17"},
+		{"1w", newYear, "2024-12-27"},
+		{"1m", endOfMarch, "2025-02-28"},
+		{"1m", leapYear, "2024-02-29"},
+		{"2m", endOfMarch, "2025-01-31"},
+		{"3m", endOfMarch, "2024-12-31"},
+		{"1m", newYear, "2024-12-03"},
+		{"12m", endOfMarch, "2024-03-31"},
+		{"13m", leapYear, "2023-02-28"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRelativeDate(tt.input, tt.now)
+		if err != nil {
+			t.Errorf("ParseRelativeDate(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRelativeDate(%q, %s) = %s, want %s", tt.input, FormatDate(tt.now), got, tt.want)
+		}
+	}
+}
+
+// TestParseRelativeDate_Invalid tests that malformed dates are rejected.
+func TestParseRelativeDate_Invalid(t *testing.T) {
+	now := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	for _, input := range []string{"", "d", "-5d", "+5d", "1.5w", "3y", "7 days", "2025-13-01", "2025-02-30", "03/31/2025", "tomorrow"} {
+		if got, err := ParseRelativeDate(input, now); err == nil {
+			t.Errorf("ParseRelativeDate(%q) = %s, want error", input, got)
+		}
+	}
+}
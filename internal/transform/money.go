@@ -33,6 +33,68 @@ func DollarsToMilliunits(dollars float64) int64 {
 	return int64(milliunits)
 }
 
+// ParseDollarsToMilliunits parses a decimal dollar amount typed by the user
+// into milliunits using integer math, so there is no float rounding. A
+// leading + or - is allowed; more than three decimal places, exponents and
+// anything else ParseFloat would accept but isn't a plain decimal are errors.
+//
+// Examples:
+//
+//	ParseDollarsToMilliunits("100")     // 100000, nil
+//	ParseDollarsToMilliunits("0.3")     // 300, nil
+//	ParseDollarsToMilliunits("-1.505")  // -1505, nil
+//	ParseDollarsToMilliunits("+.5")     // 500, nil
+//	ParseDollarsToMilliunits("1.0005")  // 0, error (more than 3 decimal places)
+func ParseDollarsToMilliunits(s string) (int64, error) {
+	str := strings.TrimSpace(s)
+	negative := false
+	if str != "" && (str[0] == '+' || str[0] == '-') {
+		negative = str[0] == '-'
+		str = str[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	if intPart == "" && fracPart == "" {
+		return 0, fmt.Errorf("invalid amount: %s", s)
+	}
+	if len(fracPart) > 3 {
+		return 0, fmt.Errorf("invalid amount: %s (at most 3 decimal places)", s)
+	}
+	if !allDigits(intPart) || !allDigits(fracPart) {
+		return 0, fmt.Errorf("invalid amount: %s", s)
+	}
+
+	var dollars int64
+	if intPart != "" {
+		var err error
+		dollars, err = strconv.ParseInt(intPart, 10, 64)
+		if err != nil || dollars > (math.MaxInt64-999)/1000 {
+			return 0, fmt.Errorf("amount out of range: %s", s)
+		}
+	}
+	var fraction int64
+	if fracPart != "" {
+		// Pad to milliunits: "5" is 500, "05" is 50
+		fraction, _ = strconv.ParseInt(fracPart+strings.Repeat("0", 3-len(fracPart)), 10, 64)
+	}
+
+	milliunits := dollars*1000 + fraction
+	if negative {
+		milliunits = -milliunits
+	}
+	return milliunits, nil
+}
+
+// allDigits reports whether s contains only ASCII digits.
+func allDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // MilliunitsToDollars converts YNAB milliunits to a dollar amount.
 //
 // Examples:
@@ -75,6 +137,62 @@ func FormatCurrency(milliunits int64) string {
 	return "$" + formatted
 }
 
+// CurrencyFormat is how a budget writes amounts. It mirrors the fields of
+// the API's currency format that formatting needs.
+type CurrencyFormat struct {
+	Symbol           string
+	SymbolFirst      bool
+	DisplaySymbol    bool
+	DecimalDigits    int
+	DecimalSeparator string
+	GroupSeparator   string
+}
+
+// FormatCurrencyWithFormat formats milliunits using a budget's currency
+// format: its symbol and where it goes, its separators and its number of
+// decimal digits. A symbol placed after the amount is separated by a space.
+// With a nil format it behaves like FormatCurrency.
+//
+// Examples:
+//
+//	FormatCurrencyWithFormat(1234560, eur)  // "1.234,56 €"
+//	FormatCurrencyWithFormat(-1234560, eur) // "-1.234,56 €"
+//	FormatCurrencyWithFormat(1234000, jpy)  // "¥1,234"
+//	FormatCurrencyWithFormat(1234560, nil)  // "$1,234.56"
+func FormatCurrencyWithFormat(milliunits int64, cf *CurrencyFormat) string {
+	if cf == nil {
+		return FormatCurrency(milliunits)
+	}
+
+	// YNAB stores at most three decimals, in milliunits
+	digits := min(max(cf.DecimalDigits, 0), 3)
+	formatted := strconv.FormatFloat(math.Abs(MilliunitsToDollars(milliunits)), 'f', digits, 64)
+
+	intPart, decPart, _ := strings.Cut(formatted, ".")
+	number := groupDigits(intPart, cf.GroupSeparator)
+	if decPart != "" {
+		decimalSeparator := cf.DecimalSeparator
+		if decimalSeparator == "" {
+			decimalSeparator = "."
+		}
+		number += decimalSeparator + decPart
+	}
+
+	sign := ""
+	if milliunits < 0 {
+		sign = "-"
+	}
+
+	switch {
+	case !cf.DisplaySymbol || cf.Symbol == "":
+		return sign + number
+	case cf.SymbolFirst:
+		return sign + cf.Symbol + number
+	default:
+		return sign + number + " " + cf.Symbol
+	}
+}
+
 // formatWithThousands formats a float with the specified decimal places
 // and adds comma separators for thousands.
 func formatWithThousands(value float64, decimals int) string {
@@ -101,16 +219,22 @@ func formatWithThousands(value float64, decimals int) string {
 
 // addThousandsSeparators adds comma separators to a number string.
 func addThousandsSeparators(s string) string {
-	// Start from the right and insert commas every 3 digits
+	return groupDigits(s, ",")
+}
+
+// groupDigits inserts sep between groups of three digits in a string of
+// digits. An empty sep leaves the digits ungrouped.
+func groupDigits(s, sep string) string {
+	// Start from the right and insert a separator every 3 digits
 	n := len(s)
-	if n <= 3 {
+	if n <= 3 || sep == "" {
 		return s
 	}
 
 	var result strings.Builder
 	for i, digit := range s {
 		if i > 0 && (n-i)%3 == 0 {
-			result.WriteRune(',')
+			result.WriteString(sep)
 		}
 		result.WriteRune(digit)
 	}
@@ -163,6 +287,38 @@ func FormatMonth(year, month int) string {
 	return fmt.Sprintf("%04d-%02d", year, month)
 }
 
+// MonthsBetween returns every month from start to end inclusive as YNAB
+// month dates (YYYY-MM-01). Both bounds accept the same formats as ParseMonth.
+//
+// Examples:
+//
+//	MonthsBetween("2024-11", "2025-02")  // ["2024-11-01", "2024-12-01", "2025-01-01", "2025-02-01"], nil
+//	MonthsBetween("2024-06", "2024-06")  // ["2024-06-01"], nil
+//	MonthsBetween("2024-06", "2024-01")  // nil, error (reversed range)
+func MonthsBetween(start, end string) ([]string, error) {
+	startYear, startMonth, err := ParseMonth(start)
+	if err != nil {
+		return nil, err
+	}
+	endYear, endMonth, err := ParseMonth(end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Count months on a single axis so year boundaries need no special casing
+	from := startYear*12 + (startMonth - 1)
+	to := endYear*12 + (endMonth - 1)
+	if from > to {
+		return nil, fmt.Errorf("invalid month range: %s is after %s", FormatMonth(startYear, startMonth), FormatMonth(endYear, endMonth))
+	}
+
+	months := make([]string, 0, to-from+1)
+	for m := from; m <= to; m++ {
+		months = append(months, FormatMonth(m/12, m%12+1)+"-01")
+	}
+	return months, nil
+}
+
 // ParseDate parses a date string in YNAB format (YYYY-MM-DD)
 // and returns a time.Time value.
 //
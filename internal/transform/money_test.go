@@ -2,6 +2,7 @@ package transform
 
 import (
 	"math"
+	"strings"
 	"testing"
 	"time"
 )
@@ -58,6 +59,52 @@ func TestDollarsToMilliunits(t *testing.T) {
 	}
 }
 
+func TestParseDollarsToMilliunits(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+		wantErr  bool
+	}{
+		{"0", 0, false},
+		{"100", 100000, false},
+		{"0.01", 10, false},
+		{"0.3", 300, false},
+		{"1.505", 1505, false},
+		{"-50.00", -50000, false},
+		{"+47.32", 47320, false},
+		{".5", 500, false},
+		{"5.", 5000, false},
+		{" 4.75 ", 4750, false},
+		{"9223372036854774.999", 9223372036854774999, false},
+
+		{"", 0, true},
+		{"-", 0, true},
+		{".", 0, true},
+		{"1.0005", 0, true},
+		{"1e3", 0, true},
+		{"1,000", 0, true},
+		{"--5", 0, true},
+		{"NaN", 0, true},
+		{"ten", 0, true},
+		{"99999999999999999", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ParseDollarsToMilliunits(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseDollarsToMilliunits(%q) = %d, want an error", tt.input, result)
+				}
+				return
+			}
+			if err != nil || result != tt.expected {
+				t.Errorf("ParseDollarsToMilliunits(%q) = %d, %v, want %d", tt.input, result, err, tt.expected)
+			}
+		})
+	}
+}
+
 func TestMilliunitsToDollars(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -164,6 +211,44 @@ func TestFormatCurrency(t *testing.T) {
 	}
 }
 
+// TestFormatCurrencyWithFormat tests formatting with a budget's currency format.
+func TestFormatCurrencyWithFormat(t *testing.T) {
+	usd := &CurrencyFormat{DecimalDigits: 2, DecimalSeparator: ".", GroupSeparator: ",",
+		SymbolFirst: true, Symbol: "$", DisplaySymbol: true}
+	eur := &CurrencyFormat{DecimalDigits: 2, DecimalSeparator: ",", GroupSeparator: ".",
+		SymbolFirst: false, Symbol: "€", DisplaySymbol: true}
+	jpy := &CurrencyFormat{DecimalDigits: 0, DecimalSeparator: ".", GroupSeparator: ",",
+		SymbolFirst: true, Symbol: "¥", DisplaySymbol: true}
+	chf := &CurrencyFormat{DecimalDigits: 2, DecimalSeparator: ".", GroupSeparator: "'",
+		SymbolFirst: true, Symbol: "CHF", DisplaySymbol: false}
+
+	tests := []struct {
+		name       string
+		milliunits int64
+		format     *CurrencyFormat
+		expected   string
+	}{
+		{"usd matches FormatCurrency", 1234560, usd, "$1,234.56"},
+		{"usd negative", -50000, usd, "-$50.00"},
+		{"eur symbol after", 1234560, eur, "1.234,56 €"},
+		{"eur negative", -1234560, eur, "-1.234,56 €"},
+		{"eur millions", 1234567890, eur, "1.234.567,89 €"},
+		{"jpy no decimals", 1234000, jpy, "¥1,234"},
+		{"jpy small", 500000, jpy, "¥500"},
+		{"symbol hidden", 1234560, chf, "1'234.56"},
+		{"nil format falls back", 1234560, nil, "$1,234.56"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatCurrencyWithFormat(tt.milliunits, tt.format)
+			if result != tt.expected {
+				t.Errorf("FormatCurrencyWithFormat(%d, %s) = %q, want %q", tt.milliunits, tt.name, result, tt.expected)
+			}
+		})
+	}
+}
+
 // TestRoundTrip verifies that converting dollars to milliunits and back
 // preserves the value (within floating point precision).
 func TestRoundTrip(t *testing.T) {
@@ -368,6 +453,44 @@ func TestFormatMonth(t *testing.T) {
 	}
 }
 
+// TestMonthsBetween tests inclusive month range expansion.
+func TestMonthsBetween(t *testing.T) {
+	tests := []struct {
+		name        string
+		start       string
+		end         string
+		expected    []string
+		expectError bool
+	}{
+		{"same month", "2024-06", "2024-06", []string{"2024-06-01"}, false},
+		{"within a year", "2024-01", "2024-03", []string{"2024-01-01", "2024-02-01", "2024-03-01"}, false},
+		{"cross year", "2024-11", "2025-02", []string{"2024-11-01", "2024-12-01", "2025-01-01", "2025-02-01"}, false},
+		{"full dates", "2024-12-01", "2025-01-15", []string{"2024-12-01", "2025-01-01"}, false},
+		{"reversed range", "2024-06", "2024-01", nil, true},
+		{"reversed across years", "2025-01", "2024-12", nil, true},
+		{"invalid start", "invalid", "2024-01", nil, true},
+		{"invalid end", "2024-01", "2024-13", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MonthsBetween(tt.start, tt.end)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("MonthsBetween(%s, %s) expected error, got nil", tt.start, tt.end)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MonthsBetween(%s, %s) unexpected error: %v", tt.start, tt.end, err)
+			}
+			if strings.Join(result, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("MonthsBetween(%s, %s) = %v, want %v", tt.start, tt.end, result, tt.expected)
+			}
+		})
+	}
+}
+
 // TestParseDate tests parsing of YNAB date strings.
 func TestParseDate(t *testing.T) {
 	tests := []struct {
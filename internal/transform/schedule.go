@@ -0,0 +1,122 @@
+package transform
+
+import "time"
+
+// ProjectOccurrences expands a YNAB scheduled transaction into concrete dates.
+//
+// Starting from first (normally the schedule's date_next), it returns each
+// occurrence on or before until, stopping after limit dates. A zero until
+// means no date bound and a limit of 0 means no count bound; at least one
+// bound must be set or nil is returned.
+//
+// Month-based frequencies are computed from the original anchor date and
+// clamped to the end of shorter months, so a schedule on the 31st falls on
+// Feb 28/29 and returns to the 31st in March rather than drifting.
+//
+// Unknown frequencies and "never" yield only the first date.
+//
+// Examples:
+//
+//	ProjectOccurrences(jan31, "monthly", time.Time{}, 3)  // Jan 31, Feb 29, Mar 31 (2024)
+//	ProjectOccurrences(jan1, "weekly", jan31, 0)          // Jan 1, 8, 15, 22, 29
+func ProjectOccurrences(first time.Time, frequency string, until time.Time, limit int) []time.Time {
+	if first.IsZero() || (until.IsZero() && limit <= 0) {
+		return nil
+	}
+
+	var dates []time.Time
+	for i := 0; limit <= 0 || i < limit; i++ {
+		next, ok := nthOccurrence(first, frequency, i)
+		if !ok {
+			break
+		}
+		if !until.IsZero() && next.After(until) {
+			break
+		}
+		dates = append(dates, next)
+	}
+	return dates
+}
+
+// nthOccurrence returns the i-th occurrence (0 = first) of a schedule.
+func nthOccurrence(first time.Time, frequency string, i int) (time.Time, bool) {
+	if i == 0 {
+		return first, true
+	}
+
+	switch frequency {
+	case "daily":
+		return first.AddDate(0, 0, i), true
+	case "weekly":
+		return first.AddDate(0, 0, 7*i), true
+	case "everyOtherWeek":
+		return first.AddDate(0, 0, 14*i), true
+	case "every4Weeks":
+		return first.AddDate(0, 0, 28*i), true
+	case "twiceAMonth":
+		return twiceAMonthOccurrence(first, i), true
+	case "monthly":
+		return addMonthsClamped(first, i), true
+	case "everyOtherMonth":
+		return addMonthsClamped(first, 2*i), true
+	case "every3Months":
+		return addMonthsClamped(first, 3*i), true
+	case "every4Months":
+		return addMonthsClamped(first, 4*i), true
+	case "twiceAYear":
+		return addMonthsClamped(first, 6*i), true
+	case "yearly":
+		return addMonthsClamped(first, 12*i), true
+	case "everyOtherYear":
+		return addMonthsClamped(first, 24*i), true
+	default:
+		// "never" and unrecognized frequencies occur once
+		return time.Time{}, false
+	}
+}
+
+// twiceAMonthOccurrence alternates between two days of the month 15 days
+// apart (e.g. the 1st and 16th, or the 15th and 30th).
+func twiceAMonthOccurrence(first time.Time, i int) time.Time {
+	day := first.Day()
+	low := day
+	startsHigh := false
+	if day > 15 {
+		low = day - 15
+		startsHigh = true
+	}
+
+	// Position within the low/high sequence, counting from the first low day
+	// of the anchor month
+	pos := i
+	if startsHigh {
+		pos++
+	}
+
+	anchor := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, first.Location())
+	month := anchor.AddDate(0, pos/2, 0)
+	target := low
+	if pos%2 == 1 {
+		target = low + 15
+	}
+	if last := daysInMonth(month); target > last {
+		target = last
+	}
+	return time.Date(month.Year(), month.Month(), target, 0, 0, 0, 0, first.Location())
+}
+
+// addMonthsClamped adds n months to t, clamping the day to the last day of
+// the resulting month instead of overflowing into the next one.
+func addMonthsClamped(t time.Time, n int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).AddDate(0, n, 0)
+	day := t.Day()
+	if last := daysInMonth(firstOfMonth); day > last {
+		day = last
+	}
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, 0, 0, 0, 0, t.Location())
+}
+
+// daysInMonth returns the number of days in t's month.
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+}
@@ -0,0 +1,62 @@
+package transform
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// formatDates joins dates as YYYY-MM-DD for compact comparison.
+func formatDates(dates []time.Time) string {
+	parts := make([]string, 0, len(dates))
+	for _, d := range dates {
+		parts = append(parts, FormatDate(d))
+	}
+	return strings.Join(parts, ",")
+}
+
+// TestProjectOccurrences tests expansion of scheduled transaction dates.
+func TestProjectOccurrences(t *testing.T) {
+	tests := []struct {
+		name      string
+		first     string
+		frequency string
+		until     string
+		limit     int
+		expected  string
+	}{
+		{"weekly until bound", "2024-01-01", "weekly", "2024-01-31", 0, "2024-01-01,2024-01-08,2024-01-15,2024-01-22,2024-01-29"},
+		{"monthly limit", "2024-01-15", "monthly", "", 3, "2024-01-15,2024-02-15,2024-03-15"},
+		{"monthly on the 31st", "2024-01-31", "monthly", "", 4, "2024-01-31,2024-02-29,2024-03-31,2024-04-30"},
+		{"monthly on the 31st, non-leap year", "2023-01-31", "monthly", "", 3, "2023-01-31,2023-02-28,2023-03-31"},
+		{"daily across leap day", "2024-02-27", "daily", "", 4, "2024-02-27,2024-02-28,2024-02-29,2024-03-01"},
+		{"every other week", "2024-01-05", "everyOtherWeek", "", 3, "2024-01-05,2024-01-19,2024-02-02"},
+		{"every 4 weeks", "2024-01-05", "every4Weeks", "", 3, "2024-01-05,2024-02-02,2024-03-01"},
+		{"twice a month from the 1st", "2024-01-01", "twiceAMonth", "", 4, "2024-01-01,2024-01-16,2024-02-01,2024-02-16"},
+		{"twice a month from the 16th", "2024-01-16", "twiceAMonth", "", 3, "2024-01-16,2024-02-01,2024-02-16"},
+		{"twice a month clamps the 30th", "2024-01-15", "twiceAMonth", "", 5, "2024-01-15,2024-01-30,2024-02-15,2024-02-29,2024-03-15"},
+		{"every other month from the 31st", "2023-12-31", "everyOtherMonth", "", 3, "2023-12-31,2024-02-29,2024-04-30"},
+		{"every 3 months", "2024-11-30", "every3Months", "", 3, "2024-11-30,2025-02-28,2025-05-30"},
+		{"every 4 months", "2024-01-31", "every4Months", "", 3, "2024-01-31,2024-05-31,2024-09-30"},
+		{"twice a year", "2024-08-31", "twiceAYear", "", 3, "2024-08-31,2025-02-28,2025-08-31"},
+		{"yearly on leap day", "2024-02-29", "yearly", "", 3, "2024-02-29,2025-02-28,2026-02-28"},
+		{"every other year on leap day", "2024-02-29", "everyOtherYear", "", 3, "2024-02-29,2026-02-28,2028-02-29"},
+		{"never occurs once", "2024-01-15", "never", "", 5, "2024-01-15"},
+		{"unknown frequency occurs once", "2024-01-15", "fortnightly", "", 5, "2024-01-15"},
+		{"until before first", "2024-02-01", "monthly", "2024-01-01", 0, ""},
+		{"no bounds", "2024-01-01", "daily", "", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var until time.Time
+			if tt.until != "" {
+				until = ParseDate(tt.until)
+			}
+			got := formatDates(ProjectOccurrences(ParseDate(tt.first), tt.frequency, until, tt.limit))
+			if got != tt.expected {
+				t.Errorf("ProjectOccurrences(%s, %s) = %s, want %s", tt.first, tt.frequency, got, tt.expected)
+			}
+		})
+	}
+}